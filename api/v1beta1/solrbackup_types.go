@@ -19,7 +19,13 @@ package v1beta1
 
 import (
 	"fmt"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+const (
+	DefaultBackupVerificationTimeoutMinutes int32 = 240
 )
 
 // SolrBackupSpec defines the desired state of SolrBackup
@@ -54,12 +60,45 @@ type SolrBackupSpec struct {
 	//
 	// +optional
 	Recurrence *BackupRecurrence `json:"recurrence,omitempty"`
+
+	// Verify each backup after it finishes, by restoring every collection into a temporary collection and comparing it with the source collection.
+	// The temporary collections are deleted once they have been compared.
+	// +optional
+	Verify *BackupVerification `json:"verify,omitempty"`
+
+	// ReclaimPolicy determines how the backup data in the repository will be treated after the SolrBackup is deleted.
+	//   - Retain: The backup data is kept in the repository.
+	//   - Delete: The backup points of every collection are deleted from the repository by the Solr Operator, before the SolrBackup is removed.
+	// The default value is Retain, so no backup data will be deleted unless explicitly configured.
+	// +optional
+	ReclaimPolicy BackupReclaimPolicy `json:"reclaimPolicy,omitempty"`
 }
 
 func (spec *SolrBackupSpec) withDefaults() (changed bool) {
+	if spec.ReclaimPolicy == "" {
+		changed = true
+		spec.ReclaimPolicy = BackupReclaimPolicyRetain
+	}
+	if spec.Verify != nil && spec.Verify.TimeoutMinutes == 0 {
+		changed = true
+		spec.Verify.TimeoutMinutes = DefaultBackupVerificationTimeoutMinutes
+	}
 	return changed
 }
 
+// BackupReclaimPolicy is a string enumeration type that enumerates
+// all possible ways that a SolrBackup can treat its backup data after its death
+// +kubebuilder:validation:Enum=Retain;Delete
+type BackupReclaimPolicy string
+
+const (
+	// The backup data is retained in the repository after the SolrBackup is deleted.
+	BackupReclaimPolicyRetain BackupReclaimPolicy = "Retain"
+
+	// The backup data is deleted from the repository after the SolrBackup is deleted.
+	BackupReclaimPolicyDelete BackupReclaimPolicy = "Delete"
+)
+
 // BackupRecurrence defines the recurrence of the incremental backup
 type BackupRecurrence struct {
 	// Perform a backup on the given schedule, in CRON format.
@@ -75,6 +114,8 @@ type BackupRecurrence struct {
 
 	// Define the number of backup points to save for this backup at any given time.
 	// The oldest backups will be deleted if too many exist when a backup is taken.
+	// If a retention is provided, then the backup points are instead deleted according to the retention,
+	// and this only limits the number of backups kept in the status history.
 	// If not provided, this defaults to 5.
 	//
 	// +kubebuilder:default:=5
@@ -82,6 +123,10 @@ type BackupRecurrence struct {
 	// +optional
 	MaxSaved int `json:"maxSaved,omitempty"`
 
+	// Delete backup points based on their age, instead of keeping only the latest maxSaved backup points.
+	// +optional
+	Retention *BackupRetention `json:"retention,omitempty"`
+
 	// Disable the recurring backups. Note this will not affect any currently-running backup.
 	//
 	// +kubebuilder:default:=false
@@ -89,10 +134,94 @@ type BackupRecurrence struct {
 	Disabled bool `json:"disabled,omitempty"`
 }
 
+// BackupRetention defines which backup points of a recurring backup are kept, based on their age.
+// A backup point is deleted once none of the rules keep it, however the newest backup point is always kept.
+// Days and months are UTC calendar days and months, and weeks are ISO weeks, each including the current one.
+// For example, "keep daily backups for 14 days, and weekly backups for 3 months" is keepDaily: 14, keepWeekly: 13.
+type BackupRetention struct {
+	// Keep every backup point that was taken within this many hours.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	KeepWithinHours int32 `json:"keepWithinHours,omitempty"`
+
+	// Keep the newest backup point of each of the last N days.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	KeepDaily int32 `json:"keepDaily,omitempty"`
+
+	// Keep the newest backup point of each of the last N weeks.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	KeepWeekly int32 `json:"keepWeekly,omitempty"`
+
+	// Keep the newest backup point of each of the last N months.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	KeepMonthly int32 `json:"keepMonthly,omitempty"`
+}
+
+// BackupVerification defines how the backups are verified, by test-restoring them into temporary collections
+type BackupVerification struct {
+	// The SolrCloud, in the same namespace, to restore the backups into for verification. Defaults to the SolrCloud that is backed up.
+	// This SolrCloud must define a backup repository with the same name as the one used for the backup, that points to the same storage.
+	//
+	// +kubebuilder:validation:Pattern:=[a-z0-9]([-a-z0-9]*[a-z0-9])?
+	// +kubebuilder:validation:MaxLength:=63
+	// +optional
+	SolrCloud string `json:"solrCloud,omitempty"`
+
+	// A query to run against both the source and the restored collections, e.g. "type:product".
+	// The numbers of documents that it matches are compared, in addition to the total numbers of documents.
+	// +optional
+	Query string `json:"query,omitempty"`
+
+	// The percentage by which the numbers of documents of the restored collection may differ from those of the source collection.
+	// The source collection is counted as soon as its backup finishes, so this only needs to allow for documents
+	// that are added or deleted while the backup is being taken.
+	// Defaults to 0, so the numbers of documents must match exactly.
+	//
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	// +optional
+	DocCountTolerancePercent int32 `json:"docCountTolerancePercent,omitempty"`
+
+	// The number of minutes, after the backup finishes, within which the verification must finish.
+	// Verifications that have not finished by then, for example because the verification SolrCloud does not exist, are failed,
+	// so that they do not stop the next recurring backups from being taken.
+	// Defaults to 240.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	TimeoutMinutes int32 `json:"timeoutMinutes,omitempty"`
+}
+
+// Timeout returns how long after the backup finishes its verification must finish by.
+func (verify *BackupVerification) Timeout() time.Duration {
+	if verify == nil || verify.TimeoutMinutes == 0 {
+		return time.Duration(DefaultBackupVerificationTimeoutMinutes) * time.Minute
+	}
+	return time.Duration(verify.TimeoutMinutes) * time.Minute
+}
+
 func (recurrence *BackupRecurrence) IsEnabled() bool {
 	return recurrence != nil && !recurrence.Disabled
 }
 
+// HasRetention returns whether the backup points should be deleted based on their age, which requires at least one retention rule.
+// Otherwise, the latest maxSaved backup points are kept.
+func (recurrence *BackupRecurrence) HasRetention() bool {
+	return recurrence != nil && recurrence.Retention != nil && !recurrence.Retention.IsEmpty()
+}
+
+// IsEmpty returns whether the retention has no rules, in which case it cannot keep any backup points.
+func (retention *BackupRetention) IsEmpty() bool {
+	return retention.KeepWithinHours == 0 && retention.KeepDaily == 0 && retention.KeepWeekly == 0 && retention.KeepMonthly == 0
+}
+
 // SolrBackupStatus defines the observed state of SolrBackup
 type SolrBackupStatus struct {
 	// The current Backup Status, which all fields are added to this struct
@@ -105,6 +234,50 @@ type SolrBackupStatus struct {
 	// The status history of recurring backups
 	// +optional
 	History []IndividualSolrBackupStatus `json:"history,omitempty"`
+
+	// The backup points that exist in the backup repository for each collection of this backup, as listed by Solr.
+	// This is refreshed periodically, and whenever a backup finishes.
+	// +optional
+	Catalog []CollectionBackupCatalog `json:"catalog,omitempty"`
+
+	// The time that the catalog was last refreshed
+	// +optional
+	CatalogRefreshTime *metav1.Time `json:"catalogRefreshTimestamp,omitempty"`
+}
+
+// CollectionBackupCatalog lists the backup points of a Solr Collection's backup that exist in the backup repository
+type CollectionBackupCatalog struct {
+	// Solr Collection name
+	Collection string `json:"collection"`
+
+	// BackupName of this collection's backup in Solr
+	BackupName string `json:"backupName"`
+
+	// The backup points of the collection's backup, from oldest to newest
+	// +optional
+	BackupPoints []BackupPoint `json:"backupPoints,omitempty"`
+}
+
+// BackupPoint describes a single point of an incremental backup, which can be restored from
+type BackupPoint struct {
+	// The ID of the backup point in Solr
+	BackupId int `json:"backupId"`
+
+	// Time that the backup point was started
+	// +optional
+	StartTime *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// The size of the index files in the backup point
+	// +optional
+	IndexSize *resource.Quantity `json:"indexSize,omitempty"`
+
+	// The number of index files in the backup point
+	// +optional
+	IndexFileCount int `json:"indexFileCount,omitempty"`
+
+	// Version of the Solr that took the backup point
+	// +optional
+	SolrVersion string `json:"solrVersion,omitempty"`
 }
 
 // IndividualSolrBackupStatus defines the observed state of a single issued SolrBackup
@@ -132,6 +305,10 @@ type IndividualSolrBackupStatus struct {
 	// Whether the backup has finished
 	// +optional
 	Finished bool `json:"finished,omitempty"`
+
+	// Whether the backup of every collection has been verified successfully, only set if the backup is verified
+	// +optional
+	Verified *bool `json:"verified,omitempty"`
 }
 
 // CollectionBackupStatus defines the progress of a Solr Collection's backup
@@ -165,6 +342,63 @@ type CollectionBackupStatus struct {
 	// Whether the backup was successful
 	// +optional
 	Successful *bool `json:"successful,omitempty"`
+
+	// The verification of the collection's backup, by test-restoring it
+	// +optional
+	Verification *CollectionBackupVerification `json:"verification,omitempty"`
+}
+
+// CollectionBackupVerification defines the progress and result of the verification of a Solr Collection's backup
+type CollectionBackupVerification struct {
+	// The SolrCloud that the backup is restored into
+	SolrCloud string `json:"solrCloud"`
+
+	// The temporary collection that the backup is restored into
+	ScratchCollection string `json:"scratchCollection"`
+
+	// Whether the backup is being restored
+	// +optional
+	InProgress bool `json:"inProgress,omitempty"`
+
+	// The status of the asynchronous restore call to solr
+	// +optional
+	AsyncRestoreStatus string `json:"asyncRestoreStatus,omitempty"`
+
+	// Time that the verification started at
+	// +optional
+	StartTime *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// The number of documents in the source collection, counted when its backup finished
+	// +optional
+	SourceNumDocs *int64 `json:"sourceNumDocs,omitempty"`
+
+	// The number of documents in the restored collection
+	// +optional
+	RestoredNumDocs *int64 `json:"restoredNumDocs,omitempty"`
+
+	// The number of documents in the source collection that match the verification query, counted when its backup finished
+	// +optional
+	SourceQueryNumFound *int64 `json:"sourceQueryNumFound,omitempty"`
+
+	// The number of documents in the restored collection that match the verification query
+	// +optional
+	RestoredQueryNumFound *int64 `json:"restoredQueryNumFound,omitempty"`
+
+	// The reason that the verification failed
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Whether the verification has finished, and the temporary collection has been deleted
+	// +optional
+	Finished bool `json:"finished,omitempty"`
+
+	// Time that the verification finished at
+	// +optional
+	FinishTime *metav1.Time `json:"finishTimestamp,omitempty"`
+
+	// Whether the verification was successful
+	// +optional
+	Successful *bool `json:"successful,omitempty"`
 }
 
 func (sb *SolrBackup) SharedLabels() map[string]string {
@@ -198,6 +432,7 @@ func (sb *SolrBackup) PersistenceJobName() string {
 //+kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTimestamp",description="Most recent time the backup started"
 //+kubebuilder:printcolumn:name="Finished",type="boolean",JSONPath=".status.finished",description="Whether the most recent backup has finished"
 //+kubebuilder:printcolumn:name="Successful",type="boolean",JSONPath=".status.successful",description="Whether the most recent backup was successful"
+//+kubebuilder:printcolumn:name="Verified",type="boolean",JSONPath=".status.verified",description="Whether the most recent backup was verified successfully",priority=1
 //+kubebuilder:printcolumn:name="NextBackup",type="string",JSONPath=".status.nextScheduledTime",description="Next scheduled time for a recurrent backup",format="date-time"
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
@@ -0,0 +1,168 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"github.com/robfig/cron/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks for SolrBackups.
+func (sb *SolrBackup) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(sb).
+		WithDefaulter(&solrBackupDefaulter{}).
+		WithValidator(&solrBackupValidator{reader: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-solr-apache-org-v1beta1-solrbackup,mutating=true,failurePolicy=fail,sideEffects=None,groups=solr.apache.org,resources=solrbackups,verbs=create;update,versions=v1beta1,name=msolrbackup.solr.apache.org,admissionReviewVersions=v1
+
+type solrBackupDefaulter struct{}
+
+var _ admission.CustomDefaulter = &solrBackupDefaulter{}
+
+// Default sets the default values for a SolrBackup when it is created or updated.
+func (d *solrBackupDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	backup, ok := obj.(*SolrBackup)
+	if !ok {
+		return fmt.Errorf("expected a SolrBackup but got a %T", obj)
+	}
+	backup.WithDefaults()
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-solr-apache-org-v1beta1-solrbackup,mutating=false,failurePolicy=fail,sideEffects=None,groups=solr.apache.org,resources=solrbackups,verbs=create;update,versions=v1beta1,name=vsolrbackup.solr.apache.org,admissionReviewVersions=v1
+
+type solrBackupValidator struct {
+	// reader is used to look up the SolrCloud that a SolrBackup references
+	reader client.Reader
+}
+
+var _ admission.CustomValidator = &solrBackupValidator{}
+
+// ValidateCreate validates a new SolrBackup, including whether the SolrCloud has the requested backup repository.
+func (v *solrBackupValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	backup, ok := obj.(*SolrBackup)
+	if !ok {
+		return nil, fmt.Errorf("expected a SolrBackup but got a %T", obj)
+	}
+	return v.validate(ctx, backup, nil)
+}
+
+// ValidateUpdate validates an updated SolrBackup, including the fields that cannot be changed after creation.
+func (v *solrBackupValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	backup, ok := newObj.(*SolrBackup)
+	if !ok {
+		return nil, fmt.Errorf("expected a SolrBackup but got a %T", newObj)
+	}
+	oldBackup, ok := oldObj.(*SolrBackup)
+	if !ok {
+		return nil, fmt.Errorf("expected a SolrBackup but got a %T", oldObj)
+	}
+	return v.validate(ctx, backup, oldBackup)
+}
+
+// ValidateDelete does nothing, SolrBackups can always be deleted.
+func (v *solrBackupValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *solrBackupValidator) validate(ctx context.Context, backup *SolrBackup, oldBackup *SolrBackup) (warnings admission.Warnings, err error) {
+	allErrs := backup.validateSpec()
+	if oldBackup != nil {
+		allErrs = append(allErrs, backup.validateImmutableFields(oldBackup)...)
+	} else {
+		var repoErrs field.ErrorList
+		repoErrs, warnings = v.validateBackupRepository(ctx, backup)
+		allErrs = append(allErrs, repoErrs...)
+	}
+	if len(allErrs) > 0 {
+		err = apierrors.NewInvalid(GroupVersion.WithKind("SolrBackup").GroupKind(), backup.Name, allErrs)
+	}
+	return warnings, err
+}
+
+// validateBackupRepository checks that the referenced SolrCloud has the backup repository that the SolrBackup uses.
+// If the SolrCloud cannot be found, the SolrBackup is accepted with a warning, since the SolrCloud might be created afterwards.
+func (v *solrBackupValidator) validateBackupRepository(ctx context.Context, backup *SolrBackup) (allErrs field.ErrorList, warnings admission.Warnings) {
+	if v.reader == nil {
+		return nil, nil
+	}
+	solrCloud := &SolrCloud{}
+	if err := v.reader.Get(ctx, types.NamespacedName{Namespace: backup.Namespace, Name: backup.Spec.SolrCloud}, solrCloud); err != nil {
+		if apierrors.IsNotFound(err) {
+			warnings = append(warnings, fmt.Sprintf("SolrCloud %s does not exist, the backup will not be taken until it is created", backup.Spec.SolrCloud))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("Could not check the backupRepositories of SolrCloud %s: %s", backup.Spec.SolrCloud, err.Error()))
+		}
+		return nil, warnings
+	}
+
+	repositoryPath := field.NewPath("spec", "repositoryName")
+	if backup.Spec.RepositoryName == "" {
+		if len(solrCloud.Spec.BackupRepositories) != 1 {
+			allErrs = append(allErrs, field.Required(repositoryPath, fmt.Sprintf("must be specified, since SolrCloud %s does not have exactly one backupRepository", solrCloud.Name)))
+		}
+		return allErrs, nil
+	}
+	for _, repo := range solrCloud.Spec.BackupRepositories {
+		if repo.Name == backup.Spec.RepositoryName {
+			return nil, nil
+		}
+	}
+	allErrs = append(allErrs, field.NotFound(repositoryPath, backup.Spec.RepositoryName))
+	return allErrs, nil
+}
+
+// validateSpec finds invalid options in the SolrBackup spec that are not caught by the CRD schema.
+func (sb *SolrBackup) validateSpec() (allErrs field.ErrorList) {
+	if sb.Spec.Recurrence != nil {
+		if _, err := cron.ParseStandard(sb.Spec.Recurrence.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "recurrence", "schedule"), sb.Spec.Recurrence.Schedule, err.Error()))
+		}
+		if retention := sb.Spec.Recurrence.Retention; retention != nil && retention.IsEmpty() {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "recurrence", "retention"), "at least one of keepWithinHours, keepDaily, keepWeekly or keepMonthly must be specified"))
+		}
+	}
+	return allErrs
+}
+
+// validateImmutableFields finds changes to options that cannot be changed once the SolrBackup has been created.
+// Changing these would leave the existing backup data and status pointing at a different location than new backups.
+func (sb *SolrBackup) validateImmutableFields(oldBackup *SolrBackup) (allErrs field.ErrorList) {
+	specPath := field.NewPath("spec")
+	if sb.Spec.SolrCloud != oldBackup.Spec.SolrCloud {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("solrCloud"), "cannot be changed once the SolrBackup has been created"))
+	}
+	if sb.Spec.RepositoryName != oldBackup.Spec.RepositoryName {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("repositoryName"), "cannot be changed once the SolrBackup has been created"))
+	}
+	if sb.Spec.Location != oldBackup.Spec.Location {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("location"), "cannot be changed once the SolrBackup has been created"))
+	}
+	return allErrs
+}
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestSolrBackupWebhookValidateRepository(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if !assert.NoError(t, AddToScheme(scheme), "Could not build scheme") {
+		return
+	}
+	solrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			BackupRepositories: []SolrBackupRepository{
+				{Name: "gcs-repo", GCS: &GcsRepository{Bucket: "bucket"}},
+				{Name: "s3-repo", S3: &S3Repository{Bucket: "bucket", Region: "us-west-2"}},
+			},
+		},
+	}
+	validator := &solrBackupValidator{reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(solrCloud).Build()}
+
+	backup := &SolrBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+		Spec: SolrBackupSpec{
+			SolrCloud:      "foo",
+			RepositoryName: "s3-repo",
+		},
+	}
+	warnings, err := validator.ValidateCreate(context.Background(), backup)
+	assert.NoError(t, err, "Backup using an existing repository should be valid")
+	assert.Empty(t, warnings, "There should be no warnings for a valid backup")
+
+	backup.Spec.RepositoryName = "missing-repo"
+	_, err = validator.ValidateCreate(context.Background(), backup)
+	if assert.Error(t, err, "Backup using a missing repository should be rejected") {
+		assert.Contains(t, err.Error(), "spec.repositoryName: Not found: \"missing-repo\"", "Wrong error for a missing repository")
+	}
+
+	backup.Spec.RepositoryName = ""
+	_, err = validator.ValidateCreate(context.Background(), backup)
+	assert.Error(t, err, "Backup without a repositoryName should be rejected when the SolrCloud has multiple repositories")
+
+	backup.Spec.SolrCloud = "bar"
+	warnings, err = validator.ValidateCreate(context.Background(), backup)
+	assert.NoError(t, err, "Backup for a SolrCloud that does not exist yet should be accepted")
+	assert.Len(t, warnings, 1, "Backup for a SolrCloud that does not exist yet should return a warning")
+}
+
+func TestSolrBackupWebhookValidateUpdate(t *testing.T) {
+	oldBackup := &SolrBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+		Spec: SolrBackupSpec{
+			SolrCloud:      "foo",
+			RepositoryName: "s3-repo",
+			Location:       "backups",
+			Recurrence: &BackupRecurrence{
+				Schedule: "@daily",
+			},
+		},
+	}
+	validator := &solrBackupValidator{}
+
+	newBackup := oldBackup.DeepCopy()
+	newBackup.Spec.Collections = []string{"col1"}
+	newBackup.Spec.Recurrence.Disabled = true
+	_, err := validator.ValidateUpdate(context.Background(), oldBackup, newBackup)
+	assert.NoError(t, err, "Changing mutable fields should be allowed")
+
+	newBackup.Spec.RepositoryName = "gcs-repo"
+	newBackup.Spec.Location = "other"
+	newBackup.Spec.Recurrence.Schedule = "every day"
+	_, err = validator.ValidateUpdate(context.Background(), oldBackup, newBackup)
+	if assert.Error(t, err, "Changing immutable fields should be rejected") {
+		assert.Contains(t, err.Error(), "spec.repositoryName: Forbidden", "Changing the repositoryName should be rejected")
+		assert.Contains(t, err.Error(), "spec.location: Forbidden", "Changing the location should be rejected")
+		assert.Contains(t, err.Error(), "spec.recurrence.schedule: Invalid value", "An invalid schedule should be rejected")
+	}
+}
+
+func TestSolrBackupWebhookValidateRetention(t *testing.T) {
+	backup := &SolrBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+		Spec: SolrBackupSpec{
+			SolrCloud: "foo",
+			Recurrence: &BackupRecurrence{
+				Schedule:  "@daily",
+				Retention: &BackupRetention{KeepDaily: 14, KeepWeekly: 13},
+			},
+		},
+	}
+	backup.WithDefaults()
+	assert.Equal(t, BackupReclaimPolicyRetain, backup.Spec.ReclaimPolicy, "The backup data should be retained by default")
+	validator := &solrBackupValidator{}
+
+	_, err := validator.ValidateCreate(context.Background(), backup)
+	assert.NoError(t, err, "A tiered retention should be valid")
+
+	backup.Spec.Recurrence.Retention = &BackupRetention{}
+	_, err = validator.ValidateCreate(context.Background(), backup)
+	if assert.Error(t, err, "A retention without any rules should be rejected") {
+		assert.Contains(t, err.Error(), "spec.recurrence.retention: Required value", "Wrong error for an empty retention")
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -43,6 +44,14 @@ const (
 	DefaultSolrLogLevel = "INFO"
 	DefaultSolrGCTune   = ""
 
+	DefaultDeletionSnapshotRetentionHours = int32(168)
+
+	DefaultAutoscalerMinReplicas              = int32(1)
+	DefaultAutoscalerCPUUtilizationPercentage = int32(80)
+	DefaultAutoscalerScaleDownCooldownSeconds = int32(300)
+	DefaultAutoscalerQueryRateMetricName      = "solr_metrics_core_requests_per_second"
+	DefaultAutoscalerDiskUsageMetricName      = "solr_metrics_core_index_size_bytes"
+
 	DefaultBusyBoxImageRepo    = "library/busybox"
 	DefaultBusyBoxImageVersion = "1.28.0-glibc"
 
@@ -84,10 +93,23 @@ type SolrCloudSpec struct {
 	// +optional
 	CustomSolrKubeOptions CustomSolrKubeOptions `json:"customSolrKubeOptions,omitempty"`
 
+	// Additional pools of Solr Nodes to run in the SolrCloud, each managed by its own StatefulSet.
+	// The replicas, dataStorage and customSolrKubeOptions.podOptions at the top level of the spec are used for the default node pool,
+	// and are inherited by node pools that do not provide their own.
+	//
+	//+optional
+	//+listType:=map
+	//+listMapKey:=name
+	NodePools []SolrNodePool `json:"nodePools,omitempty"`
+
 	// Customize how Solr is addressed both internally and externally in Kubernetes.
 	// +optional
 	SolrAddressability SolrAddressabilityOptions `json:"solrAddressability,omitempty"`
 
+	// Create NetworkPolicies that only allow the expected traffic to the Solr pods and the pods of the provided Zookeeper ensemble.
+	// +optional
+	NetworkPolicy *SolrNetworkPolicyOptions `json:"networkPolicy,omitempty"`
+
 	// Define how Solr rolling updates are executed.
 	// +optional
 	UpdateStrategy SolrUpdateStrategy `json:"updateStrategy,omitempty"`
@@ -198,6 +220,14 @@ func (spec *SolrCloudSpec) withDefaults(logger logr.Logger) (changed bool) {
 
 	changed = spec.StorageOptions.withDefaults() || changed
 
+	if spec.Scaling.Autoscaler != nil {
+		changed = spec.Scaling.Autoscaler.withDefaults() || changed
+	}
+
+	for i := range spec.NodePools {
+		changed = spec.NodePools[i].withDefaults() || changed
+	}
+
 	if spec.BusyBoxImage == nil {
 		c := ContainerImage{}
 		spec.BusyBoxImage = &c
@@ -225,7 +255,7 @@ type CustomSolrKubeOptions struct {
 	HeadlessServiceOptions *ServiceOptions `json:"headlessServiceOptions,omitempty"`
 
 	// NodeServiceOptions defines the custom options for the individual solrCloud Node services, if they are created.
-	// These services will only be created when exposing SolrNodes externally via an Ingress in the AddressabilityOptions.
+	// These services will only be created when exposing SolrNodes externally via an Ingress or LoadBalancer in the AddressabilityOptions.
 	// +optional
 	NodeServiceOptions *ServiceOptions `json:"nodeServiceOptions,omitempty"`
 
@@ -238,6 +268,65 @@ type CustomSolrKubeOptions struct {
 	IngressOptions *IngressOptions `json:"ingressOptions,omitempty"`
 }
 
+// SolrNodePool is a group of Solr Nodes in a SolrCloud that share the same pod and storage options.
+type SolrNodePool struct {
+	// The name of the node pool, used in the names of the StatefulSet and pods for the pool.
+	//
+	//+kubebuilder:validation:Pattern:=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	//+kubebuilder:validation:MaxLength:=30
+	Name string `json:"name"`
+
+	// The number of Solr Nodes to run in the node pool
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Custom options for the pods in the node pool.
+	// If not provided, the podOptions in spec.customSolrKubeOptions are used.
+	// +optional
+	PodOptions *PodOptions `json:"podOptions,omitempty"`
+
+	// Customize how the data is stored for the Solr Nodes in the node pool.
+	// If not provided, spec.dataStorage is used.
+	// Node pools must use the same type of storage, persistent or ephemeral, as spec.dataStorage.
+	//
+	// +optional
+	StorageOptions *SolrDataStorageOptions `json:"dataStorage,omitempty"`
+
+	// Labels to give the Solr Nodes in the node pool.
+	// These are passed to Solr as system properties, so that replica placement plugins can use them to choose nodes for replicas.
+	// E.g. "replica_type: PULL" or "node_type: hot"
+	// +optional
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+}
+
+func (pool *SolrNodePool) withDefaults() (changed bool) {
+	if pool.Replicas == nil {
+		changed = true
+		r := DefaultSolrReplicas
+		pool.Replicas = &r
+	}
+
+	if pool.StorageOptions != nil {
+		changed = pool.StorageOptions.withDefaults() || changed
+	}
+
+	return changed
+}
+
+// UsesPersistentStorage returns whether the Solr Nodes in the node pool use persistent storage for their data.
+func (pool *SolrNodePool) UsesPersistentStorage() bool {
+	return pool.StorageOptions != nil && pool.StorageOptions.PersistentStorage != nil
+}
+
+// DataVolumeName returns the name of the volume that the Solr Nodes in the node pool use for their data.
+func (pool *SolrNodePool) DataVolumeName() string {
+	if pool.UsesPersistentStorage() && pool.StorageOptions.PersistentStorage.PersistentVolumeClaimTemplate.ObjectMeta.Name != "" {
+		return pool.StorageOptions.PersistentStorage.PersistentVolumeClaimTemplate.ObjectMeta.Name
+	} else {
+		return "data"
+	}
+}
+
 type SolrDataStorageOptions struct {
 
 	// PersistentStorage is the specification for how the persistent Solr data storage should be configured.
@@ -279,6 +368,11 @@ type SolrPersistentDataStorageOptions struct {
 	// This field is optional. If no PVC spec is provided, then a default will be provided.
 	// +optional
 	PersistentVolumeClaimTemplate PersistentVolumeClaimTemplate `json:"pvcTemplate,omitempty"`
+
+	// DeletionSnapshots has the Solr Operator take a CSI VolumeSnapshot of each data PVC, before it deletes the PVC.
+	// This only applies when the reclaimPolicy is "Delete", since PVCs are otherwise never deleted by the Solr Operator.
+	// +optional
+	DeletionSnapshots *SolrDataVolumeSnapshotOptions `json:"deletionSnapshots,omitempty"`
 }
 
 func (opts *SolrPersistentDataStorageOptions) withDefaults() (changed bool) {
@@ -287,9 +381,36 @@ func (opts *SolrPersistentDataStorageOptions) withDefaults() (changed bool) {
 		opts.VolumeReclaimPolicy = VolumeReclaimPolicyRetain
 	}
 
+	if opts.DeletionSnapshots != nil && opts.DeletionSnapshots.RetentionHours == nil {
+		changed = true
+		r := DefaultDeletionSnapshotRetentionHours
+		opts.DeletionSnapshots.RetentionHours = &r
+	}
+
 	return changed
 }
 
+type SolrDataVolumeSnapshotOptions struct {
+	// The name of the VolumeSnapshotClass to use for the snapshots.
+	// If not provided, the default VolumeSnapshotClass for the CSI driver of the PVC will be used.
+	// +optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+
+	// The number of hours to keep each snapshot, after which the Solr Operator will delete it.
+	// Snapshots are only deleted while the SolrCloud exists.
+	//
+	// Defaults to 168 (7 days).
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RetentionHours *int32 `json:"retentionHours,omitempty"`
+
+	// When a Solr pod is created for an ordinal that does not have a data PVC, such as when scaling back up,
+	// create the data PVC from the latest snapshot that was taken of that ordinal's previous data PVC.
+	// +optional
+	RestoreOnRecreate bool `json:"restoreOnRecreate,omitempty"`
+}
+
 // VolumeReclaimPolicy is a string enumeration type that enumerates
 // all possible ways that a SolrCloud can treat it's PVCs after its death
 // +kubebuilder:validation:Enum=Retain;Delete
@@ -378,6 +499,14 @@ type SolrBackupRepository struct {
 	//+optional
 	S3 *S3Repository `json:"s3,omitempty"`
 
+	// An AzureRepository for Solr to use when backing up and restoring collections, in Azure Blob Storage.
+	//+optional
+	Azure *AzureRepository `json:"azure,omitempty"`
+
+	// An HdfsRepository for Solr to use when backing up and restoring collections, in HDFS.
+	//+optional
+	HDFS *HdfsRepository `json:"hdfs,omitempty"`
+
 	// Allows specification of a "repository" for Solr to use when backing up data "locally".
 	//+optional
 	Volume *VolumeRepository `json:"volume,omitempty"`
@@ -445,6 +574,65 @@ type S3Credentials struct {
 	CredentialsFileSecret *corev1.SecretKeySelector `json:"credentialsFileSecret,omitempty"`
 }
 
+type AzureRepository struct {
+	// The name of the Azure Blob Storage container that all backup data will be stored in
+	Container string `json:"container"`
+
+	// The name of the Azure Storage account that the container belongs to
+	AccountName string `json:"accountName"`
+
+	// Options for specifying Azure credentials. This is optional in case you want to load this information yourself,
+	// such as through Azure Workload Identity, with the serviceAccountName of your SolrCloud.
+	//
+	// +optional
+	Credentials *AzureCredentials `json:"credentials,omitempty"`
+
+	// An already-created chroot within the container to store data in. Defaults to the root path "/" if not specified.
+	// +optional
+	BaseLocation string `json:"baseLocation,omitempty"`
+
+	// The full endpoint URL of the Blob service, such as the URL of an Azurite emulator, e.g. "http://azurite:10000/devstoreaccount1".
+	// Defaults to "https://<accountName>.blob.core.windows.net" if not specified.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+type AzureCredentials struct {
+	// The name & key of a Kubernetes secret holding the Azure Storage account key
+	// +optional
+	AccountKeySecret *corev1.SecretKeySelector `json:"accountKeySecret,omitempty"`
+
+	// The name & key of a Kubernetes secret holding a shared access signature (SAS) token for the container
+	// +optional
+	SasTokenSecret *corev1.SecretKeySelector `json:"sasTokenSecret,omitempty"`
+}
+
+type HdfsRepository struct {
+	// The URI of the HDFS NameNode, or of the nameservice if HA is used, e.g. "hdfs://namenode:8020"
+	// +kubebuilder:validation:Pattern:=`^[a-z]+://[^/]+$`
+	NameNodeUri string `json:"nameNodeUri"`
+
+	// An already-created path within HDFS to store data in. Defaults to the root path "/" if not specified.
+	// +optional
+	BasePath string `json:"basePath,omitempty"`
+
+	// The name of a ConfigMap holding the Hadoop configuration files, such as core-site.xml and hdfs-site.xml, that Solr should use to connect to HDFS.
+	// +optional
+	HadoopConfigMap string `json:"hadoopConfigMap,omitempty"`
+
+	// Options for authenticating to a Kerberized HDFS cluster.
+	// +optional
+	Kerberos *HdfsKerberosOptions `json:"kerberos,omitempty"`
+}
+
+type HdfsKerberosOptions struct {
+	// The Kerberos principal that Solr should authenticate to HDFS as, e.g. "solr/_HOST@EXAMPLE.COM"
+	Principal string `json:"principal"`
+
+	// The name & key of a Kubernetes secret holding the Kerberos keytab for the principal
+	KeytabSecret corev1.SecretKeySelector `json:"keytabSecret"`
+}
+
 type VolumeRepository struct {
 	// This is a volumeSource for a volume that will be mounted to all solrNodes to store backups and load restores.
 	// The data within the volume will be namespaced for this instance, so feel free to use the same volume for multiple clouds.
@@ -533,6 +721,9 @@ type ExternalAddressability struct {
 	//
 	// For the LoadBalancer method, this field is optional and will only be used when useExternalAddress=true.
 	// If used with the LoadBalancer method, you will need DNS routing to the LoadBalancer IP address through the url template given above.
+	//
+	// This field is required for the Ingress and ExternalDNS methods.
+	// +optional
 	DomainName string `json:"domainName"`
 
 	// Provide additional domainNames that the Ingress or ExternalDNS should listen on.
@@ -562,11 +753,25 @@ type ExternalAddressability struct {
 	//
 	// +optional
 	IngressTLSTermination *SolrIngressTLSTermination `json:"ingressTLSTermination,omitempty"`
+
+	// LoadBalancer defines the options for the LoadBalancer Services that expose the SolrCloud.
+	//
+	// This is option is only available when Method=LoadBalancer.
+	//
+	// +optional
+	LoadBalancer *SolrLoadBalancerOptions `json:"loadBalancer,omitempty"`
+
+	// Gateway defines the Gateway API Gateway that the SolrCloud's routes attach to.
+	//
+	// This is option is required when Method=GatewayAPI, and unavailable otherwise.
+	//
+	// +optional
+	Gateway *SolrGatewayOptions `json:"gateway,omitempty"`
 }
 
 // ExternalAddressabilityMethod is a string enumeration type that enumerates
 // all possible ways that a SolrCloud can be made addressable external to the kubernetes cluster.
-// +kubebuilder:validation:Enum=Ingress;ExternalDNS
+// +kubebuilder:validation:Enum=Ingress;ExternalDNS;LoadBalancer;GatewayAPI
 type ExternalAddressabilityMethod string
 
 const (
@@ -577,8 +782,10 @@ const (
 	ExternalDNS ExternalAddressabilityMethod = "ExternalDNS"
 
 	// Make Solr service(s) type:LoadBalancer to make them externally addressable
-	// NOTE: This option is not currently supported.
 	LoadBalancer ExternalAddressabilityMethod = "LoadBalancer"
+
+	// Use Gateway API routes, attached to an existing Gateway, to make the Solr service(s) externally addressable
+	GatewayAPI ExternalAddressabilityMethod = "GatewayAPI"
 )
 
 func (opts *ExternalAddressability) withDefaults(usesTLS bool, logger logr.Logger) (changed bool) {
@@ -588,8 +795,8 @@ func (opts *ExternalAddressability) withDefaults(usesTLS bool, logger logr.Logge
 		opts.UseExternalAddress = false
 	}
 
-	// If the Ingress method is used, default the nodePortOverride to 80 or 443, since that is the port that most ingress controllers listen on.
-	if !opts.HideNodes && opts.Method == Ingress && opts.NodePortOverride == 0 {
+	// If the Ingress or GatewayAPI method is used, default the nodePortOverride to 80 or 443, since that is the port that most ingress controllers and gateways listen on.
+	if !opts.HideNodes && opts.UsesHostnameRouting() && opts.NodePortOverride == 0 {
 		changed = true
 		if usesTLS {
 			opts.NodePortOverride = 443
@@ -625,6 +832,69 @@ type SolrIngressTLSTermination struct {
 	TLSSecret string `json:"tlsSecret,omitempty"`
 }
 
+// SolrLoadBalancerOptions defines the options for the LoadBalancer Services of a SolrCloud using the LoadBalancer external addressability method.
+type SolrLoadBalancerOptions struct {
+	// LoadBalancerSourceRanges restricts the client IP ranges that can access the LoadBalancer Services, if supported by the LoadBalancer implementation.
+	//
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// CommonServiceAnnotations are added to the common LoadBalancer Service.
+	// These can be used to request a static IP address for the common endpoint, e.g. "metallb.universe.tf/loadBalancerIPs".
+	//
+	// +optional
+	CommonServiceAnnotations map[string]string `json:"commonServiceAnnotations,omitempty"`
+
+	// NodeServiceAnnotations are added to the LoadBalancer Service of individual Solr Nodes, keyed by the name of the Solr pod.
+	// These can be used to request a static IP address for each Solr Node, e.g. "metallb.universe.tf/loadBalancerIPs".
+	// Annotations for all Solr Nodes can be provided through customSolrKubeOptions.nodeServiceOptions.
+	//
+	// +optional
+	NodeServiceAnnotations map[string]map[string]string `json:"nodeServiceAnnotations,omitempty"`
+}
+
+// SolrGatewayOptions defines how the routes of a SolrCloud using the GatewayAPI external addressability method attach to a Gateway.
+type SolrGatewayOptions struct {
+	// ParentRef is the Gateway, and optionally the listener of the Gateway, that the SolrCloud's routes attach to.
+	// HTTPRoutes are used when Solr serves HTTP, and TLSRoutes, for TLS passthrough, when the SolrCloud has TLS enabled via `spec.solrTLS`.
+	ParentRef SolrGatewayParentReference `json:"parentRef"`
+}
+
+// SolrGatewayParentReference identifies a Gateway API Gateway.
+type SolrGatewayParentReference struct {
+	// The name of the Gateway.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The namespace of the Gateway, defaults to the namespace of the SolrCloud.
+	// The Gateway must allow routes from the SolrCloud's namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// The name of the listener of the Gateway to attach to.
+	// If not provided, the routes will attach to all listeners of the Gateway that accept them.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// SolrNetworkPolicyOptions configures the NetworkPolicies that the Solr Operator creates for a SolrCloud.
+// Traffic between the Solr pods, from the Solr Operator and from the SolrPrometheusExporters that reference the SolrCloud is always allowed.
+type SolrNetworkPolicyOptions struct {
+	// Additional peers that are allowed to connect to the Solr pods, such as an Ingress controller or the applications that query Solr.
+	// +optional
+	AllowedClients []netv1.NetworkPolicyPeer `json:"allowedClients,omitempty"`
+
+	// Additional peers that are allowed to connect to the pods of the provided Zookeeper ensemble, such as the Zookeeper Operator.
+	// These peers are allowed to connect on any port.
+	// +optional
+	AllowedZookeeperClients []netv1.NetworkPolicyPeer `json:"allowedZookeeperClients,omitempty"`
+
+	// The peer that selects the Solr Operator pods.
+	// Defaults to the pods labeled "control-plane: solr-operator" in the namespace that the Solr Operator is running in.
+	// +optional
+	SolrOperator *netv1.NetworkPolicyPeer `json:"solrOperator,omitempty"`
+}
+
 type SolrUpdateStrategy struct {
 	// Method defines the way in which SolrClouds should be updated when the podSpec changes.
 	// +optional
@@ -717,13 +987,18 @@ type SolrPodDisruptionBudgetOptions struct {
 
 // SolrPodDisruptionBudgetMethod is a string enumeration type that enumerates
 // all possible ways that a SolrCloud can have PodDisruptionBudgets managed.
-// +kubebuilder:validation:Enum=ClusterWide
+// +kubebuilder:validation:Enum=ClusterWide;Shard
 type SolrPodDisruptionBudgetMethod string
 
 const (
 	// ClusterWidePDB will result in a single cluster-wide PDB being created to ensure availability of the SolrCloud.
 	// This will not take replica/shard readiness into account.
 	ClusterWidePDB SolrPodDisruptionBudgetMethod = "ClusterWide"
+
+	// ShardPDB will create the same cluster-wide PDB as ClusterWidePDB, and will additionally refuse pod evictions
+	// that would take more than MaxShardReplicasUnavailable replicas of any shard offline.
+	// This requires the Solr Operator to be running with admission webhooks enabled.
+	ShardPDB SolrPodDisruptionBudgetMethod = "Shard"
 )
 
 type SolrScalingOptions struct {
@@ -743,6 +1018,124 @@ type SolrScalingOptions struct {
 	// +kubebuilder:default=true
 	// +optional
 	PopulatePodsOnScaleUp *bool `json:"populatePodsOnScaleUp,omitempty"`
+
+	// Autoscaler creates a HorizontalPodAutoscaler that scales the number of Solr Nodes, through spec.replicas, based on the given metrics.
+	// The scaling decisions are carried out by the Solr Operator through its ScaleUp and ScaleDown cluster operations,
+	// so replicas are always moved off of Solr Nodes before they are removed.
+	//
+	// This cannot be used with nodePools.
+	//
+	// +optional
+	Autoscaler *SolrAutoscalerOptions `json:"autoscaler,omitempty"`
+}
+
+type SolrAutoscalerOptions struct {
+	// The minimum number of Solr Nodes that the autoscaler can scale down to.
+	//
+	// Defaults to 1.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// The maximum number of Solr Nodes that the autoscaler can scale up to.
+	//
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// The metrics that the autoscaler uses to determine the number of Solr Nodes.
+	// If multiple metrics are provided, the number of Solr Nodes will be the largest number required by any of them.
+	//
+	// If no metrics are provided, an average CPU utilization of 80% is used.
+	//
+	// +optional
+	Metrics SolrAutoscalerMetrics `json:"metrics,omitempty"`
+
+	// The number of seconds that the autoscaler waits, after the metrics call for fewer Solr Nodes, before scaling down.
+	// Only 1 Solr Node is removed per cooldown period.
+	//
+	// Defaults to 300.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	ScaleDownCooldownSeconds *int32 `json:"scaleDownCooldownSeconds,omitempty"`
+
+	// The number of seconds that the autoscaler waits, after the metrics call for more Solr Nodes, before scaling up.
+	//
+	// Defaults to 0.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	ScaleUpCooldownSeconds *int32 `json:"scaleUpCooldownSeconds,omitempty"`
+}
+
+func (opts *SolrAutoscalerOptions) withDefaults() (changed bool) {
+	if opts.MinReplicas == nil {
+		changed = true
+		r := DefaultAutoscalerMinReplicas
+		opts.MinReplicas = &r
+	}
+
+	metrics := &opts.Metrics
+	if metrics.CPUUtilizationPercentage == nil && metrics.QueryRate == nil && metrics.DiskUsage == nil {
+		changed = true
+		c := DefaultAutoscalerCPUUtilizationPercentage
+		metrics.CPUUtilizationPercentage = &c
+	}
+	if metrics.QueryRate != nil && metrics.QueryRate.MetricName == "" {
+		changed = true
+		metrics.QueryRate.MetricName = DefaultAutoscalerQueryRateMetricName
+	}
+	if metrics.DiskUsage != nil && metrics.DiskUsage.MetricName == "" {
+		changed = true
+		metrics.DiskUsage.MetricName = DefaultAutoscalerDiskUsageMetricName
+	}
+
+	if opts.ScaleDownCooldownSeconds == nil {
+		changed = true
+		c := DefaultAutoscalerScaleDownCooldownSeconds
+		opts.ScaleDownCooldownSeconds = &c
+	}
+
+	return changed
+}
+
+type SolrAutoscalerMetrics struct {
+	// The target average CPU utilization of the Solr pods, as a percentage of the CPU that they request.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CPUUtilizationPercentage *int32 `json:"cpuUtilizationPercentage,omitempty"`
+
+	// The target query rate for each Solr pod.
+	// This metric must be available through the Kubernetes custom metrics API, e.g. by using the prometheus-adapter
+	// with the metrics of a SolrPrometheusExporter.
+	//
+	// The metricName defaults to "solr_metrics_core_requests_per_second".
+	//
+	// +optional
+	QueryRate *SolrAutoscalerPodMetric `json:"queryRate,omitempty"`
+
+	// The target disk usage for each Solr pod.
+	// This metric must be available through the Kubernetes custom metrics API, e.g. by using the prometheus-adapter
+	// with the metrics of a SolrPrometheusExporter.
+	//
+	// The metricName defaults to "solr_metrics_core_index_size_bytes".
+	//
+	// +optional
+	DiskUsage *SolrAutoscalerPodMetric `json:"diskUsage,omitempty"`
+}
+
+type SolrAutoscalerPodMetric struct {
+	// The name of the metric in the Kubernetes custom metrics API, which must be available for each Solr pod.
+	//
+	// +optional
+	MetricName string `json:"metricName,omitempty"`
+
+	// The target value of the metric, averaged across all Solr pods.
+	TargetAverageValue resource.Quantity `json:"targetAverageValue"`
 }
 
 // ZookeeperRef defines the zookeeper ensemble for solr to connect to
@@ -1160,8 +1553,109 @@ type SolrCloudStatus struct {
 	// BackupRepositoriesAvailable lists the backupRepositories specified in the SolrCloud and whether they are available across all Pods.
 	// +optional
 	BackupRepositoriesAvailable map[string]bool `json:"backupRepositoriesAvailable,omitempty"`
+
+	// ClusterOperation is the locked cluster operation that is currently running on the SolrCloud, if any.
+	// +optional
+	ClusterOperation *SolrClusterOperationStatus `json:"clusterOperation,omitempty"`
+
+	// QueuedClusterOperations are the cluster operations that have been stopped, and are queued to be retried, in order.
+	// +optional
+	QueuedClusterOperations []SolrClusterOperationStatus `json:"queuedClusterOperations,omitempty"`
+
+	// Conditions represent the latest available observations of the SolrCloud's state.
+	// Available types are: Available, Progressing, ZookeeperReady, ClusterOperationInProgress, BackupReposAvailable and SecurityBootstrapped.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// SolrClusterOperationStatus describes a cluster operation that is either running or queued for retry
+type SolrClusterOperationStatus struct {
+	// The type of cluster operation, e.g. RollingUpdate, ScalingDown, ScalingUp or BalanceReplicas
+	Operation string `json:"operation"`
+
+	// The time that the cluster operation was started or last re-started
+	StartTime metav1.Time `json:"startTime"`
+
+	// Metadata for the cluster operation, such as the number of Solr Nodes to scale to
+	// +optional
+	Metadata string `json:"metadata,omitempty"`
+
+	// The number of Solr Nodes that the cluster operation has finished with.
+	// Only provided for running operations that act on individual Solr Nodes.
+	// +optional
+	NodesDone *int32 `json:"nodesDone,omitempty"`
+
+	// The total number of Solr Nodes that the cluster operation needs to act on.
+	// Only provided for running operations that act on individual Solr Nodes.
+	// +optional
+	NodesTotal *int32 `json:"nodesTotal,omitempty"`
+
+	// A short summary of the cluster operation and its progress, e.g. "ScalingDown 2/5"
+	// +optional
+	Summary string `json:"summary,omitempty"`
 }
 
+// SolrCloudConditionType is the type of a condition in the SolrCloud status
+type SolrCloudConditionType string
+
+const (
+	// SolrCloudAvailable means that at least one Solr Node is ready to serve requests.
+	SolrCloudAvailable SolrCloudConditionType = "Available"
+
+	// SolrCloudProgressing means that the Solr Operator is working to bring the Solr Nodes to the desired state,
+	// such as creating, scaling or updating them.
+	SolrCloudProgressing SolrCloudConditionType = "Progressing"
+
+	// SolrCloudZookeeperReady means that the connection information for Zookeeper is available to the Solr Nodes.
+	SolrCloudZookeeperReady SolrCloudConditionType = "ZookeeperReady"
+
+	// SolrCloudClusterOperationInProgress means that a locked cluster operation is currently running on the SolrCloud.
+	SolrCloudClusterOperationInProgress SolrCloudConditionType = "ClusterOperationInProgress"
+
+	// SolrCloudBackupReposAvailable means that all backupRepositories are available across all Solr Nodes.
+	// This condition is only present when backupRepositories are specified.
+	SolrCloudBackupReposAvailable SolrCloudConditionType = "BackupReposAvailable"
+
+	// SolrCloudSecurityBootstrapped means that the security.json and the credentials used by the Solr Operator have been set up.
+	// This condition is only present when solrSecurity is specified.
+	SolrCloudSecurityBootstrapped SolrCloudConditionType = "SecurityBootstrapped"
+
+	// SolrCloudSecurityReconciled means that the live security config of Solr matches the managed security config.
+	// This condition is only present when solrSecurity.managedSecurity is specified.
+	SolrCloudSecurityReconciled SolrCloudConditionType = "SecurityReconciled"
+
+	// SolrCloudStorageMigrationBlocked means that the data PVCs of the Solr Nodes need to be migrated, but the migration cannot be done safely.
+	// This condition is only present while a storage migration is blocked.
+	SolrCloudStorageMigrationBlocked SolrCloudConditionType = "StorageMigrationBlocked"
+)
+
+// Reasons for the SolrCloud status conditions
+const (
+	SolrCloudReasonSolrNodesReady           = "SolrNodesReady"
+	SolrCloudReasonNoSolrNodesReady         = "NoSolrNodesReady"
+	SolrCloudReasonStatefulSetNotCreated    = "StatefulSetNotCreated"
+	SolrCloudReasonScaling                  = "Scaling"
+	SolrCloudReasonUpdatingSolrNodes        = "UpdatingSolrNodes"
+	SolrCloudReasonWaitingForSolrNodes      = "WaitingForSolrNodes"
+	SolrCloudReasonClusterOperation         = "ClusterOperationInProgress"
+	SolrCloudReasonReconciled               = "Reconciled"
+	SolrCloudReasonZookeeperConnectionFound = "ZookeeperConnectionInfoFound"
+	SolrCloudReasonZookeeperNotReady        = "ZookeeperNotReady"
+	SolrCloudReasonNoClusterOperation       = "NoClusterOperation"
+	SolrCloudReasonBackupReposAvailable     = "BackupReposAvailable"
+	SolrCloudReasonBackupReposUnavailable   = "BackupReposUnavailable"
+	SolrCloudReasonSecurityBootstrapped     = "SecurityBootstrapped"
+	SolrCloudReasonSecurityBootstrapFailed  = "SecurityBootstrapFailed"
+	SolrCloudReasonSecurityReconciled       = "SecurityReconciled"
+	SolrCloudReasonSecurityReconcileFailed  = "SecurityReconcileFailed"
+	SolrCloudReasonSecurityNotReconciled    = "SecurityNotReconciled"
+	SolrCloudReasonTooFewSolrNodes          = "TooFewSolrNodes"
+)
+
 // SolrNodeStatus is the status of a solrNode in the cloud, with readiness status
 // and internal and external addresses
 type SolrNodeStatus struct {
@@ -1171,6 +1665,10 @@ type SolrNodeStatus struct {
 	// The name of the Kubernetes Node which the pod is running on
 	NodeName string `json:"nodeName"`
 
+	// The node pool that the Solr Node belongs to, empty for the default node pool
+	// +optional
+	NodePool string `json:"nodePool,omitempty"`
+
 	// An address the node can be connected to from within the Kube cluster
 	InternalAddress string `json:"internalAddress"`
 
@@ -1191,6 +1689,49 @@ type SolrNodeStatus struct {
 	// This Solr Node pod is scheduled for deletion
 	// +optional
 	ScheduledForDeletion bool `json:"scheduledForDeletion"`
+
+	// The progress of the expansion of the Solr Node's data volume.
+	// This is only provided while the data volume is being expanded.
+	// +optional
+	DataVolumeResize *SolrVolumeResizeStatus `json:"dataVolumeResize,omitempty"`
+}
+
+// VolumeResizeState is the state of the expansion of a PersistentVolumeClaim
+// +kubebuilder:validation:Enum=Pending;Resizing;FileSystemResizePending;Failed
+type VolumeResizeState string
+
+const (
+	// The larger size has been requested, but the volume has not started to be resized
+	VolumeResizePending VolumeResizeState = "Pending"
+
+	// The volume is being resized by the storage provider
+	VolumeResizeInProgress VolumeResizeState = "Resizing"
+
+	// The volume has been resized, but the file system still needs to be resized on the Kubernetes Node.
+	// Depending on the storage provider, this might require the pod to be restarted.
+	VolumeResizeFileSystemPending VolumeResizeState = "FileSystemResizePending"
+
+	// The storage provider has failed to resize the volume, see the message for more information
+	VolumeResizeFailed VolumeResizeState = "Failed"
+)
+
+type SolrVolumeResizeStatus struct {
+	// The name of the PersistentVolumeClaim being expanded
+	PersistentVolumeClaim string `json:"persistentVolumeClaim"`
+
+	// The size that has been requested for the volume
+	RequestedSize resource.Quantity `json:"requestedSize"`
+
+	// The current capacity of the volume
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+
+	// The state of the expansion
+	State VolumeResizeState `json:"state"`
+
+	// Information about the state of the expansion, provided by the storage provider
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -1206,6 +1747,7 @@ type SolrNodeStatus struct {
 //+kubebuilder:printcolumn:name="Nodes",type="integer",JSONPath=".status.replicas",description="Number of solr nodes running"
 //+kubebuilder:printcolumn:name="ReadyNodes",type="integer",JSONPath=".status.readyReplicas",description="Number of solr nodes connected to the cloud"
 //+kubebuilder:printcolumn:name="UpToDateNodes",type="integer",JSONPath=".status.upToDateNodes",description="Number of solr nodes running the latest SolrCloud pod spec"
+//+kubebuilder:printcolumn:name="ClusterOp",type="string",JSONPath=".status.clusterOperation.summary",description="The cluster operation currently running, and its progress"
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SolrCloud is the Schema for the solrclouds API
@@ -1222,20 +1764,68 @@ func (sc *SolrCloud) WithDefaults(logger logr.Logger) bool {
 	return sc.Spec.withDefaults(logger)
 }
 
-func (sc *SolrCloud) GetAllSolrPodNames() []string {
-	replicas := 1
-	if sc.Spec.Replicas != nil {
-		replicas = int(*sc.Spec.Replicas)
+// AllNodePools returns the default node pool, followed by the additional node pools of the SolrCloud.
+// The options that a node pool inherits from the SolrCloud spec are filled in, if the node pool does not provide them.
+func (sc *SolrCloud) AllNodePools() []SolrNodePool {
+	defaultPool := SolrNodePool{
+		Replicas:       sc.Spec.Replicas,
+		PodOptions:     sc.Spec.CustomSolrKubeOptions.PodOptions,
+		StorageOptions: &sc.Spec.StorageOptions,
 	}
-	if int(sc.Status.Replicas) > replicas {
-		replicas = int(sc.Status.Replicas)
+	nodePools := append(make([]SolrNodePool, 0, len(sc.Spec.NodePools)+1), defaultPool)
+	for _, pool := range sc.Spec.NodePools {
+		if pool.PodOptions == nil {
+			pool.PodOptions = defaultPool.PodOptions
+		}
+		if pool.StorageOptions == nil {
+			pool.StorageOptions = defaultPool.StorageOptions
+		}
+		nodePools = append(nodePools, pool)
 	}
-	return sc.GetSolrPodNames(replicas)
+	return nodePools
+}
+
+// TotalReplicas returns the number of Solr Nodes that should be running across all node pools of the SolrCloud.
+func (sc *SolrCloud) TotalReplicas() (replicas int32) {
+	for _, pool := range sc.AllNodePools() {
+		if pool.Replicas != nil {
+			replicas += *pool.Replicas
+		}
+	}
+	return replicas
+}
+
+// GetAllSolrPodNames returns the names of all Solr pods that should exist, or still exist, across all node pools of the SolrCloud.
+// This includes the pods of node pools that have been removed, but have not yet been scaled down.
+func (sc *SolrCloud) GetAllSolrPodNames() (podNames []string) {
+	nodePools := make(map[string]bool, len(sc.Spec.NodePools)+1)
+	for _, pool := range sc.AllNodePools() {
+		nodePools[pool.Name] = true
+		replicas := 1
+		if pool.Replicas != nil {
+			replicas = int(*pool.Replicas)
+		}
+		if existingReplicas := sc.Status.nodePoolReplicas(pool.Name); existingReplicas > replicas {
+			replicas = existingReplicas
+		}
+		podNames = append(podNames, sc.GetSolrPodNamesForNodePool(pool.Name, replicas)...)
+	}
+	for _, node := range sc.Status.SolrNodes {
+		if !nodePools[node.NodePool] {
+			nodePools[node.NodePool] = true
+			podNames = append(podNames, sc.GetSolrPodNamesForNodePool(node.NodePool, sc.Status.nodePoolReplicas(node.NodePool))...)
+		}
+	}
+	return podNames
 }
 
 func (sc *SolrCloud) GetSolrPodNames(replicas int) []string {
+	return sc.GetSolrPodNamesForNodePool("", replicas)
+}
+
+func (sc *SolrCloud) GetSolrPodNamesForNodePool(nodePool string, replicas int) []string {
 	podNames := make([]string, replicas)
-	statefulSetName := sc.StatefulSetName()
+	statefulSetName := sc.NodePoolStatefulSetName(nodePool)
 	for i := range podNames {
 		podNames[i] = fmt.Sprintf("%s-%d", statefulSetName, i)
 	}
@@ -1243,15 +1833,24 @@ func (sc *SolrCloud) GetSolrPodNames(replicas int) []string {
 }
 
 func (sc *SolrCloud) GetRandomSolrPodName() string {
-	replicas := 1
-	if sc.Spec.Replicas != nil {
-		replicas = int(*sc.Spec.Replicas)
+	var podNames []string
+	for _, pool := range sc.AllNodePools() {
+		if pool.Replicas != nil {
+			podNames = append(podNames, sc.GetSolrPodNamesForNodePool(pool.Name, int(*pool.Replicas))...)
+		}
 	}
-	return sc.GetSolrPodName(rand.Intn(replicas))
+	if len(podNames) == 0 {
+		return sc.GetSolrPodName(0)
+	}
+	return podNames[rand.Intn(len(podNames))]
 }
 
 func (sc *SolrCloud) GetSolrPodName(podNumber int) string {
-	return fmt.Sprintf("%s-%d", sc.StatefulSetName(), podNumber)
+	return sc.GetSolrPodNameForNodePool("", podNumber)
+}
+
+func (sc *SolrCloud) GetSolrPodNameForNodePool(nodePool string, podNumber int) string {
+	return fmt.Sprintf("%s-%d", sc.NodePoolStatefulSetName(nodePool), podNumber)
 }
 
 func (sc *SolrCloud) BasicAuthSecretName() string {
@@ -1276,6 +1875,15 @@ func (sc *SolrCloud) StatefulSetName() string {
 	return fmt.Sprintf("%s-solrcloud", sc.GetName())
 }
 
+// NodePoolStatefulSetName returns the name of the statefulset for a node pool of the cloud.
+// The default node pool, with an empty name, uses the statefulset of the cloud.
+func (sc *SolrCloud) NodePoolStatefulSetName(nodePool string) string {
+	if nodePool == "" {
+		return sc.StatefulSetName()
+	}
+	return fmt.Sprintf("%s-solrcloud-%s", sc.GetName(), nodePool)
+}
+
 // CommonServiceName returns the name of the common service for the cloud
 func (sc *SolrCloud) CommonServiceName() string {
 	return fmt.Sprintf("%s-solrcloud-common", sc.GetName())
@@ -1291,11 +1899,26 @@ func (sc *SolrCloud) HeadlessServiceName() string {
 	return fmt.Sprintf("%s-solrcloud-headless", sc.GetName())
 }
 
+// CommonRouteName returns the name of the common Gateway API route for the cloud
+func (sc *SolrCloud) CommonRouteName() string {
+	return fmt.Sprintf("%s-solrcloud-common", sc.GetName())
+}
+
 // CommonIngressName returns the name of the common ingress for the cloud
 func (sc *SolrCloud) CommonIngressName() string {
 	return fmt.Sprintf("%s-solrcloud-common", sc.GetName())
 }
 
+// NetworkPolicyName returns the name of the NetworkPolicy for the Solr pods of the cloud
+func (sc *SolrCloud) NetworkPolicyName() string {
+	return fmt.Sprintf("%s-solrcloud", sc.GetName())
+}
+
+// ZookeeperNetworkPolicyName returns the name of the NetworkPolicy for the pods of the provided zk cluster
+func (sc *SolrCloud) ZookeeperNetworkPolicyName() string {
+	return fmt.Sprintf("%s-solrcloud-zookeeper", sc.GetName())
+}
+
 // ProvidedZookeeperName returns the provided zk cluster
 func (sc *SolrCloud) ProvidedZookeeperName() string {
 	return fmt.Sprintf("%s-solrcloud-zookeeper", sc.GetName())
@@ -1313,6 +1936,21 @@ func (sc *SolrCloud) ZkConnectionString() string {
 func (scs SolrCloudStatus) ZkConnectionString() string {
 	return scs.ZookeeperConnectionInfo.ZkConnectionString()
 }
+
+// nodePoolReplicas returns the number of Solr Nodes found for the given node pool when the status was last updated.
+func (scs SolrCloudStatus) nodePoolReplicas(nodePool string) (replicas int) {
+	// Solr Nodes are not listed until the pods have been created, so fall back to the number of replicas for the default node pool
+	if len(scs.SolrNodes) == 0 && nodePool == "" {
+		return int(scs.Replicas)
+	}
+	for _, node := range scs.SolrNodes {
+		if node.NodePool == nodePool {
+			replicas++
+		}
+	}
+	return replicas
+}
+
 func (scs SolrCloudStatus) DissectZkInfo() (zkConnectionString string, zkServer string, zkChRoot string) {
 	zkConnectionString = scs.ZookeeperConnectionInfo.ZkConnectionString()
 	zkParts := strings.SplitN(zkConnectionString, "/", 2)
@@ -1339,8 +1977,13 @@ func (sc *SolrCloud) UsesIndividualNodeServices() bool {
 }
 
 func (extOpts *ExternalAddressability) UsesIndividualNodeServices() bool {
-	// LoadBalancer and Ingress will not work with headless services if each pod needs to be exposed externally.
-	return extOpts != nil && !extOpts.HideNodes && (extOpts.Method == Ingress || extOpts.Method == LoadBalancer)
+	// LoadBalancer, Ingress and GatewayAPI will not work with headless services if each pod needs to be exposed externally.
+	return extOpts != nil && !extOpts.HideNodes && (extOpts.Method == Ingress || extOpts.Method == LoadBalancer || extOpts.Method == GatewayAPI)
+}
+
+// UsesHostnameRouting returns whether the external addresses are routed by hostname, through an Ingress or Gateway that listens on the standard HTTP(S) ports.
+func (extOpts *ExternalAddressability) UsesHostnameRouting() bool {
+	return extOpts != nil && (extOpts.Method == Ingress || extOpts.Method == GatewayAPI)
 }
 
 func (sc *SolrCloud) CommonExternalPrefix() string {
@@ -1430,30 +2073,34 @@ func (sc *SolrCloud) InternalCommonUrl(withPort bool) (url string) {
 }
 
 func (sc *SolrCloud) ExternalNodeUrl(nodeName string, domainName string, withPort bool) (url string) {
-	if sc.Spec.SolrAddressability.External.Method == Ingress {
+	if sc.Spec.SolrAddressability.External.UsesHostnameRouting() {
 		url = fmt.Sprintf("%s.%s", sc.NodeIngressPrefix(nodeName), domainName)
 	} else if sc.Spec.SolrAddressability.External.Method == ExternalDNS {
 		url = fmt.Sprintf("%s.%s", nodeName, sc.ExternalDnsDomain(domainName))
+	} else if sc.Spec.SolrAddressability.External.Method == LoadBalancer {
+		// The LoadBalancer IP must be routed to this hostname through DNS, outside of the Solr Operator
+		url = fmt.Sprintf("%s.%s", sc.NodeIngressPrefix(nodeName), domainName)
 	}
-	// TODO: Add LoadBalancer stuff here
 
-	if withPort && sc.Spec.SolrAddressability.External.Method != Ingress {
-		// Ingress does not require a port, since the port is whatever the ingress is listening on (80 and 443)
+	if withPort && !sc.Spec.SolrAddressability.External.UsesHostnameRouting() {
+		// Ingress and GatewayAPI do not require a port, since the port is whatever the ingress or gateway is listening on (80 and 443)
 		url += sc.NodePortSuffix(true)
 	}
 	return url
 }
 
 func (sc *SolrCloud) ExternalCommonUrl(domainName string, withPort bool) (url string) {
-	if sc.Spec.SolrAddressability.External.Method == Ingress {
+	if sc.Spec.SolrAddressability.External.UsesHostnameRouting() {
 		url = fmt.Sprintf("%s.%s", sc.CommonExternalPrefix(), domainName)
 	} else if sc.Spec.SolrAddressability.External.Method == ExternalDNS {
 		url = fmt.Sprintf("%s.%s", sc.CommonServiceName(), sc.ExternalDnsDomain(domainName))
+	} else if sc.Spec.SolrAddressability.External.Method == LoadBalancer {
+		// The LoadBalancer IP must be routed to this hostname through DNS, outside of the Solr Operator
+		url = fmt.Sprintf("%s.%s", sc.CommonExternalPrefix(), domainName)
 	}
-	// TODO: Add LoadBalancer stuff here
 
-	if withPort && sc.Spec.SolrAddressability.External.Method != Ingress {
-		// Ingress does not require a port, since the port is whatever the ingress is listening on (80 and 443)
+	if withPort && !sc.Spec.SolrAddressability.External.UsesHostnameRouting() {
+		// Ingress and GatewayAPI do not require a port, since the port is whatever the ingress or gateway is listening on (80 and 443)
 		url += sc.CommonPortSuffix(true)
 	}
 	return url
@@ -1614,18 +2261,36 @@ type SolrTLSOptions struct {
 	MountedTLSDir *MountedTLSDirectory `json:"mountedTLSDir,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=Basic
+// +kubebuilder:validation:Enum=Basic;JWT;Cert
 type AuthenticationType string
 
 const (
 	Basic AuthenticationType = "Basic"
+	JWT   AuthenticationType = "JWT"
+	Cert  AuthenticationType = "Cert"
 )
 
 type SolrSecurityOptions struct {
-	// Indicates the authentication plugin type that is being used by Solr; for now only "Basic" is supported by the
-	// Solr operator but support for other authentication plugins may be added in the future.
+	// Indicates the authentication plugin type that is being used by Solr; "Basic" for the BasicAuthPlugin,
+	// "JWT" for the JWTAuthPlugin, or "Cert" for the CertAuthPlugin.
 	AuthenticationType AuthenticationType `json:"authenticationType,omitempty"`
 
+	// Secret (kubernetes.io/tls) containing the client certificate ("tls.crt") and key ("tls.key") that the operator uses
+	// for mTLS with this SolrCloud, instead of the client certificate shared by all SolrClouds.
+	// If the Secret contains a "ca.crt", then it is used to verify the certificates of the Solr pods.
+	// Required for the "Cert" authenticationType, unless a bootstrapSecurityJson is provided.
+	// +optional
+	OperatorClientCert string `json:"operatorClientCert,omitempty"`
+
+	// Options for the CertAuthPlugin, used when the authenticationType is "Cert".
+	// +optional
+	Cert *SolrCertAuthOptions `json:"cert,omitempty"`
+
+	// Options for the JWTAuthPlugin, and how the Solr Operator authenticates to Solr with it.
+	// Required when the authenticationType is "JWT".
+	// +optional
+	JWT *SolrJWTOptions `json:"jwt,omitempty"`
+
 	// Secret (kubernetes.io/basic-auth) containing credentials the operator should use for API requests to secure Solr pods.
 	// If you provide this secret, then the operator assumes you've also configured your own security.json file and
 	// uploaded it to Solr. If you change the password for this user using the Solr security API, then you *must* update
@@ -1644,12 +2309,198 @@ type SolrSecurityOptions struct {
 	// Flag to indicate if the configured HTTP endpoint(s) used for the probes require authentication; defaults
 	// to false. If you set to true, then probes will use a local command on the main container to hit the secured
 	// endpoints with credentials sourced from an env var instead of HTTP directly.
+	// Not supported for JWT authentication, since the probes have no way to get a token,
+	// nor for Cert authentication, since the probes use the certificate of the Solr pod.
 	// +optional
 	ProbesRequireAuth bool `json:"probesRequireAuth,omitempty"`
 
 	// Configure a user-provided security.json from a secret to allow for advanced security config.
-	// If not specified, the operator bootstraps a security.json with basic auth enabled.
-	// This is a bootstrapping config only; once Solr is initialized, the security config should be managed by the security API.
+	// If not specified, the operator bootstraps a security.json with basic, JWT or cert auth enabled.
+	// This is a bootstrapping config only; once Solr is initialized, the security config should be managed by the security API,
+	// unless managedSecurity.fromBootstrapSecurityJson is true.
 	// +optional
 	BootstrapSecurityJson *corev1.SecretKeySelector `json:"bootstrapSecurityJson,omitempty"`
+
+	// Continuously reconcile the users, user roles and permissions of the live security config through the Security API,
+	// instead of only bootstrapping the security.json.
+	// The user that the Solr Operator makes requests with is never changed.
+	// +optional
+	ManagedSecurity *SolrManagedSecurityOptions `json:"managedSecurity,omitempty"`
+
+	// Periodically rotate the password of the user that the Solr Operator makes requests with.
+	// Only supported when the Solr Operator generates the basicAuthSecret.
+	// +optional
+	CredentialRotation *SolrCredentialRotationOptions `json:"credentialRotation,omitempty"`
+
+	// Secret (kubernetes.io/basic-auth) containing the credentials of a user with the "security-edit" permission,
+	// used to call the Security API for managedSecurity and credentialRotation.
+	// Defaults to the "admin" user of the bootstrapped security.json, if the Solr Operator bootstrapped security.
+	// Otherwise, defaults to the basicAuthSecret, in which case that user needs the "security-read" and "security-edit" permissions.
+	// +optional
+	SecurityAdminSecret string `json:"securityAdminSecret,omitempty"`
+}
+
+// SolrCertAuthOptions defines the config of the CertAuthPlugin in the bootstrapped security.json
+type SolrCertAuthOptions struct {
+	// The roles of each user, where the user is the subject of its client certificate, e.g. "CN=alice,O=Example".
+	// The user of the operatorClientCert is given the "k8s" role.
+	// +optional
+	UserRoles map[string][]string `json:"userRoles,omitempty"`
+}
+
+// SolrJWTOptions defines the config of the JWTAuthPlugin in the bootstrapped security.json,
+// and how the Solr Operator gets the tokens it makes requests to Solr with.
+type SolrJWTOptions struct {
+	// The issuer of the tokens, which must match the "iss" claim of the tokens
+	// +kubebuilder:validation:MinLength=1
+	Issuer string `json:"issuer"`
+
+	// The URL of the JSON Web Key Set that the issuer signs tokens with.
+	// Either jwksUrl or jwkSetSecret is required, unless a bootstrapSecurityJson is provided.
+	// +optional
+	JwksUrl string `json:"jwksUrl,omitempty"`
+
+	// A Secret key containing the JSON Web Key Set that the issuer signs tokens with,
+	// for clusters that cannot reach the issuer.
+	// +optional
+	JwkSetSecret *corev1.SecretKeySelector `json:"jwkSetSecret,omitempty"`
+
+	// The audience that tokens must be issued for, which must match the "aud" claim of the tokens
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// The claim that is used as the name of the user, Solr defaults to "sub"
+	// +optional
+	PrincipalClaim string `json:"principalClaim,omitempty"`
+
+	// Claims that all tokens must have, mapped to a regular expression that the value of the claim must match.
+	// This also applies to the tokens of the Solr Operator.
+	// +optional
+	ClaimsMatch map[string]string `json:"claimsMatch,omitempty"`
+
+	// The claim containing the roles of the user.
+	// If provided, the roles of each request are taken from its token, using the ExternalRoleRuleBasedAuthorizationPlugin.
+	// Otherwise, the roles are mapped from the user with userRoles, using the RuleBasedAuthorizationPlugin.
+	// +optional
+	RolesClaim string `json:"rolesClaim,omitempty"`
+
+	// The roles of each user, used when no rolesClaim is provided.
+	// The user of a token is the value of its principalClaim.
+	// +optional
+	UserRoles map[string][]string `json:"userRoles,omitempty"`
+
+	// How the Solr Operator gets tokens to make requests to Solr with
+	OperatorAuth SolrJWTOperatorAuth `json:"operatorAuth"`
+}
+
+// SolrJWTOperatorAuth defines how the Solr Operator gets tokens. Exactly one option must be provided.
+type SolrJWTOperatorAuth struct {
+	// Fetch tokens from the issuer, with the OAuth2 client credentials flow.
+	// The tokens must give the Solr Operator the "k8s" role, either through the rolesClaim or the userRoles.
+	// +optional
+	ClientCredentials *SolrJWTClientCredentials `json:"clientCredentials,omitempty"`
+
+	// A Secret key containing a PEM-encoded RSA private key, that the Solr Operator signs its own tokens with.
+	// The public key is added to the bootstrapped security.json as a separate issuer, and the tokens are given the "k8s" role.
+	// +optional
+	SigningKeySecret *corev1.SecretKeySelector `json:"signingKeySecret,omitempty"`
+}
+
+// SolrJWTClientCredentials defines the OAuth2 client that the Solr Operator fetches tokens with
+type SolrJWTClientCredentials struct {
+	// The token endpoint of the issuer
+	// +kubebuilder:validation:MinLength=1
+	TokenUrl string `json:"tokenUrl"`
+
+	// The name of a Secret containing the "client-id" and "client-secret" of the Solr Operator's OAuth2 client
+	// +kubebuilder:validation:MinLength=1
+	ClientSecret string `json:"clientSecret"`
+
+	// The scopes to request tokens for
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// SolrCredentialRotationOptions defines when the Solr Operator rotates the password of its own user.
+// The new password is set through the Security API, and verified, before the basicAuthSecret is updated.
+type SolrCredentialRotationOptions struct {
+	// Rotate the password on the given schedule, in CRON format.
+	//
+	// Multiple CRON syntaxes are supported
+	//   - Standard CRON (e.g. "CRON_TZ=Asia/Seoul 0 6 * * ?")
+	//   - Predefined Schedules (e.g. "@yearly", "@weekly", etc.)
+	//   - Intervals (e.g. "@every 10h30m")
+	//
+	// For more information please check this reference:
+	// https://pkg.go.dev/github.com/robfig/cron/v3?utm_source=godoc#hdr-CRON_Expression_Format
+	//
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+}
+
+// SolrManagedSecurityOptions defines the security config that the Solr Operator keeps in sync with the live security config of Solr.
+// The desired config is either the bootstrapSecurityJson, or the users and permissions listed here.
+type SolrManagedSecurityOptions struct {
+	// Reconcile the credentials, user roles and permissions of the bootstrapSecurityJson.
+	// Since the Security API only accepts plain text passwords, the users of the bootstrapSecurityJson cannot be created or have their passwords changed,
+	// they can only be removed.
+	// Cannot be used with users or permissions.
+	// +optional
+	FromBootstrapSecurityJson bool `json:"fromBootstrapSecurityJson,omitempty"`
+
+	// The Solr users, and their roles.
+	// Users that exist in Solr but are not listed here are removed, other than the user of the Solr Operator and the solrSecurity.securityAdminSecret user.
+	// +optional
+	//+listType:=map
+	//+listMapKey:=name
+	Users []SolrSecurityUser `json:"users,omitempty"`
+
+	// The permissions of the RuleBasedAuthorizationPlugin, in order of precedence.
+	// Permissions with a name starting with "k8s-" are used by the Solr Operator, and are kept at the start of the list if none are provided here.
+	// +optional
+	Permissions []SolrSecurityPermission `json:"permissions,omitempty"`
+}
+
+// SolrSecurityUser is a user of the Solr BasicAuthPlugin
+type SolrSecurityUser struct {
+	// The name of the user
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The key of a secret, in the namespace of the SolrCloud, that contains the password of the user
+	PasswordSecret corev1.SecretKeySelector `json:"passwordSecret"`
+
+	// The roles of the user, used by the RuleBasedAuthorizationPlugin
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+}
+
+// SolrSecurityPermission is a permission of the Solr RuleBasedAuthorizationPlugin.
+// Refer to the Solr Reference Guide for the meaning of each field.
+type SolrSecurityPermission struct {
+	// The name of a predefined permission, such as "read" or "all", or of a custom permission
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The collection that a custom permission applies to, use "*" for all collections.
+	// Use an empty string for requests that are not for a collection, such as the Collections API.
+	// +optional
+	Collection *string `json:"collection,omitempty"`
+
+	// The request paths that a custom permission applies to
+	// +optional
+	Path []string `json:"path,omitempty"`
+
+	// The HTTP methods that a custom permission applies to
+	// +optional
+	Method []string `json:"method,omitempty"`
+
+	// The request parameter values that a custom permission applies to
+	// +optional
+	Params map[string][]string `json:"params,omitempty"`
+
+	// The roles that are allowed to make the requests of this permission.
+	// If no roles are provided, the requests are allowed for everyone.
+	// +optional
+	Role []string `json:"role,omitempty"`
 }
@@ -0,0 +1,348 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"github.com/robfig/cron/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"regexp"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"strings"
+)
+
+// nodeLabelKeyRegex matches the node label names that can be passed to Solr as system properties
+var nodeLabelKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks for SolrClouds.
+func (sc *SolrCloud) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(sc).
+		WithDefaulter(&solrCloudDefaulter{}).
+		WithValidator(&solrCloudValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-solr-apache-org-v1beta1-solrcloud,mutating=true,failurePolicy=fail,sideEffects=None,groups=solr.apache.org,resources=solrclouds,verbs=create;update,versions=v1beta1,name=msolrcloud.solr.apache.org,admissionReviewVersions=v1
+
+type solrCloudDefaulter struct{}
+
+var _ admission.CustomDefaulter = &solrCloudDefaulter{}
+
+// Default sets the default values for a SolrCloud when it is created or updated.
+func (d *solrCloudDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	solrCloud, ok := obj.(*SolrCloud)
+	if !ok {
+		return fmt.Errorf("expected a SolrCloud but got a %T", obj)
+	}
+	// Defaulting would silently correct some conflicting options, so leave them as-is for the validating webhook to reject.
+	if len(solrCloud.validateConflicts()) > 0 {
+		return nil
+	}
+	solrCloud.WithDefaults(log.FromContext(ctx))
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-solr-apache-org-v1beta1-solrcloud,mutating=false,failurePolicy=fail,sideEffects=None,groups=solr.apache.org,resources=solrclouds,verbs=create;update,versions=v1beta1,name=vsolrcloud.solr.apache.org,admissionReviewVersions=v1
+
+type solrCloudValidator struct{}
+
+var _ admission.CustomValidator = &solrCloudValidator{}
+
+// ValidateCreate validates a new SolrCloud.
+func (v *solrCloudValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	solrCloud, ok := obj.(*SolrCloud)
+	if !ok {
+		return nil, fmt.Errorf("expected a SolrCloud but got a %T", obj)
+	}
+	return nil, solrCloud.validate(nil)
+}
+
+// ValidateUpdate validates an updated SolrCloud, including the fields that cannot be changed after creation.
+func (v *solrCloudValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	solrCloud, ok := newObj.(*SolrCloud)
+	if !ok {
+		return nil, fmt.Errorf("expected a SolrCloud but got a %T", newObj)
+	}
+	oldSolrCloud, ok := oldObj.(*SolrCloud)
+	if !ok {
+		return nil, fmt.Errorf("expected a SolrCloud but got a %T", oldObj)
+	}
+	return nil, solrCloud.validate(oldSolrCloud)
+}
+
+// ValidateDelete does nothing, SolrClouds can always be deleted.
+func (v *solrCloudValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate returns an Invalid error containing all problems with the SolrCloud spec, or nil if there are none.
+// If oldSolrCloud is provided, then changes to immutable fields are also checked.
+func (sc *SolrCloud) validate(oldSolrCloud *SolrCloud) error {
+	allErrs := sc.validateConflicts()
+	allErrs = append(allErrs, sc.validateSpec()...)
+	if oldSolrCloud != nil {
+		allErrs = append(allErrs, sc.validateImmutableFields(oldSolrCloud)...)
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("SolrCloud").GroupKind(), sc.Name, allErrs)
+}
+
+// validateConflicts finds options that cannot be used together.
+// Without the validating webhook, some of these conflicts are corrected when defaulting the SolrCloud.
+func (sc *SolrCloud) validateConflicts() (allErrs field.ErrorList) {
+	specPath := field.NewPath("spec")
+
+	if external := sc.Spec.SolrAddressability.External; external != nil {
+		externalPath := specPath.Child("solrAddressability", "external")
+		if external.UseExternalAddress && external.HideNodes {
+			allErrs = append(allErrs, field.Invalid(externalPath.Child("useExternalAddress"), external.UseExternalAddress, "cannot be true when hideNodes is true, since the Solr Nodes are not addressable externally"))
+		}
+		if external.UseExternalAddress && external.IngressTLSTermination != nil {
+			allErrs = append(allErrs, field.Invalid(externalPath.Child("useExternalAddress"), external.UseExternalAddress, "cannot be true when ingressTLSTermination is used, since Solr cannot make internal requests over HTTPS while running in HTTP mode"))
+		}
+		if external.IngressTLSTermination != nil {
+			if external.Method != Ingress {
+				allErrs = append(allErrs, field.Forbidden(externalPath.Child("ingressTLSTermination"), fmt.Sprintf("can only be used with the %s method", Ingress)))
+			}
+			if sc.Spec.SolrTLS != nil {
+				allErrs = append(allErrs, field.Forbidden(externalPath.Child("ingressTLSTermination"), "cannot be used when spec.solrTLS is enabled, since the Ingress cannot terminate TLS before reaching Solr"))
+			}
+		}
+		if external.DomainName == "" && external.Method != LoadBalancer {
+			allErrs = append(allErrs, field.Required(externalPath.Child("domainName"), fmt.Sprintf("is required for the %s method", external.Method)))
+		} else if external.DomainName == "" && external.UseExternalAddress {
+			allErrs = append(allErrs, field.Required(externalPath.Child("domainName"), "is required when useExternalAddress is true, since it is used in the hostnames that the Solr Nodes advertise"))
+		}
+		if external.LoadBalancer != nil && external.Method != LoadBalancer {
+			allErrs = append(allErrs, field.Forbidden(externalPath.Child("loadBalancer"), fmt.Sprintf("can only be used with the %s method", LoadBalancer)))
+		}
+		if external.Method == GatewayAPI && external.Gateway == nil {
+			allErrs = append(allErrs, field.Required(externalPath.Child("gateway"), fmt.Sprintf("is required for the %s method", GatewayAPI)))
+		} else if external.Method != GatewayAPI && external.Gateway != nil {
+			allErrs = append(allErrs, field.Forbidden(externalPath.Child("gateway"), fmt.Sprintf("can only be used with the %s method", GatewayAPI)))
+		}
+		if external.NodePortOverride > 0 && !external.UsesIndividualNodeServices() {
+			allErrs = append(allErrs, field.Forbidden(externalPath.Child("nodePortOverride"), "can only be used when the Solr Nodes are exposed individually, through the Ingress, LoadBalancer or GatewayAPI methods with hideNodes=false"))
+		}
+	}
+
+	if sc.Spec.ZookeeperRef != nil && sc.Spec.ZookeeperRef.ConnectionInfo != nil && sc.Spec.ZookeeperRef.ProvidedZookeeper != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("zookeeperRef"), "only one of connectionInfo or provided can be specified"))
+	}
+
+	return allErrs
+}
+
+// validateSpec finds invalid options in the SolrCloud spec that are not caught by the CRD schema.
+func (sc *SolrCloud) validateSpec() (allErrs field.ErrorList) {
+	specPath := field.NewPath("spec")
+
+	if sc.Spec.StorageOptions.PersistentStorage != nil && sc.Spec.StorageOptions.EphemeralStorage != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("dataStorage"), "only one of persistent or ephemeral can be specified"))
+	}
+
+	repoNames := make(map[string]bool, len(sc.Spec.BackupRepositories))
+	for i, repo := range sc.Spec.BackupRepositories {
+		repoPath := specPath.Child("backupRepositories").Index(i)
+		if repoNames[repo.Name] {
+			allErrs = append(allErrs, field.Duplicate(repoPath.Child("name"), repo.Name))
+		}
+		repoNames[repo.Name] = true
+
+		repoTypes := 0
+		for _, isSet := range []bool{repo.GCS != nil, repo.S3 != nil, repo.Azure != nil, repo.HDFS != nil, repo.Volume != nil} {
+			if isSet {
+				repoTypes++
+			}
+		}
+		if repoTypes != 1 {
+			allErrs = append(allErrs, field.Invalid(repoPath, repo.Name, "exactly one of gcs, s3, azure, hdfs or volume must be specified"))
+		}
+		if repo.Azure != nil && repo.Azure.Credentials != nil && repo.Azure.Credentials.AccountKeySecret != nil && repo.Azure.Credentials.SasTokenSecret != nil {
+			allErrs = append(allErrs, field.Invalid(repoPath.Child("azure", "credentials"), "accountKeySecret, sasTokenSecret", "only one of accountKeySecret or sasTokenSecret can be specified"))
+		}
+	}
+
+	nodePoolNames := make(map[string]bool, len(sc.Spec.NodePools))
+	for i, pool := range sc.Spec.NodePools {
+		poolPath := specPath.Child("nodePools").Index(i)
+		if nodePoolNames[pool.Name] {
+			allErrs = append(allErrs, field.Duplicate(poolPath.Child("name"), pool.Name))
+		}
+		nodePoolNames[pool.Name] = true
+
+		if pool.StorageOptions != nil {
+			if pool.StorageOptions.PersistentStorage != nil && pool.StorageOptions.EphemeralStorage != nil {
+				allErrs = append(allErrs, field.Forbidden(poolPath.Child("dataStorage"), "only one of persistent or ephemeral can be specified"))
+			} else if pool.UsesPersistentStorage() != sc.UsesPersistentStorage() {
+				allErrs = append(allErrs, field.Forbidden(poolPath.Child("dataStorage"), "must use the same type of storage, persistent or ephemeral, as spec.dataStorage"))
+			}
+		}
+
+		for key, value := range pool.NodeLabels {
+			if !nodeLabelKeyRegex.MatchString(key) {
+				allErrs = append(allErrs, field.Invalid(poolPath.Child("nodeLabels"), key, "node label names may only contain letters, numbers, '_', '.' and '-'"))
+			}
+			if strings.ContainsAny(value, " \t\n\r") {
+				allErrs = append(allErrs, field.Invalid(poolPath.Child("nodeLabels").Key(key), value, "node label values cannot contain whitespace"))
+			}
+		}
+	}
+
+	if autoscaler := sc.Spec.Scaling.Autoscaler; autoscaler != nil {
+		autoscalerPath := specPath.Child("scaling", "autoscaler")
+		if len(sc.Spec.NodePools) > 0 {
+			allErrs = append(allErrs, field.Forbidden(autoscalerPath, "cannot be used with nodePools"))
+		}
+		if autoscaler.MinReplicas != nil && *autoscaler.MinReplicas > autoscaler.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(autoscalerPath.Child("minReplicas"), *autoscaler.MinReplicas, "cannot be greater than maxReplicas"))
+		}
+	}
+
+	if sc.Spec.SolrSecurity != nil && sc.Spec.SolrSecurity.ManagedSecurity != nil {
+		managedPath := specPath.Child("solrSecurity", "managedSecurity")
+		managed := sc.Spec.SolrSecurity.ManagedSecurity
+		if managed.FromBootstrapSecurityJson {
+			if sc.Spec.SolrSecurity.BootstrapSecurityJson == nil {
+				allErrs = append(allErrs, field.Required(specPath.Child("solrSecurity", "bootstrapSecurityJson"), "is required when managedSecurity.fromBootstrapSecurityJson is true"))
+			}
+			if len(managed.Users) > 0 || len(managed.Permissions) > 0 {
+				allErrs = append(allErrs, field.Forbidden(managedPath.Child("fromBootstrapSecurityJson"), "cannot be used with users or permissions"))
+			}
+		}
+	}
+
+	if sc.Spec.SolrSecurity != nil {
+		allErrs = append(allErrs, sc.validateAuthentication(specPath.Child("solrSecurity"))...)
+	}
+
+	if sc.Spec.SolrSecurity != nil && sc.Spec.SolrSecurity.CredentialRotation != nil {
+		rotationPath := specPath.Child("solrSecurity", "credentialRotation")
+		if sc.Spec.SolrSecurity.BasicAuthSecret != "" {
+			allErrs = append(allErrs, field.Forbidden(rotationPath, "cannot be used with a user-provided basicAuthSecret"))
+		}
+		if sc.Spec.SolrSecurity.ProbesRequireAuth {
+			allErrs = append(allErrs, field.Forbidden(rotationPath, "cannot be used with probesRequireAuth, since the probes of every Solr pod would fail until the mounted secret is updated"))
+		}
+		if _, err := cron.ParseStandard(sc.Spec.SolrSecurity.CredentialRotation.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(rotationPath.Child("schedule"), sc.Spec.SolrSecurity.CredentialRotation.Schedule, err.Error()))
+		}
+	}
+
+	if sc.Spec.UpdateStrategy.RestartSchedule != "" {
+		if _, err := cron.ParseStandard(sc.Spec.UpdateStrategy.RestartSchedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("updateStrategy", "restartSchedule"), sc.Spec.UpdateStrategy.RestartSchedule, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateImmutableFields finds changes to options that cannot be changed once the SolrCloud has been created.
+func (sc *SolrCloud) validateImmutableFields(oldSolrCloud *SolrCloud) (allErrs field.ErrorList) {
+	specPath := field.NewPath("spec")
+
+	if sc.UsesPersistentStorage() != oldSolrCloud.UsesPersistentStorage() {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("dataStorage"), "cannot be switched between persistent and ephemeral storage, since the Solr data would be lost"))
+	}
+
+	if oldChRoot, newChRoot := oldSolrCloud.Spec.ZookeeperRef.chRoot(), sc.Spec.ZookeeperRef.chRoot(); oldChRoot != "" && newChRoot != "" && oldChRoot != newChRoot {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("zookeeperRef"), fmt.Sprintf("the Zookeeper chroot cannot be changed from %q to %q, since the SolrCloud would lose its cluster state", oldChRoot, newChRoot)))
+	}
+
+	return allErrs
+}
+
+// chRoot returns the normalized chroot that Solr uses in Zookeeper, or an empty string if it is not known yet.
+func (ref *ZookeeperRef) chRoot() (chRoot string) {
+	if ref == nil {
+		return ""
+	}
+	if ref.ConnectionInfo != nil {
+		chRoot = ref.ConnectionInfo.ChRoot
+	} else if ref.ProvidedZookeeper != nil {
+		chRoot = ref.ProvidedZookeeper.ChRoot
+	} else {
+		return ""
+	}
+	if len(chRoot) == 0 || chRoot[0] != '/' {
+		chRoot = "/" + chRoot
+	}
+	return chRoot
+}
+
+// validateAuthentication validates the options that depend on the authenticationType of the solrSecurity
+func (sc *SolrCloud) validateAuthentication(securityPath *field.Path) (allErrs field.ErrorList) {
+	security := sc.Spec.SolrSecurity
+	jwtPath := securityPath.Child("jwt")
+	if security.AuthenticationType != JWT && security.JWT != nil {
+		allErrs = append(allErrs, field.Forbidden(jwtPath, "can only be used with the JWT authenticationType"))
+	}
+	if security.AuthenticationType != Cert && security.Cert != nil {
+		allErrs = append(allErrs, field.Forbidden(securityPath.Child("cert"), "can only be used with the Cert authenticationType"))
+	}
+
+	switch security.AuthenticationType {
+	case JWT:
+		if security.JWT == nil {
+			allErrs = append(allErrs, field.Required(jwtPath, "is required for the JWT authenticationType"))
+			break
+		}
+		if security.BootstrapSecurityJson == nil && (security.JWT.JwksUrl == "") == (security.JWT.JwkSetSecret == nil) {
+			allErrs = append(allErrs, field.Invalid(jwtPath, "jwksUrl, jwkSetSecret", "exactly one of jwksUrl or jwkSetSecret must be specified, unless a bootstrapSecurityJson is provided"))
+		}
+		operatorAuth := security.JWT.OperatorAuth
+		if (operatorAuth.ClientCredentials == nil) == (operatorAuth.SigningKeySecret == nil) {
+			allErrs = append(allErrs, field.Invalid(jwtPath.Child("operatorAuth"), "clientCredentials, signingKeySecret", "exactly one of clientCredentials or signingKeySecret must be specified"))
+		}
+	case Cert:
+		if security.OperatorClientCert == "" && security.BootstrapSecurityJson == nil {
+			allErrs = append(allErrs, field.Required(securityPath.Child("operatorClientCert"), "is required for the Cert authenticationType, unless a bootstrapSecurityJson is provided"))
+		}
+		if sc.Spec.SolrTLS == nil {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("solrTLS"), "is required for the Cert authenticationType"))
+		} else if sc.Spec.SolrTLS.ClientAuth != Want && sc.Spec.SolrTLS.ClientAuth != Need {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("solrTLS", "clientAuth"), sc.Spec.SolrTLS.ClientAuth, "must be Want or Need for the Cert authenticationType"))
+		}
+	}
+
+	// These options only apply to the BasicAuthPlugin
+	if security.AuthenticationType == JWT || security.AuthenticationType == Cert {
+		if security.BasicAuthSecret != "" {
+			allErrs = append(allErrs, field.Forbidden(securityPath.Child("basicAuthSecret"), fmt.Sprintf("cannot be used with the %s authenticationType", security.AuthenticationType)))
+		}
+		if security.ProbesRequireAuth {
+			allErrs = append(allErrs, field.Forbidden(securityPath.Child("probesRequireAuth"), fmt.Sprintf("cannot be used with the %s authenticationType", security.AuthenticationType)))
+		}
+		if security.ManagedSecurity != nil {
+			allErrs = append(allErrs, field.Forbidden(securityPath.Child("managedSecurity"), fmt.Sprintf("cannot be used with the %s authenticationType", security.AuthenticationType)))
+		}
+		if security.CredentialRotation != nil {
+			allErrs = append(allErrs, field.Forbidden(securityPath.Child("credentialRotation"), fmt.Sprintf("cannot be used with the %s authenticationType", security.AuthenticationType)))
+		}
+	}
+	return allErrs
+}
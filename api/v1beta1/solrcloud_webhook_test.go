@@ -0,0 +1,486 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestSolrCloudWebhookDefaulting(t *testing.T) {
+	solrCloud := &SolrCloud{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+
+	assert.NoError(t, (&solrCloudDefaulter{}).Default(context.Background(), solrCloud), "Defaulting should not fail")
+	assert.NotNil(t, solrCloud.Spec.Replicas, "Replicas should be defaulted")
+	assert.NotNil(t, solrCloud.Spec.ZookeeperRef, "ZookeeperRef should be defaulted")
+	assert.Equal(t, 8983, solrCloud.Spec.SolrAddressability.PodPort, "PodPort should be defaulted")
+
+	// Conflicting options should not be corrected, so that the validating webhook can reject them
+	conflictingSolrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			SolrAddressability: SolrAddressabilityOptions{
+				External: &ExternalAddressability{
+					Method:             Ingress,
+					DomainName:         "example.com",
+					UseExternalAddress: true,
+					HideNodes:          true,
+				},
+			},
+		},
+	}
+	assert.NoError(t, (&solrCloudDefaulter{}).Default(context.Background(), conflictingSolrCloud), "Defaulting should not fail")
+	assert.True(t, conflictingSolrCloud.Spec.SolrAddressability.External.UseExternalAddress, "Conflicting useExternalAddress option should not be corrected by the defaulting webhook")
+
+	_, err := (&solrCloudValidator{}).ValidateCreate(context.Background(), conflictingSolrCloud)
+	if assert.Error(t, err, "SolrCloud with useExternalAddress and hideNodes should be rejected") {
+		assert.True(t, apierrors.IsInvalid(err), "Rejection should be an Invalid error")
+		assert.Contains(t, err.Error(), "spec.solrAddressability.external.useExternalAddress", "Error should reference the conflicting field")
+	}
+}
+
+func TestSolrCloudWebhookValidateConflicts(t *testing.T) {
+	solrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			SolrAddressability: SolrAddressabilityOptions{
+				External: &ExternalAddressability{
+					Method:     Ingress,
+					DomainName: "example.com",
+					IngressTLSTermination: &SolrIngressTLSTermination{
+						TLSSecret: "ingress-tls",
+					},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	_, err := (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "Ingress TLS Termination without solrTLS should be valid")
+
+	solrCloud.Spec.SolrTLS = &SolrTLSOptions{}
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Ingress TLS Termination with solrTLS should be rejected") {
+		assert.Contains(t, err.Error(), "spec.solrAddressability.external.ingressTLSTermination", "Error should reference the conflicting field")
+	}
+
+	solrCloud.Spec.SolrTLS = nil
+	solrCloud.Spec.BackupRepositories = []SolrBackupRepository{
+		{Name: "repo", Volume: &VolumeRepository{}},
+		{Name: "repo", GCS: &GcsRepository{}, S3: &S3Repository{}},
+	}
+	solrCloud.Spec.UpdateStrategy.RestartSchedule = "not a cron"
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "SolrCloud with invalid backupRepositories and restartSchedule should be rejected") {
+		assert.Contains(t, err.Error(), "spec.backupRepositories[1].name: Duplicate value", "Duplicate backupRepository names should be rejected")
+		assert.Contains(t, err.Error(), "exactly one of gcs, s3, azure, hdfs or volume must be specified", "backupRepositories with multiple types should be rejected")
+		assert.Contains(t, err.Error(), "spec.updateStrategy.restartSchedule", "Invalid restartSchedule should be rejected")
+	}
+}
+
+func TestSolrCloudWebhookValidateImmutableFields(t *testing.T) {
+	oldSolrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			StorageOptions: SolrDataStorageOptions{
+				PersistentStorage: &SolrPersistentDataStorageOptions{},
+			},
+			ZookeeperRef: &ZookeeperRef{
+				ConnectionInfo: &ZookeeperConnectionInfo{
+					InternalConnectionString: "zk:2181",
+					ChRoot:                   "/solr",
+				},
+			},
+		},
+	}
+	oldSolrCloud.WithDefaults(logr.Discard())
+
+	newSolrCloud := oldSolrCloud.DeepCopy()
+	newSolrCloud.Spec.ZookeeperRef.ConnectionInfo.ChRoot = "solr"
+	newSolrCloud.Spec.Replicas = nil
+	_, err := (&solrCloudValidator{}).ValidateUpdate(context.Background(), oldSolrCloud, newSolrCloud)
+	assert.NoError(t, err, "Changing mutable fields, or the format of the chroot, should be allowed")
+
+	newSolrCloud.Spec.ZookeeperRef.ConnectionInfo.ChRoot = "/other"
+	newSolrCloud.Spec.StorageOptions = SolrDataStorageOptions{EphemeralStorage: &SolrEphemeralDataStorageOptions{}}
+	_, err = (&solrCloudValidator{}).ValidateUpdate(context.Background(), oldSolrCloud, newSolrCloud)
+	if assert.Error(t, err, "Changing immutable fields should be rejected") {
+		assert.Contains(t, err.Error(), "spec.dataStorage: Forbidden", "Switching from persistent to ephemeral storage should be rejected")
+		assert.Contains(t, err.Error(), "spec.zookeeperRef: Forbidden", "Changing the Zookeeper chroot should be rejected")
+	}
+}
+
+func TestSolrCloudWebhookValidateDataVolumeSize(t *testing.T) {
+	oldSolrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			StorageOptions: SolrDataStorageOptions{
+				PersistentStorage: &SolrPersistentDataStorageOptions{
+					PersistentVolumeClaimTemplate: PersistentVolumeClaimTemplate{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.VolumeResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	oldSolrCloud.WithDefaults(logr.Discard())
+
+	newSolrCloud := oldSolrCloud.DeepCopy()
+	newSolrCloud.Spec.StorageOptions.PersistentStorage.PersistentVolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("20Gi")
+	_, err := (&solrCloudValidator{}).ValidateUpdate(context.Background(), oldSolrCloud, newSolrCloud)
+	assert.NoError(t, err, "Expanding the data volumes should be allowed")
+
+	// Smaller data volumes are created through a storage migration
+	newSolrCloud.Spec.StorageOptions.PersistentStorage.PersistentVolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("5Gi")
+	_, err = (&solrCloudValidator{}).ValidateUpdate(context.Background(), oldSolrCloud, newSolrCloud)
+	assert.NoError(t, err, "Shrinking the data volumes should be allowed")
+
+	newSolrCloud.Spec.StorageOptions = SolrDataStorageOptions{EphemeralStorage: &SolrEphemeralDataStorageOptions{}}
+	_, err = (&solrCloudValidator{}).ValidateUpdate(context.Background(), oldSolrCloud, newSolrCloud)
+	assert.Error(t, err, "Switching to ephemeral storage should still be rejected")
+}
+
+func TestSolrCloudWebhookValidateNodePools(t *testing.T) {
+	solrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			NodePools: []SolrNodePool{
+				{Name: "pull", NodeLabels: map[string]string{"replica_type": "PULL"}},
+				{Name: "hot", StorageOptions: &SolrDataStorageOptions{EphemeralStorage: &SolrEphemeralDataStorageOptions{}}},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	_, err := (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "Node pools with unique names and matching storage should be valid")
+	assert.Equal(t, DefaultSolrReplicas, *solrCloud.Spec.NodePools[0].Replicas, "Node pool replicas should be defaulted")
+
+	solrCloud.Spec.NodePools = append(solrCloud.Spec.NodePools, SolrNodePool{
+		Name:           "pull",
+		StorageOptions: &SolrDataStorageOptions{PersistentStorage: &SolrPersistentDataStorageOptions{}},
+		NodeLabels:     map[string]string{"replica type": "PULL", "node_type": "hot storage"},
+	})
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Invalid node pools should be rejected") {
+		assert.Contains(t, err.Error(), "spec.nodePools[2].name: Duplicate value", "Duplicate node pool names should be rejected")
+		assert.Contains(t, err.Error(), "spec.nodePools[2].dataStorage: Forbidden", "Node pools with a different type of storage should be rejected")
+		assert.Contains(t, err.Error(), "node label names may only contain", "Node label names with whitespace should be rejected")
+		assert.Contains(t, err.Error(), "spec.nodePools[2].nodeLabels[node_type]", "Node label values with whitespace should be rejected")
+	}
+}
+
+func TestSolrCloudWebhookValidateAutoscaler(t *testing.T) {
+	solrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			Scaling: SolrScalingOptions{
+				Autoscaler: &SolrAutoscalerOptions{
+					MaxReplicas: 5,
+					Metrics: SolrAutoscalerMetrics{
+						QueryRate: &SolrAutoscalerPodMetric{TargetAverageValue: resource.MustParse("100")},
+					},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	_, err := (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "Autoscaler with minReplicas less than maxReplicas should be valid")
+	assert.Equal(t, DefaultAutoscalerMinReplicas, *solrCloud.Spec.Scaling.Autoscaler.MinReplicas, "Autoscaler minReplicas should be defaulted")
+	assert.Equal(t, DefaultAutoscalerQueryRateMetricName, solrCloud.Spec.Scaling.Autoscaler.Metrics.QueryRate.MetricName, "Autoscaler query rate metric name should be defaulted")
+	assert.Nil(t, solrCloud.Spec.Scaling.Autoscaler.Metrics.CPUUtilizationPercentage, "Autoscaler CPU utilization should not be defaulted when another metric is provided")
+
+	minReplicas := int32(6)
+	solrCloud.Spec.Scaling.Autoscaler.MinReplicas = &minReplicas
+	solrCloud.Spec.NodePools = []SolrNodePool{{Name: "pool"}}
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Invalid autoscaler options should be rejected") {
+		assert.Contains(t, err.Error(), "spec.scaling.autoscaler.minReplicas: Invalid value", "minReplicas greater than maxReplicas should be rejected")
+		assert.Contains(t, err.Error(), "spec.scaling.autoscaler: Forbidden", "Autoscaler with nodePools should be rejected")
+	}
+}
+
+func TestSolrCloudWebhookValidateLoadBalancer(t *testing.T) {
+	solrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			SolrAddressability: SolrAddressabilityOptions{
+				External: &ExternalAddressability{
+					Method: LoadBalancer,
+					LoadBalancer: &SolrLoadBalancerOptions{
+						LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+					},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	_, err := (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "The LoadBalancer method should not require a domainName")
+
+	solrCloud.Spec.SolrAddressability.External.UseExternalAddress = true
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Advertising the external address of a LoadBalancer without a domainName should be rejected") {
+		assert.Contains(t, err.Error(), "spec.solrAddressability.external.domainName: Required value", "Wrong error for a missing domainName")
+	}
+
+	solrCloud.Spec.SolrAddressability.External.UseExternalAddress = false
+	solrCloud.Spec.SolrAddressability.External.Method = ExternalDNS
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "LoadBalancer options should be rejected for other methods") {
+		assert.Contains(t, err.Error(), "spec.solrAddressability.external.loadBalancer: Forbidden", "Wrong error for loadBalancer options with the ExternalDNS method")
+		assert.Contains(t, err.Error(), "spec.solrAddressability.external.domainName: Required value", "The ExternalDNS method should require a domainName")
+	}
+}
+
+func TestSolrCloudWebhookValidateGatewayAPI(t *testing.T) {
+	solrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			SolrAddressability: SolrAddressabilityOptions{
+				External: &ExternalAddressability{
+					Method:     GatewayAPI,
+					DomainName: "example.com",
+					Gateway: &SolrGatewayOptions{
+						ParentRef: SolrGatewayParentReference{Name: "gateway"},
+					},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	_, err := (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "The GatewayAPI method with a parentRef should be valid")
+	assert.Equal(t, 80, solrCloud.Spec.SolrAddressability.External.NodePortOverride, "The GatewayAPI method should default the nodePortOverride, like the Ingress method")
+
+	solrCloud.Spec.SolrAddressability.External.Gateway = nil
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "The GatewayAPI method without gateway options should be rejected") {
+		assert.Contains(t, err.Error(), "spec.solrAddressability.external.gateway: Required value", "Wrong error for missing gateway options")
+	}
+
+	solrCloud.Spec.SolrAddressability.External.Method = Ingress
+	solrCloud.Spec.SolrAddressability.External.Gateway = &SolrGatewayOptions{ParentRef: SolrGatewayParentReference{Name: "gateway"}}
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Gateway options should be rejected for other methods") {
+		assert.Contains(t, err.Error(), "spec.solrAddressability.external.gateway: Forbidden", "Wrong error for gateway options with the Ingress method")
+	}
+}
+
+func TestSolrCloudWebhookValidateManagedSecurity(t *testing.T) {
+	solrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			SolrSecurity: &SolrSecurityOptions{
+				AuthenticationType: Basic,
+				ManagedSecurity: &SolrManagedSecurityOptions{
+					Users: []SolrSecurityUser{{Name: "alice", Roles: []string{"admin"}}},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	_, err := (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "Managed security with a structured spec should be valid")
+
+	solrCloud.Spec.SolrSecurity.ManagedSecurity.FromBootstrapSecurityJson = true
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Managing security from the bootstrap security.json, while also providing users, should be rejected") {
+		assert.Contains(t, err.Error(), "spec.solrSecurity.bootstrapSecurityJson: Required value", "Wrong error for a missing bootstrapSecurityJson")
+		assert.Contains(t, err.Error(), "spec.solrSecurity.managedSecurity.fromBootstrapSecurityJson: Forbidden", "Wrong error for users provided alongside fromBootstrapSecurityJson")
+	}
+
+	solrCloud.Spec.SolrSecurity.ManagedSecurity.Users = nil
+	solrCloud.Spec.SolrSecurity.BootstrapSecurityJson = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "security"}, Key: "security.json"}
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "Managing security from the bootstrap security.json should be valid")
+}
+
+func TestSolrCloudWebhookValidateCredentialRotation(t *testing.T) {
+	solrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			SolrSecurity: &SolrSecurityOptions{
+				AuthenticationType: Basic,
+				CredentialRotation: &SolrCredentialRotationOptions{Schedule: "0 6 1 */3 *"},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	_, err := (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "Credential rotation for the generated basicAuthSecret should be valid")
+
+	solrCloud.Spec.SolrSecurity.ProbesRequireAuth = true
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Credential rotation with probesRequireAuth should be rejected") {
+		assert.Contains(t, err.Error(), "spec.solrSecurity.credentialRotation: Forbidden", "Credential rotation with probesRequireAuth should be rejected")
+	}
+
+	solrCloud.Spec.SolrSecurity.ProbesRequireAuth = false
+	solrCloud.Spec.SolrSecurity.BasicAuthSecret = "my-basic-auth"
+	solrCloud.Spec.SolrSecurity.CredentialRotation.Schedule = "every 90 days"
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Invalid credential rotation options should be rejected") {
+		assert.Contains(t, err.Error(), "spec.solrSecurity.credentialRotation: Forbidden", "Credential rotation with a user-provided basicAuthSecret should be rejected")
+		assert.Contains(t, err.Error(), "spec.solrSecurity.credentialRotation.schedule: Invalid value", "An invalid schedule should be rejected")
+	}
+}
+
+func TestSolrCloudWebhookValidateJWT(t *testing.T) {
+	solrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			SolrSecurity: &SolrSecurityOptions{
+				AuthenticationType: JWT,
+				JWT: &SolrJWTOptions{
+					Issuer:  "https://idp.example.com",
+					JwksUrl: "https://idp.example.com/jwks",
+					OperatorAuth: SolrJWTOperatorAuth{
+						ClientCredentials: &SolrJWTClientCredentials{TokenUrl: "https://idp.example.com/token", ClientSecret: "solr-operator-client"},
+					},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	_, err := (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "JWT authentication with a jwksUrl and client credentials should be valid")
+
+	solrCloud.Spec.SolrSecurity.JWT.JwkSetSecret = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "jwks"}, Key: "jwks.json"}
+	solrCloud.Spec.SolrSecurity.JWT.OperatorAuth.SigningKeySecret = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "signing-key"}, Key: "key.pem"}
+	solrCloud.Spec.SolrSecurity.ProbesRequireAuth = true
+	solrCloud.Spec.SolrSecurity.CredentialRotation = &SolrCredentialRotationOptions{Schedule: "@monthly"}
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Invalid JWT options should be rejected") {
+		assert.Contains(t, err.Error(), "exactly one of jwksUrl or jwkSetSecret must be specified", "Both a jwksUrl and a jwkSetSecret should be rejected")
+		assert.Contains(t, err.Error(), "exactly one of clientCredentials or signingKeySecret must be specified", "Both clientCredentials and a signingKeySecret should be rejected")
+		assert.Contains(t, err.Error(), "spec.solrSecurity.probesRequireAuth: Forbidden", "probesRequireAuth should be rejected for JWT authentication")
+		assert.Contains(t, err.Error(), "spec.solrSecurity.credentialRotation: Forbidden", "credentialRotation should be rejected for JWT authentication")
+	}
+
+	solrCloud.Spec.SolrSecurity.AuthenticationType = Basic
+	solrCloud.Spec.SolrSecurity.ProbesRequireAuth = false
+	solrCloud.Spec.SolrSecurity.CredentialRotation = nil
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "JWT options should be rejected for Basic authentication") {
+		assert.Contains(t, err.Error(), "spec.solrSecurity.jwt: Forbidden", "Wrong error for JWT options with Basic authentication")
+	}
+
+	solrCloud.Spec.SolrSecurity.AuthenticationType = JWT
+	solrCloud.Spec.SolrSecurity.JWT = nil
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "JWT authentication without JWT options should be rejected") {
+		assert.Contains(t, err.Error(), "spec.solrSecurity.jwt: Required value", "Wrong error for missing JWT options")
+	}
+}
+
+func TestSolrCloudWebhookValidateCertAuth(t *testing.T) {
+	solrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			SolrTLS: &SolrTLSOptions{
+				PKCS12Secret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "solr-tls"}, Key: "keystore.p12"},
+				ClientAuth:   Need,
+			},
+			SolrSecurity: &SolrSecurityOptions{
+				AuthenticationType: Cert,
+				OperatorClientCert: "solr-operator-cert",
+				Cert: &SolrCertAuthOptions{
+					UserRoles: map[string][]string{"CN=alice,O=Example": {"admin"}},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	_, err := (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "Cert authentication with an operatorClientCert and required client auth should be valid")
+
+	solrCloud.Spec.SolrTLS.ClientAuth = None
+	solrCloud.Spec.SolrSecurity.OperatorClientCert = ""
+	solrCloud.Spec.SolrSecurity.ManagedSecurity = &SolrManagedSecurityOptions{}
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Invalid Cert options should be rejected") {
+		assert.Contains(t, err.Error(), "spec.solrTLS.clientAuth: Invalid value", "Cert authentication without client auth should be rejected")
+		assert.Contains(t, err.Error(), "spec.solrSecurity.operatorClientCert: Required value", "Cert authentication without an operatorClientCert should be rejected")
+		assert.Contains(t, err.Error(), "spec.solrSecurity.managedSecurity: Forbidden", "managedSecurity should be rejected for Cert authentication")
+	}
+
+	solrCloud.Spec.SolrTLS.ClientAuth = Want
+	solrCloud.Spec.SolrSecurity.ManagedSecurity = nil
+	solrCloud.Spec.SolrSecurity.BootstrapSecurityJson = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "security"}, Key: "security.json"}
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "Cert authentication with a bootstrapSecurityJson does not need an operatorClientCert")
+
+	solrCloud.Spec.SolrTLS = nil
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Cert authentication without TLS should be rejected") {
+		assert.Contains(t, err.Error(), "spec.solrTLS: Required value", "Wrong error for Cert authentication without TLS")
+	}
+
+	solrCloud.Spec.SolrSecurity.AuthenticationType = Basic
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "Cert options should be rejected for Basic authentication") {
+		assert.Contains(t, err.Error(), "spec.solrSecurity.cert: Forbidden", "Wrong error for Cert options with Basic authentication")
+	}
+}
+
+func TestSolrCloudWebhookValidateAzureRepository(t *testing.T) {
+	solrCloud := &SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: SolrCloudSpec{
+			BackupRepositories: []SolrBackupRepository{
+				{
+					Name: "azure-repo",
+					Azure: &AzureRepository{
+						Container:   "backups",
+						AccountName: "devstoreaccount1",
+						Endpoint:    "http://azurite:10000/devstoreaccount1",
+						Credentials: &AzureCredentials{
+							AccountKeySecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "azure"}, Key: "account-key"},
+						},
+					},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	_, err := (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	assert.NoError(t, err, "An Azure repository with an account key should be valid")
+
+	solrCloud.Spec.BackupRepositories[0].Azure.Credentials.SasTokenSecret = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "azure"}, Key: "sas-token"}
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "An Azure repository with both an account key and a SAS token should be rejected") {
+		assert.Contains(t, err.Error(), "spec.backupRepositories[0].azure.credentials: Invalid value", "Wrong error for conflicting Azure credentials")
+	}
+
+	solrCloud.Spec.BackupRepositories[0].S3 = &S3Repository{Bucket: "bucket", Region: "us-west-2"}
+	_, err = (&solrCloudValidator{}).ValidateCreate(context.Background(), solrCloud)
+	if assert.Error(t, err, "A repository with both Azure and S3 should be rejected") {
+		assert.Contains(t, err.Error(), "exactly one of gcs, s3, azure, hdfs or volume must be specified", "Wrong error for multiple repository types")
+	}
+}
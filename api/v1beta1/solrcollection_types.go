@@ -0,0 +1,357 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DefaultCollectionNumShards int32 = 1
+)
+
+// SolrCollectionSpec defines the desired state of SolrCollection
+//
+// +kubebuilder:validation:XValidation:rule="(has(self.nrtReplicas) ? self.nrtReplicas : (has(self.replicationFactor) ? self.replicationFactor : (has(self.tlogReplicas) && self.tlogReplicas > 0 ? 0 : 1))) + (has(self.tlogReplicas) ? self.tlogReplicas : 0) >= 1",message="Each shard must have at least one NRT or TLOG replica, since PULL replicas cannot become leaders"
+type SolrCollectionSpec struct {
+	// A reference to the SolrCloud to create the collection in
+	//
+	// +kubebuilder:validation:Pattern:=[a-z0-9]([-a-z0-9]*[a-z0-9])?
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	SolrCloud string `json:"solrCloud"`
+
+	// The name of the collection in Solr.
+	// Defaults to the name of the SolrCollection resource.
+	// This cannot be changed after the collection has been created.
+	//
+	// +kubebuilder:validation:Pattern:=[a-zA-Z0-9_]([-._a-zA-Z0-9]*)?
+	// +optional
+	CollectionName string `json:"collectionName,omitempty"`
+
+	// The name of the configset, already uploaded to the SolrCloud, that the collection should use.
+	// If not provided, Solr will use its default configset.
+	//
+	// +optional
+	ConfigSet string `json:"configSet,omitempty"`
+
+	// The number of shards to create the collection with.
+	// Only used when the collection is created, and when using the "compositeId" router.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	NumShards *int32 `json:"numShards,omitempty"`
+
+	// The number of NRT replicas to create for each shard, used when nrtReplicas is not provided.
+	// If neither are provided, this defaults to 1 when there are no TLOG replicas, otherwise 0.
+	//
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	ReplicationFactor *int32 `json:"replicationFactor,omitempty"`
+
+	// The number of NRT replicas to maintain for each shard.
+	// Takes precedence over replicationFactor.
+	//
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	NrtReplicas *int32 `json:"nrtReplicas,omitempty"`
+
+	// The number of TLOG replicas to maintain for each shard.
+	//
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	TlogReplicas *int32 `json:"tlogReplicas,omitempty"`
+
+	// The number of PULL replicas to maintain for each shard.
+	//
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	PullReplicas *int32 `json:"pullReplicas,omitempty"`
+
+	// Options for routing documents to the shards of the collection.
+	// This cannot be changed after the collection has been created.
+	//
+	// +optional
+	Router *SolrCollectionRouterOptions `json:"router,omitempty"`
+}
+
+func (spec *SolrCollectionSpec) withDefaults(name string) (changed bool) {
+	if spec.CollectionName == "" {
+		changed = true
+		spec.CollectionName = name
+	}
+
+	if spec.Router == nil {
+		changed = true
+		spec.Router = &SolrCollectionRouterOptions{}
+	}
+	changed = spec.Router.withDefaults() || changed
+
+	if spec.NumShards == nil && spec.Router.Name == CompositeIdRouter {
+		changed = true
+		ns := DefaultCollectionNumShards
+		spec.NumShards = &ns
+	}
+
+	return changed
+}
+
+// CreationOptions returns the options, from the spec, that can only be used when the collection is created.
+func (spec *SolrCollectionSpec) CreationOptions() *SolrCollectionCreationOptions {
+	options := &SolrCollectionCreationOptions{}
+	if spec.Router != nil {
+		options.RouterName = spec.Router.Name
+		options.RouterField = spec.Router.Field
+	}
+	if spec.NumShards != nil && options.RouterName != ImplicitRouter {
+		numShards := *spec.NumShards
+		options.NumShards = &numShards
+	}
+	return options
+}
+
+// DesiredReplicasPerShard returns the number of NRT, TLOG and PULL replicas that each shard should have.
+// This follows the same defaulting that Solr uses when creating a collection.
+func (spec *SolrCollectionSpec) DesiredReplicasPerShard() (nrt int, tlog int, pull int) {
+	if spec.TlogReplicas != nil {
+		tlog = int(*spec.TlogReplicas)
+	}
+	if spec.PullReplicas != nil {
+		pull = int(*spec.PullReplicas)
+	}
+	if spec.NrtReplicas != nil {
+		nrt = int(*spec.NrtReplicas)
+	} else if spec.ReplicationFactor != nil {
+		nrt = int(*spec.ReplicationFactor)
+	} else if tlog == 0 {
+		nrt = 1
+	}
+	return
+}
+
+// HasLeaderEligibleReplicas returns whether each shard has at least one NRT or TLOG replica, which is required for the shard to have a leader.
+func (spec *SolrCollectionSpec) HasLeaderEligibleReplicas() bool {
+	nrt, tlog, _ := spec.DesiredReplicasPerShard()
+	return nrt+tlog > 0
+}
+
+// SolrCollectionRouterOptions defines how documents are routed to the shards of a collection
+type SolrCollectionRouterOptions struct {
+	// The router to use for the collection.
+	//
+	// +kubebuilder:default=compositeId
+	// +optional
+	Name SolrCollectionRouterName `json:"name,omitempty"`
+
+	// The field in each document to use when routing it to a shard.
+	// If not provided, the uniqueKey of the collection is used.
+	//
+	// +optional
+	Field string `json:"field,omitempty"`
+
+	// The names of the shards to create, required when using the "implicit" router.
+	// Shards that are added to this list after the collection has been created will be created as well.
+	//
+	// +optional
+	Shards []string `json:"shards,omitempty"`
+}
+
+func (opts *SolrCollectionRouterOptions) withDefaults() (changed bool) {
+	if opts.Name == "" {
+		changed = true
+		opts.Name = CompositeIdRouter
+	}
+	return changed
+}
+
+// SolrCollectionRouterName is a string enumeration type that enumerates the ways that documents can be routed for a collection.
+// +kubebuilder:validation:Enum=compositeId;implicit
+type SolrCollectionRouterName string
+
+const (
+	CompositeIdRouter SolrCollectionRouterName = "compositeId"
+	ImplicitRouter    SolrCollectionRouterName = "implicit"
+)
+
+// SolrCollectionHealth is a string enumeration type that enumerates the health states of a collection.
+type SolrCollectionHealth string
+
+const (
+	// CollectionHealthy means that all replicas of the collection are active
+	CollectionHealthy SolrCollectionHealth = "Healthy"
+	// CollectionDegraded means that every shard has an active leader, but some replicas are not active
+	CollectionDegraded SolrCollectionHealth = "Degraded"
+	// CollectionDown means that at least one shard has no active leader, and cannot serve requests
+	CollectionDown SolrCollectionHealth = "Down"
+	// CollectionMissing means that the collection was created, but no longer exists in Solr
+	CollectionMissing SolrCollectionHealth = "Missing"
+)
+
+// SolrCollectionStatus defines the observed state of SolrCollection
+type SolrCollectionStatus struct {
+	// The name of the collection in Solr
+	// +optional
+	CollectionName string `json:"collectionName,omitempty"`
+
+	// Whether the collection has been created in Solr
+	// +optional
+	Created bool `json:"created,omitempty"`
+
+	// The options that the collection was created with, which cannot be changed afterwards
+	// +optional
+	CreationOptions *SolrCollectionCreationOptions `json:"creationOptions,omitempty"`
+
+	// The configset used by the collection
+	// +optional
+	ConfigSet string `json:"configSet,omitempty"`
+
+	// The overall health of the collection, determined from the state of its shards and replicas
+	// +optional
+	Health SolrCollectionHealth `json:"health,omitempty"`
+
+	// The number of active replicas across all shards of the collection
+	// +optional
+	ActiveReplicas int `json:"activeReplicas,omitempty"`
+
+	// The number of replicas across all shards of the collection
+	// +optional
+	TotalReplicas int `json:"totalReplicas,omitempty"`
+
+	// A human-readable summary of the active and total replicas of the collection, e.g. "5/6"
+	// +optional
+	ReplicasReady string `json:"replicasReady,omitempty"`
+
+	// The status of each shard in the collection
+	// +optional
+	Shards []SolrCollectionShardStatus `json:"shards,omitempty"`
+}
+
+// SolrCollectionCreationOptions records the options of a SolrCollection that are only used when the collection is created
+type SolrCollectionCreationOptions struct {
+	// The number of shards that the collection was created with, when using the "compositeId" router
+	// +optional
+	NumShards *int32 `json:"numShards,omitempty"`
+
+	// The router that the collection was created with
+	// +optional
+	RouterName SolrCollectionRouterName `json:"routerName,omitempty"`
+
+	// The field that the collection routes documents with
+	// +optional
+	RouterField string `json:"routerField,omitempty"`
+}
+
+// SolrCollectionShardStatus defines the observed state of a shard in a Solr Collection
+type SolrCollectionShardStatus struct {
+	// The name of the shard
+	Name string `json:"name"`
+
+	// The state of the shard in Solr
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// The name of the replica that is the leader for this shard
+	// +optional
+	Leader string `json:"leader,omitempty"`
+
+	// The status of each replica of this shard
+	// +optional
+	Replicas []SolrCollectionReplicaStatus `json:"replicas,omitempty"`
+}
+
+// SolrCollectionReplicaStatus defines the observed state of a replica in a Solr Collection
+type SolrCollectionReplicaStatus struct {
+	// The name of the replica
+	Name string `json:"name"`
+
+	// The name of the core for the replica
+	// +optional
+	Core string `json:"core,omitempty"`
+
+	// The Solr node that hosts the replica
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// The type of the replica, NRT, TLOG or PULL
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// The state of the replica in Solr
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// Whether this replica is the leader of its shard
+	// +optional
+	Leader bool `json:"leader,omitempty"`
+}
+
+func (sc *SolrCollection) SharedLabels() map[string]string {
+	return sc.SharedLabelsWith(map[string]string{})
+}
+
+func (sc *SolrCollection) SharedLabelsWith(labels map[string]string) map[string]string {
+	newLabels := map[string]string{}
+
+	if labels != nil {
+		for k, v := range labels {
+			newLabels[k] = v
+		}
+	}
+
+	newLabels["solr-collection"] = sc.Name
+	return newLabels
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:storageversion
+//+kubebuilder:categories=all
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Cloud",type="string",JSONPath=".spec.solrCloud",description="Solr Cloud"
+//+kubebuilder:printcolumn:name="Collection",type="string",JSONPath=".status.collectionName",description="Name of the collection in Solr"
+//+kubebuilder:printcolumn:name="Created",type="boolean",JSONPath=".status.created",description="Whether the collection has been created"
+//+kubebuilder:printcolumn:name="Health",type="string",JSONPath=".status.health",description="Health of the collection"
+//+kubebuilder:printcolumn:name="Replicas",type="string",JSONPath=".status.replicasReady",description="Active and total replicas of the collection"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SolrCollection is the Schema for the solrcollections API
+type SolrCollection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SolrCollectionSpec   `json:"spec,omitempty"`
+	Status SolrCollectionStatus `json:"status,omitempty"`
+}
+
+// WithDefaults set default values when not defined in the spec.
+func (sc *SolrCollection) WithDefaults() bool {
+	return sc.Spec.withDefaults(sc.Name)
+}
+
+//+kubebuilder:object:root=true
+
+// SolrCollectionList contains a list of SolrCollection
+type SolrCollectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SolrCollection `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SolrCollection{}, &SolrCollectionList{})
+}
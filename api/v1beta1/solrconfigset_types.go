@@ -0,0 +1,144 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SolrConfigSetSpec defines the desired state of SolrConfigSet
+type SolrConfigSetSpec struct {
+	// A reference to the SolrCloud to upload the configset to
+	//
+	// +kubebuilder:validation:Pattern:=[a-z0-9]([-a-z0-9]*[a-z0-9])?
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	SolrCloud string `json:"solrCloud"`
+
+	// The name of the configset in Solr.
+	// Defaults to the name of the SolrConfigSet resource.
+	// This cannot be changed after the configset has been uploaded.
+	//
+	// +kubebuilder:validation:Pattern:=[a-zA-Z0-9_]([-._a-zA-Z0-9]*)?
+	// +optional
+	ConfigSetName string `json:"configSetName,omitempty"`
+
+	// ConfigMaps containing the files of the configset.
+	// Each key in a ConfigMap is a file, placed under the path given for that ConfigMap.
+	// Either this or zipSecret must be provided, but not both.
+	//
+	// +optional
+	ConfigMaps []ConfigSetConfigMapSource `json:"configMaps,omitempty"`
+
+	// A key in a Secret that contains a zip file of the configset.
+	// Either this or configMaps must be provided, but not both.
+	//
+	// +optional
+	ZipSecret *corev1.SecretKeySelector `json:"zipSecret,omitempty"`
+
+	// Reload the collections that use this configset, whenever a changed configset is uploaded.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	ReloadCollectionsOnChange bool `json:"reloadCollectionsOnChange,omitempty"`
+}
+
+func (spec *SolrConfigSetSpec) withDefaults(name string) (changed bool) {
+	if spec.ConfigSetName == "" {
+		changed = true
+		spec.ConfigSetName = name
+	}
+	return changed
+}
+
+// ConfigSetConfigMapSource defines a ConfigMap to take configset files from
+type ConfigSetConfigMapSource struct {
+	// The name of the ConfigMap, in the same namespace as the SolrConfigSet
+	//
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// The directory, relative to the root of the configset, to place the files of this ConfigMap in.
+	// Since ConfigMap keys cannot contain "/", this is how files in sub-directories (e.g. "lang") are provided.
+	// Defaults to the root of the configset.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// SolrConfigSetStatus defines the observed state of SolrConfigSet
+type SolrConfigSetStatus struct {
+	// The name of the configset in Solr
+	// +optional
+	ConfigSetName string `json:"configSetName,omitempty"`
+
+	// Whether the configset has been uploaded to Solr
+	// +optional
+	Uploaded bool `json:"uploaded,omitempty"`
+
+	// The SHA-256 hash of the configset zip that was last uploaded to Solr
+	// +optional
+	ContentHash string `json:"contentHash,omitempty"`
+
+	// The time that the configset was last uploaded to Solr
+	// +optional
+	LastUploadTime *metav1.Time `json:"lastUploadTimestamp,omitempty"`
+
+	// The collections that use this configset, and have not yet been reloaded since the last upload
+	// +optional
+	CollectionsPendingReload []string `json:"collectionsPendingReload,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:storageversion
+//+kubebuilder:categories=all
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Cloud",type="string",JSONPath=".spec.solrCloud",description="Solr Cloud"
+//+kubebuilder:printcolumn:name="ConfigSet",type="string",JSONPath=".status.configSetName",description="Name of the configset in Solr"
+//+kubebuilder:printcolumn:name="Uploaded",type="boolean",JSONPath=".status.uploaded",description="Whether the configset has been uploaded"
+//+kubebuilder:printcolumn:name="LastUpload",type="date",JSONPath=".status.lastUploadTimestamp",description="Most recent time the configset was uploaded"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SolrConfigSet is the Schema for the solrconfigsets API
+type SolrConfigSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SolrConfigSetSpec   `json:"spec,omitempty"`
+	Status SolrConfigSetStatus `json:"status,omitempty"`
+}
+
+// WithDefaults set default values when not defined in the spec.
+func (scs *SolrConfigSet) WithDefaults() bool {
+	return scs.Spec.withDefaults(scs.Name)
+}
+
+//+kubebuilder:object:root=true
+
+// SolrConfigSetList contains a list of SolrConfigSet
+type SolrConfigSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SolrConfigSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SolrConfigSet{}, &SolrConfigSetList{})
+}
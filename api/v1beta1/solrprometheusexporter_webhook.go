@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"github.com/robfig/cron/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks for SolrPrometheusExporters.
+func (spe *SolrPrometheusExporter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(spe).
+		WithDefaulter(&solrPrometheusExporterDefaulter{}).
+		WithValidator(&solrPrometheusExporterValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-solr-apache-org-v1beta1-solrprometheusexporter,mutating=true,failurePolicy=fail,sideEffects=None,groups=solr.apache.org,resources=solrprometheusexporters,verbs=create;update,versions=v1beta1,name=msolrprometheusexporter.solr.apache.org,admissionReviewVersions=v1
+
+type solrPrometheusExporterDefaulter struct{}
+
+var _ admission.CustomDefaulter = &solrPrometheusExporterDefaulter{}
+
+// Default sets the default values for a SolrPrometheusExporter when it is created or updated.
+func (d *solrPrometheusExporterDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	exporter, ok := obj.(*SolrPrometheusExporter)
+	if !ok {
+		return fmt.Errorf("expected a SolrPrometheusExporter but got a %T", obj)
+	}
+	// The namespace is not always set on the object when it is being created, so default to the namespace of the request.
+	if exporter.Namespace == "" {
+		if req, err := admission.RequestFromContext(ctx); err == nil {
+			exporter.Namespace = req.Namespace
+		}
+	}
+	exporter.WithDefaults()
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-solr-apache-org-v1beta1-solrprometheusexporter,mutating=false,failurePolicy=fail,sideEffects=None,groups=solr.apache.org,resources=solrprometheusexporters,verbs=create;update,versions=v1beta1,name=vsolrprometheusexporter.solr.apache.org,admissionReviewVersions=v1
+
+type solrPrometheusExporterValidator struct{}
+
+var _ admission.CustomValidator = &solrPrometheusExporterValidator{}
+
+// ValidateCreate validates a new SolrPrometheusExporter.
+func (v *solrPrometheusExporterValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	exporter, ok := obj.(*SolrPrometheusExporter)
+	if !ok {
+		return nil, fmt.Errorf("expected a SolrPrometheusExporter but got a %T", obj)
+	}
+	return nil, exporter.validate()
+}
+
+// ValidateUpdate validates an updated SolrPrometheusExporter.
+func (v *solrPrometheusExporterValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	exporter, ok := newObj.(*SolrPrometheusExporter)
+	if !ok {
+		return nil, fmt.Errorf("expected a SolrPrometheusExporter but got a %T", newObj)
+	}
+	return nil, exporter.validate()
+}
+
+// ValidateDelete does nothing, SolrPrometheusExporters can always be deleted.
+func (v *solrPrometheusExporterValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate returns an Invalid error containing all problems with the SolrPrometheusExporter spec, or nil if there are none.
+func (spe *SolrPrometheusExporter) validate() error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	solrReference := spe.Spec.SolrReference
+	solrReferencePath := specPath.Child("solrReference")
+	if solrReference.Cloud == nil && solrReference.Standalone == nil {
+		allErrs = append(allErrs, field.Required(solrReferencePath, "one of cloud or standalone must be specified"))
+	} else if solrReference.Cloud != nil && solrReference.Standalone != nil {
+		allErrs = append(allErrs, field.Forbidden(solrReferencePath, "only one of cloud or standalone can be specified"))
+	}
+	if cloud := solrReference.Cloud; cloud != nil {
+		if cloud.Name == "" && cloud.ZookeeperConnectionInfo == nil {
+			allErrs = append(allErrs, field.Required(solrReferencePath.Child("cloud"), "one of name or zkConnectionInfo must be specified"))
+		} else if cloud.Name != "" && cloud.ZookeeperConnectionInfo != nil {
+			allErrs = append(allErrs, field.Forbidden(solrReferencePath.Child("cloud"), "only one of name or zkConnectionInfo can be specified"))
+		}
+	}
+
+	if spe.Spec.RestartSchedule != "" {
+		if _, err := cron.ParseStandard(spe.Spec.RestartSchedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("restartSchedule"), spe.Spec.RestartSchedule, err.Error()))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("SolrPrometheusExporter").GroupKind(), spe.Name, allErrs)
+}
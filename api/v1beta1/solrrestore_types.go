@@ -0,0 +1,220 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SolrRestoreSpec defines the desired state of SolrRestore
+type SolrRestoreSpec struct {
+	// A reference to the SolrCloud to restore the collections into
+	//
+	// +kubebuilder:validation:Pattern:=[a-z0-9]([-a-z0-9]*[a-z0-9])?
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=63
+	SolrCloud string `json:"solrCloud"`
+
+	// A reference to the SolrBackup, in the same namespace, to restore from.
+	// The repository, location, backup name and list of collections will be taken from this SolrBackup, unless overridden in this spec.
+	//
+	// Either this or backupName must be provided.
+	//
+	// +kubebuilder:validation:Pattern:=[a-z0-9]([-a-z0-9]*[a-z0-9])?
+	// +kubebuilder:validation:MaxLength:=253
+	// +optional
+	SolrBackup string `json:"solrBackup,omitempty"`
+
+	// The name of the backup to restore from, when not using a SolrBackup reference.
+	// This should be the name of the SolrBackup resource that took the backup, even if that resource no longer exists.
+	// The backup of each collection is expected to be named "<backupName>-<collection>" in the repository,
+	// which can be overridden per-collection through collections[].backupName.
+	//
+	// Either this or solrBackup must be provided.
+	//
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// The name of the repository to restore from.
+	// Defaults to the repository of the referenced SolrBackup, or the only repository defined in the SolrCloud.
+	//
+	// +kubebuilder:validation:Pattern:=[a-zA-Z0-9]([-_a-zA-Z0-9]*[a-zA-Z0-9])?
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=100
+	// +optional
+	RepositoryName string `json:"repositoryName,omitempty"`
+
+	// The location of the backup in the specified backup repository.
+	// Defaults to the location of the referenced SolrBackup.
+	//
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// The list of collections to restore.
+	// If not provided, all collections successfully backed up by the latest successful backup of the referenced SolrBackup will be restored.
+	// This must be provided if solrBackup is not.
+	//
+	// +listType:=map
+	// +listMapKey:=name
+	// +optional
+	Collections []RestoreCollection `json:"collections,omitempty"`
+}
+
+// RestoreCollection defines a Solr Collection to restore, and the name to restore it as
+type RestoreCollection struct {
+	// The name of the collection that was backed up
+	//
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// The name of the collection to create with the restored data.
+	// The collection must not already exist in the SolrCloud.
+	// Defaults to the name of the collection that was backed up.
+	//
+	// +optional
+	RestoreAs string `json:"restoreAs,omitempty"`
+
+	// The full name of this collection's backup in the repository.
+	// Only necessary if the backup was not taken by the Solr Operator, defaults to "<backupName>-<collection>".
+	//
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+}
+
+// TargetCollection returns the name of the collection that this backup will be restored into
+func (rc *RestoreCollection) TargetCollection() string {
+	if rc.RestoreAs != "" {
+		return rc.RestoreAs
+	}
+	return rc.Name
+}
+
+// SolrRestoreStatus defines the observed state of SolrRestore
+type SolrRestoreStatus struct {
+	// Version of the Solr being restored into
+	// +optional
+	SolrVersion string `json:"solrVersion,omitempty"`
+
+	// The time that this restore was initiated
+	// +optional
+	StartTime metav1.Time `json:"startTimestamp,omitempty"`
+
+	// The status of each collection's restore progress
+	// +optional
+	CollectionRestoreStatuses []CollectionRestoreStatus `json:"collectionRestoreStatuses,omitempty"`
+
+	// The time that this restore was finished
+	// +optional
+	FinishTime *metav1.Time `json:"finishTimestamp,omitempty"`
+
+	// Whether the restore was successful
+	// +optional
+	Successful *bool `json:"successful,omitempty"`
+
+	// Whether the restore has finished
+	// +optional
+	Finished bool `json:"finished,omitempty"`
+}
+
+// CollectionRestoreStatus defines the progress of a Solr Collection's restore
+type CollectionRestoreStatus struct {
+	// Solr Collection name that was backed up
+	Collection string `json:"collection"`
+
+	// Solr Collection name that is being restored into
+	// +optional
+	RestoredAs string `json:"restoredAs,omitempty"`
+
+	// BackupName of this collection's backup in Solr
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// Whether the collection is being restored
+	// +optional
+	InProgress bool `json:"inProgress,omitempty"`
+
+	// Time that the collection restore started at
+	// +optional
+	StartTime *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// The status of the asynchronous restore call to solr
+	// +optional
+	AsyncRestoreStatus string `json:"asyncRestoreStatus,omitempty"`
+
+	// Whether the restore has finished
+	Finished bool `json:"finished,omitempty"`
+
+	// Time that the collection restore finished at
+	// +optional
+	FinishTime *metav1.Time `json:"finishTimestamp,omitempty"`
+
+	// Whether the restore was successful
+	// +optional
+	Successful *bool `json:"successful,omitempty"`
+}
+
+func (sr *SolrRestore) SharedLabels() map[string]string {
+	return sr.SharedLabelsWith(map[string]string{})
+}
+
+func (sr *SolrRestore) SharedLabelsWith(labels map[string]string) map[string]string {
+	newLabels := map[string]string{}
+
+	if labels != nil {
+		for k, v := range labels {
+			newLabels[k] = v
+		}
+	}
+
+	newLabels["solr-restore"] = sr.Name
+	return newLabels
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:storageversion
+//+kubebuilder:categories=all
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Cloud",type="string",JSONPath=".spec.solrCloud",description="Solr Cloud"
+//+kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".spec.solrBackup",description="Solr Backup"
+//+kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTimestamp",description="Time the restore started"
+//+kubebuilder:printcolumn:name="Finished",type="boolean",JSONPath=".status.finished",description="Whether the restore has finished"
+//+kubebuilder:printcolumn:name="Successful",type="boolean",JSONPath=".status.successful",description="Whether the restore was successful"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SolrRestore is the Schema for the solrrestores API
+type SolrRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SolrRestoreSpec   `json:"spec,omitempty"`
+	Status SolrRestoreStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SolrRestoreList contains a list of SolrRestore
+type SolrRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SolrRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SolrRestore{}, &SolrRestoreList{})
+}
@@ -24,7 +24,9 @@ package v1beta1
 import (
 	apiv1beta1 "github.com/pravega/zookeeper-operator/api/v1beta1"
 	"k8s.io/api/core/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -49,9 +51,83 @@ func (in *AdditionalVolume) DeepCopy() *AdditionalVolume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureCredentials) DeepCopyInto(out *AzureCredentials) {
+	*out = *in
+	if in.AccountKeySecret != nil {
+		in, out := &in.AccountKeySecret, &out.AccountKeySecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SasTokenSecret != nil {
+		in, out := &in.SasTokenSecret, &out.SasTokenSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureCredentials.
+func (in *AzureCredentials) DeepCopy() *AzureCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureRepository) DeepCopyInto(out *AzureRepository) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(AzureCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureRepository.
+func (in *AzureRepository) DeepCopy() *AzureRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPoint) DeepCopyInto(out *BackupPoint) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.IndexSize != nil {
+		in, out := &in.IndexSize, &out.IndexSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPoint.
+func (in *BackupPoint) DeepCopy() *BackupPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupRecurrence) DeepCopyInto(out *BackupRecurrence) {
 	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(BackupRetention)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRecurrence.
@@ -64,6 +140,58 @@ func (in *BackupRecurrence) DeepCopy() *BackupRecurrence {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetention) DeepCopyInto(out *BackupRetention) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetention.
+func (in *BackupRetention) DeepCopy() *BackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerification) DeepCopyInto(out *BackupVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerification.
+func (in *BackupVerification) DeepCopy() *BackupVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionBackupCatalog) DeepCopyInto(out *CollectionBackupCatalog) {
+	*out = *in
+	if in.BackupPoints != nil {
+		in, out := &in.BackupPoints, &out.BackupPoints
+		*out = make([]BackupPoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionBackupCatalog.
+func (in *CollectionBackupCatalog) DeepCopy() *CollectionBackupCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionBackupCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CollectionBackupStatus) DeepCopyInto(out *CollectionBackupStatus) {
 	*out = *in
@@ -80,6 +208,11 @@ func (in *CollectionBackupStatus) DeepCopyInto(out *CollectionBackupStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(CollectionBackupVerification)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionBackupStatus.
@@ -92,6 +225,82 @@ func (in *CollectionBackupStatus) DeepCopy() *CollectionBackupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionBackupVerification) DeepCopyInto(out *CollectionBackupVerification) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SourceNumDocs != nil {
+		in, out := &in.SourceNumDocs, &out.SourceNumDocs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RestoredNumDocs != nil {
+		in, out := &in.RestoredNumDocs, &out.RestoredNumDocs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SourceQueryNumFound != nil {
+		in, out := &in.SourceQueryNumFound, &out.SourceQueryNumFound
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RestoredQueryNumFound != nil {
+		in, out := &in.RestoredQueryNumFound, &out.RestoredQueryNumFound
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Successful != nil {
+		in, out := &in.Successful, &out.Successful
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionBackupVerification.
+func (in *CollectionBackupVerification) DeepCopy() *CollectionBackupVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionBackupVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionRestoreStatus) DeepCopyInto(out *CollectionRestoreStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Successful != nil {
+		in, out := &in.Successful, &out.Successful
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionRestoreStatus.
+func (in *CollectionRestoreStatus) DeepCopy() *CollectionRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapOptions) DeepCopyInto(out *ConfigMapOptions) {
 	*out = *in
@@ -121,6 +330,21 @@ func (in *ConfigMapOptions) DeepCopy() *ConfigMapOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigSetConfigMapSource) DeepCopyInto(out *ConfigSetConfigMapSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSetConfigMapSource.
+func (in *ConfigSetConfigMapSource) DeepCopy() *ConfigSetConfigMapSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigSetConfigMapSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerImage) DeepCopyInto(out *ContainerImage) {
 	*out = *in
@@ -263,6 +487,16 @@ func (in *ExternalAddressability) DeepCopyInto(out *ExternalAddressability) {
 		*out = new(SolrIngressTLSTermination)
 		**out = **in
 	}
+	if in.LoadBalancer != nil {
+		in, out := &in.LoadBalancer, &out.LoadBalancer
+		*out = new(SolrLoadBalancerOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(SolrGatewayOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAddressability.
@@ -295,6 +529,42 @@ func (in *GcsRepository) DeepCopy() *GcsRepository {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HdfsKerberosOptions) DeepCopyInto(out *HdfsKerberosOptions) {
+	*out = *in
+	in.KeytabSecret.DeepCopyInto(&out.KeytabSecret)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HdfsKerberosOptions.
+func (in *HdfsKerberosOptions) DeepCopy() *HdfsKerberosOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HdfsKerberosOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HdfsRepository) DeepCopyInto(out *HdfsRepository) {
+	*out = *in
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(HdfsKerberosOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HdfsRepository.
+func (in *HdfsRepository) DeepCopy() *HdfsRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(HdfsRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IndividualSolrBackupStatus) DeepCopyInto(out *IndividualSolrBackupStatus) {
 	*out = *in
@@ -315,6 +585,11 @@ func (in *IndividualSolrBackupStatus) DeepCopyInto(out *IndividualSolrBackupStat
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Verified != nil {
+		in, out := &in.Verified, &out.Verified
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndividualSolrBackupStatus.
@@ -543,6 +818,21 @@ func (in *PodOptions) DeepCopy() *PodOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreCollection) DeepCopyInto(out *RestoreCollection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreCollection.
+func (in *RestoreCollection) DeepCopy() *RestoreCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3Credentials) DeepCopyInto(out *S3Credentials) {
 	*out = *in
@@ -647,6 +937,83 @@ func (in *SolrAddressabilityOptions) DeepCopy() *SolrAddressabilityOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrAutoscalerMetrics) DeepCopyInto(out *SolrAutoscalerMetrics) {
+	*out = *in
+	if in.CPUUtilizationPercentage != nil {
+		in, out := &in.CPUUtilizationPercentage, &out.CPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueryRate != nil {
+		in, out := &in.QueryRate, &out.QueryRate
+		*out = new(SolrAutoscalerPodMetric)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskUsage != nil {
+		in, out := &in.DiskUsage, &out.DiskUsage
+		*out = new(SolrAutoscalerPodMetric)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrAutoscalerMetrics.
+func (in *SolrAutoscalerMetrics) DeepCopy() *SolrAutoscalerMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrAutoscalerMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrAutoscalerOptions) DeepCopyInto(out *SolrAutoscalerOptions) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Metrics.DeepCopyInto(&out.Metrics)
+	if in.ScaleDownCooldownSeconds != nil {
+		in, out := &in.ScaleDownCooldownSeconds, &out.ScaleDownCooldownSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleUpCooldownSeconds != nil {
+		in, out := &in.ScaleUpCooldownSeconds, &out.ScaleUpCooldownSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrAutoscalerOptions.
+func (in *SolrAutoscalerOptions) DeepCopy() *SolrAutoscalerOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrAutoscalerOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrAutoscalerPodMetric) DeepCopyInto(out *SolrAutoscalerPodMetric) {
+	*out = *in
+	out.TargetAverageValue = in.TargetAverageValue.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrAutoscalerPodMetric.
+func (in *SolrAutoscalerPodMetric) DeepCopy() *SolrAutoscalerPodMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrAutoscalerPodMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SolrAvailabilityOptions) DeepCopyInto(out *SolrAvailabilityOptions) {
 	*out = *in
@@ -735,6 +1102,16 @@ func (in *SolrBackupRepository) DeepCopyInto(out *SolrBackupRepository) {
 		*out = new(S3Repository)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureRepository)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HDFS != nil {
+		in, out := &in.HDFS, &out.HDFS
+		*out = new(HdfsRepository)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volume != nil {
 		in, out := &in.Volume, &out.Volume
 		*out = new(VolumeRepository)
@@ -763,7 +1140,12 @@ func (in *SolrBackupSpec) DeepCopyInto(out *SolrBackupSpec) {
 	if in.Recurrence != nil {
 		in, out := &in.Recurrence, &out.Recurrence
 		*out = new(BackupRecurrence)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(BackupVerification)
+		**out = **in
 	}
 }
 
@@ -792,6 +1174,17 @@ func (in *SolrBackupStatus) DeepCopyInto(out *SolrBackupStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = make([]CollectionBackupCatalog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CatalogRefreshTime != nil {
+		in, out := &in.CatalogRefreshTime, &out.CatalogRefreshTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrBackupStatus.
@@ -804,6 +1197,37 @@ func (in *SolrBackupStatus) DeepCopy() *SolrBackupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrCertAuthOptions) DeepCopyInto(out *SolrCertAuthOptions) {
+	*out = *in
+	if in.UserRoles != nil {
+		in, out := &in.UserRoles, &out.UserRoles
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrCertAuthOptions.
+func (in *SolrCertAuthOptions) DeepCopy() *SolrCertAuthOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrCertAuthOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SolrCloud) DeepCopyInto(out *SolrCloud) {
 	*out = *in
@@ -903,7 +1327,19 @@ func (in *SolrCloudSpec) DeepCopyInto(out *SolrCloudSpec) {
 	}
 	in.StorageOptions.DeepCopyInto(&out.StorageOptions)
 	in.CustomSolrKubeOptions.DeepCopyInto(&out.CustomSolrKubeOptions)
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]SolrNodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.SolrAddressability.DeepCopyInto(&out.SolrAddressability)
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(SolrNetworkPolicyOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
 	in.Availability.DeepCopyInto(&out.Availability)
 	in.Scaling.DeepCopyInto(&out.Scaling)
@@ -962,7 +1398,9 @@ func (in *SolrCloudStatus) DeepCopyInto(out *SolrCloudStatus) {
 	if in.SolrNodes != nil {
 		in, out := &in.SolrNodes, &out.SolrNodes
 		*out = make([]SolrNodeStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ExternalCommonAddress != nil {
 		in, out := &in.ExternalCommonAddress, &out.ExternalCommonAddress
@@ -977,6 +1415,25 @@ func (in *SolrCloudStatus) DeepCopyInto(out *SolrCloudStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.ClusterOperation != nil {
+		in, out := &in.ClusterOperation, &out.ClusterOperation
+		*out = new(SolrClusterOperationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QueuedClusterOperations != nil {
+		in, out := &in.QueuedClusterOperations, &out.QueuedClusterOperations
+		*out = make([]SolrClusterOperationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrCloudStatus.
@@ -990,142 +1447,258 @@ func (in *SolrCloudStatus) DeepCopy() *SolrCloudStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrDataStorageOptions) DeepCopyInto(out *SolrDataStorageOptions) {
+func (in *SolrClusterOperationStatus) DeepCopyInto(out *SolrClusterOperationStatus) {
 	*out = *in
-	if in.PersistentStorage != nil {
-		in, out := &in.PersistentStorage, &out.PersistentStorage
-		*out = new(SolrPersistentDataStorageOptions)
-		(*in).DeepCopyInto(*out)
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.NodesDone != nil {
+		in, out := &in.NodesDone, &out.NodesDone
+		*out = new(int32)
+		**out = **in
 	}
-	if in.EphemeralStorage != nil {
-		in, out := &in.EphemeralStorage, &out.EphemeralStorage
-		*out = new(SolrEphemeralDataStorageOptions)
-		(*in).DeepCopyInto(*out)
+	if in.NodesTotal != nil {
+		in, out := &in.NodesTotal, &out.NodesTotal
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrDataStorageOptions.
-func (in *SolrDataStorageOptions) DeepCopy() *SolrDataStorageOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrClusterOperationStatus.
+func (in *SolrClusterOperationStatus) DeepCopy() *SolrClusterOperationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SolrDataStorageOptions)
+	out := new(SolrClusterOperationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrEphemeralDataStorageOptions) DeepCopyInto(out *SolrEphemeralDataStorageOptions) {
+func (in *SolrCollection) DeepCopyInto(out *SolrCollection) {
 	*out = *in
-	if in.HostPath != nil {
-		in, out := &in.HostPath, &out.HostPath
-		*out = new(v1.HostPathVolumeSource)
-		(*in).DeepCopyInto(*out)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrCollection.
+func (in *SolrCollection) DeepCopy() *SolrCollection {
+	if in == nil {
+		return nil
 	}
-	if in.EmptyDir != nil {
-		in, out := &in.EmptyDir, &out.EmptyDir
-		*out = new(v1.EmptyDirVolumeSource)
-		(*in).DeepCopyInto(*out)
+	out := new(SolrCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SolrCollection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrEphemeralDataStorageOptions.
-func (in *SolrEphemeralDataStorageOptions) DeepCopy() *SolrEphemeralDataStorageOptions {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrCollectionCreationOptions) DeepCopyInto(out *SolrCollectionCreationOptions) {
+	*out = *in
+	if in.NumShards != nil {
+		in, out := &in.NumShards, &out.NumShards
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrCollectionCreationOptions.
+func (in *SolrCollectionCreationOptions) DeepCopy() *SolrCollectionCreationOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(SolrEphemeralDataStorageOptions)
+	out := new(SolrCollectionCreationOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrIngressTLSTermination) DeepCopyInto(out *SolrIngressTLSTermination) {
+func (in *SolrCollectionList) DeepCopyInto(out *SolrCollectionList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SolrCollection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrIngressTLSTermination.
-func (in *SolrIngressTLSTermination) DeepCopy() *SolrIngressTLSTermination {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrCollectionList.
+func (in *SolrCollectionList) DeepCopy() *SolrCollectionList {
 	if in == nil {
 		return nil
 	}
-	out := new(SolrIngressTLSTermination)
+	out := new(SolrCollectionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SolrCollectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrNodeStatus) DeepCopyInto(out *SolrNodeStatus) {
+func (in *SolrCollectionReplicaStatus) DeepCopyInto(out *SolrCollectionReplicaStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrNodeStatus.
-func (in *SolrNodeStatus) DeepCopy() *SolrNodeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrCollectionReplicaStatus.
+func (in *SolrCollectionReplicaStatus) DeepCopy() *SolrCollectionReplicaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SolrNodeStatus)
+	out := new(SolrCollectionReplicaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrPersistentDataStorageOptions) DeepCopyInto(out *SolrPersistentDataStorageOptions) {
+func (in *SolrCollectionRouterOptions) DeepCopyInto(out *SolrCollectionRouterOptions) {
 	*out = *in
-	in.PersistentVolumeClaimTemplate.DeepCopyInto(&out.PersistentVolumeClaimTemplate)
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPersistentDataStorageOptions.
-func (in *SolrPersistentDataStorageOptions) DeepCopy() *SolrPersistentDataStorageOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrCollectionRouterOptions.
+func (in *SolrCollectionRouterOptions) DeepCopy() *SolrCollectionRouterOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(SolrPersistentDataStorageOptions)
+	out := new(SolrCollectionRouterOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrPodDisruptionBudgetOptions) DeepCopyInto(out *SolrPodDisruptionBudgetOptions) {
+func (in *SolrCollectionShardStatus) DeepCopyInto(out *SolrCollectionShardStatus) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]SolrCollectionReplicaStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrCollectionShardStatus.
+func (in *SolrCollectionShardStatus) DeepCopy() *SolrCollectionShardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrCollectionShardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrCollectionSpec) DeepCopyInto(out *SolrCollectionSpec) {
+	*out = *in
+	if in.NumShards != nil {
+		in, out := &in.NumShards, &out.NumShards
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReplicationFactor != nil {
+		in, out := &in.ReplicationFactor, &out.ReplicationFactor
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NrtReplicas != nil {
+		in, out := &in.NrtReplicas, &out.NrtReplicas
+		*out = new(int32)
 		**out = **in
 	}
+	if in.TlogReplicas != nil {
+		in, out := &in.TlogReplicas, &out.TlogReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PullReplicas != nil {
+		in, out := &in.PullReplicas, &out.PullReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Router != nil {
+		in, out := &in.Router, &out.Router
+		*out = new(SolrCollectionRouterOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPodDisruptionBudgetOptions.
-func (in *SolrPodDisruptionBudgetOptions) DeepCopy() *SolrPodDisruptionBudgetOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrCollectionSpec.
+func (in *SolrCollectionSpec) DeepCopy() *SolrCollectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SolrPodDisruptionBudgetOptions)
+	out := new(SolrCollectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrPrometheusExporter) DeepCopyInto(out *SolrPrometheusExporter) {
+func (in *SolrCollectionStatus) DeepCopyInto(out *SolrCollectionStatus) {
+	*out = *in
+	if in.CreationOptions != nil {
+		in, out := &in.CreationOptions, &out.CreationOptions
+		*out = new(SolrCollectionCreationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = make([]SolrCollectionShardStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrCollectionStatus.
+func (in *SolrCollectionStatus) DeepCopy() *SolrCollectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrCollectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrConfigSet) DeepCopyInto(out *SolrConfigSet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPrometheusExporter.
-func (in *SolrPrometheusExporter) DeepCopy() *SolrPrometheusExporter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrConfigSet.
+func (in *SolrConfigSet) DeepCopy() *SolrConfigSet {
 	if in == nil {
 		return nil
 	}
-	out := new(SolrPrometheusExporter)
+	out := new(SolrConfigSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SolrPrometheusExporter) DeepCopyObject() runtime.Object {
+func (in *SolrConfigSet) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1133,31 +1706,31 @@ func (in *SolrPrometheusExporter) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrPrometheusExporterList) DeepCopyInto(out *SolrPrometheusExporterList) {
+func (in *SolrConfigSetList) DeepCopyInto(out *SolrConfigSetList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SolrPrometheusExporter, len(*in))
+		*out = make([]SolrConfigSet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPrometheusExporterList.
-func (in *SolrPrometheusExporterList) DeepCopy() *SolrPrometheusExporterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrConfigSetList.
+func (in *SolrConfigSetList) DeepCopy() *SolrConfigSetList {
 	if in == nil {
 		return nil
 	}
-	out := new(SolrPrometheusExporterList)
+	out := new(SolrConfigSetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SolrPrometheusExporterList) DeepCopyObject() runtime.Object {
+func (in *SolrConfigSetList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1165,89 +1738,739 @@ func (in *SolrPrometheusExporterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrPrometheusExporterSpec) DeepCopyInto(out *SolrPrometheusExporterSpec) {
+func (in *SolrConfigSetSpec) DeepCopyInto(out *SolrConfigSetSpec) {
 	*out = *in
-	in.SolrReference.DeepCopyInto(&out.SolrReference)
-	if in.Image != nil {
-		in, out := &in.Image, &out.Image
-		*out = new(ContainerImage)
-		**out = **in
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]ConfigSetConfigMapSource, len(*in))
+		copy(*out, *in)
 	}
-	in.CustomKubeOptions.DeepCopyInto(&out.CustomKubeOptions)
-	if in.BusyBoxImage != nil {
-		in, out := &in.BusyBoxImage, &out.BusyBoxImage
-		*out = new(ContainerImage)
-		**out = **in
+	if in.ZipSecret != nil {
+		in, out := &in.ZipSecret, &out.ZipSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPrometheusExporterSpec.
-func (in *SolrPrometheusExporterSpec) DeepCopy() *SolrPrometheusExporterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrConfigSetSpec.
+func (in *SolrConfigSetSpec) DeepCopy() *SolrConfigSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SolrPrometheusExporterSpec)
+	out := new(SolrConfigSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrPrometheusExporterStatus) DeepCopyInto(out *SolrPrometheusExporterStatus) {
+func (in *SolrConfigSetStatus) DeepCopyInto(out *SolrConfigSetStatus) {
 	*out = *in
+	if in.LastUploadTime != nil {
+		in, out := &in.LastUploadTime, &out.LastUploadTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CollectionsPendingReload != nil {
+		in, out := &in.CollectionsPendingReload, &out.CollectionsPendingReload
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPrometheusExporterStatus.
-func (in *SolrPrometheusExporterStatus) DeepCopy() *SolrPrometheusExporterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrConfigSetStatus.
+func (in *SolrConfigSetStatus) DeepCopy() *SolrConfigSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SolrPrometheusExporterStatus)
+	out := new(SolrConfigSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrReference) DeepCopyInto(out *SolrReference) {
+func (in *SolrCredentialRotationOptions) DeepCopyInto(out *SolrCredentialRotationOptions) {
 	*out = *in
-	if in.Cloud != nil {
-		in, out := &in.Cloud, &out.Cloud
-		*out = new(SolrCloudReference)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Standalone != nil {
-		in, out := &in.Standalone, &out.Standalone
-		*out = new(StandaloneSolrReference)
-		**out = **in
-	}
-	if in.SolrTLS != nil {
-		in, out := &in.SolrTLS, &out.SolrTLS
-		*out = new(SolrTLSOptions)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrReference.
-func (in *SolrReference) DeepCopy() *SolrReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrCredentialRotationOptions.
+func (in *SolrCredentialRotationOptions) DeepCopy() *SolrCredentialRotationOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(SolrReference)
+	out := new(SolrCredentialRotationOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SolrScalingOptions) DeepCopyInto(out *SolrScalingOptions) {
+func (in *SolrDataStorageOptions) DeepCopyInto(out *SolrDataStorageOptions) {
 	*out = *in
-	if in.VacatePodsOnScaleDown != nil {
-		in, out := &in.VacatePodsOnScaleDown, &out.VacatePodsOnScaleDown
-		*out = new(bool)
-		**out = **in
+	if in.PersistentStorage != nil {
+		in, out := &in.PersistentStorage, &out.PersistentStorage
+		*out = new(SolrPersistentDataStorageOptions)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.PopulatePodsOnScaleUp != nil {
-		in, out := &in.PopulatePodsOnScaleUp, &out.PopulatePodsOnScaleUp
-		*out = new(bool)
-		**out = **in
+	if in.EphemeralStorage != nil {
+		in, out := &in.EphemeralStorage, &out.EphemeralStorage
+		*out = new(SolrEphemeralDataStorageOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrDataStorageOptions.
+func (in *SolrDataStorageOptions) DeepCopy() *SolrDataStorageOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrDataStorageOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrDataVolumeSnapshotOptions) DeepCopyInto(out *SolrDataVolumeSnapshotOptions) {
+	*out = *in
+	if in.RetentionHours != nil {
+		in, out := &in.RetentionHours, &out.RetentionHours
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrDataVolumeSnapshotOptions.
+func (in *SolrDataVolumeSnapshotOptions) DeepCopy() *SolrDataVolumeSnapshotOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrDataVolumeSnapshotOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrEphemeralDataStorageOptions) DeepCopyInto(out *SolrEphemeralDataStorageOptions) {
+	*out = *in
+	if in.HostPath != nil {
+		in, out := &in.HostPath, &out.HostPath
+		*out = new(v1.HostPathVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(v1.EmptyDirVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrEphemeralDataStorageOptions.
+func (in *SolrEphemeralDataStorageOptions) DeepCopy() *SolrEphemeralDataStorageOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrEphemeralDataStorageOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrGatewayOptions) DeepCopyInto(out *SolrGatewayOptions) {
+	*out = *in
+	out.ParentRef = in.ParentRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrGatewayOptions.
+func (in *SolrGatewayOptions) DeepCopy() *SolrGatewayOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrGatewayOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrGatewayParentReference) DeepCopyInto(out *SolrGatewayParentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrGatewayParentReference.
+func (in *SolrGatewayParentReference) DeepCopy() *SolrGatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrGatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrIngressTLSTermination) DeepCopyInto(out *SolrIngressTLSTermination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrIngressTLSTermination.
+func (in *SolrIngressTLSTermination) DeepCopy() *SolrIngressTLSTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrIngressTLSTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrJWTClientCredentials) DeepCopyInto(out *SolrJWTClientCredentials) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrJWTClientCredentials.
+func (in *SolrJWTClientCredentials) DeepCopy() *SolrJWTClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrJWTClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrJWTOperatorAuth) DeepCopyInto(out *SolrJWTOperatorAuth) {
+	*out = *in
+	if in.ClientCredentials != nil {
+		in, out := &in.ClientCredentials, &out.ClientCredentials
+		*out = new(SolrJWTClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SigningKeySecret != nil {
+		in, out := &in.SigningKeySecret, &out.SigningKeySecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrJWTOperatorAuth.
+func (in *SolrJWTOperatorAuth) DeepCopy() *SolrJWTOperatorAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrJWTOperatorAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrJWTOptions) DeepCopyInto(out *SolrJWTOptions) {
+	*out = *in
+	if in.JwkSetSecret != nil {
+		in, out := &in.JwkSetSecret, &out.JwkSetSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClaimsMatch != nil {
+		in, out := &in.ClaimsMatch, &out.ClaimsMatch
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.UserRoles != nil {
+		in, out := &in.UserRoles, &out.UserRoles
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	in.OperatorAuth.DeepCopyInto(&out.OperatorAuth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrJWTOptions.
+func (in *SolrJWTOptions) DeepCopy() *SolrJWTOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrJWTOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrLoadBalancerOptions) DeepCopyInto(out *SolrLoadBalancerOptions) {
+	*out = *in
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CommonServiceAnnotations != nil {
+		in, out := &in.CommonServiceAnnotations, &out.CommonServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeServiceAnnotations != nil {
+		in, out := &in.NodeServiceAnnotations, &out.NodeServiceAnnotations
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrLoadBalancerOptions.
+func (in *SolrLoadBalancerOptions) DeepCopy() *SolrLoadBalancerOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrLoadBalancerOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrManagedSecurityOptions) DeepCopyInto(out *SolrManagedSecurityOptions) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]SolrSecurityUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]SolrSecurityPermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrManagedSecurityOptions.
+func (in *SolrManagedSecurityOptions) DeepCopy() *SolrManagedSecurityOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrManagedSecurityOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrNetworkPolicyOptions) DeepCopyInto(out *SolrNetworkPolicyOptions) {
+	*out = *in
+	if in.AllowedClients != nil {
+		in, out := &in.AllowedClients, &out.AllowedClients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowedZookeeperClients != nil {
+		in, out := &in.AllowedZookeeperClients, &out.AllowedZookeeperClients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SolrOperator != nil {
+		in, out := &in.SolrOperator, &out.SolrOperator
+		*out = new(networkingv1.NetworkPolicyPeer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrNetworkPolicyOptions.
+func (in *SolrNetworkPolicyOptions) DeepCopy() *SolrNetworkPolicyOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrNetworkPolicyOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrNodePool) DeepCopyInto(out *SolrNodePool) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodOptions != nil {
+		in, out := &in.PodOptions, &out.PodOptions
+		*out = new(PodOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageOptions != nil {
+		in, out := &in.StorageOptions, &out.StorageOptions
+		*out = new(SolrDataStorageOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrNodePool.
+func (in *SolrNodePool) DeepCopy() *SolrNodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrNodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrNodeStatus) DeepCopyInto(out *SolrNodeStatus) {
+	*out = *in
+	if in.DataVolumeResize != nil {
+		in, out := &in.DataVolumeResize, &out.DataVolumeResize
+		*out = new(SolrVolumeResizeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrNodeStatus.
+func (in *SolrNodeStatus) DeepCopy() *SolrNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrPersistentDataStorageOptions) DeepCopyInto(out *SolrPersistentDataStorageOptions) {
+	*out = *in
+	in.PersistentVolumeClaimTemplate.DeepCopyInto(&out.PersistentVolumeClaimTemplate)
+	if in.DeletionSnapshots != nil {
+		in, out := &in.DeletionSnapshots, &out.DeletionSnapshots
+		*out = new(SolrDataVolumeSnapshotOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPersistentDataStorageOptions.
+func (in *SolrPersistentDataStorageOptions) DeepCopy() *SolrPersistentDataStorageOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrPersistentDataStorageOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrPodDisruptionBudgetOptions) DeepCopyInto(out *SolrPodDisruptionBudgetOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPodDisruptionBudgetOptions.
+func (in *SolrPodDisruptionBudgetOptions) DeepCopy() *SolrPodDisruptionBudgetOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrPodDisruptionBudgetOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrPrometheusExporter) DeepCopyInto(out *SolrPrometheusExporter) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPrometheusExporter.
+func (in *SolrPrometheusExporter) DeepCopy() *SolrPrometheusExporter {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrPrometheusExporter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SolrPrometheusExporter) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrPrometheusExporterList) DeepCopyInto(out *SolrPrometheusExporterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SolrPrometheusExporter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPrometheusExporterList.
+func (in *SolrPrometheusExporterList) DeepCopy() *SolrPrometheusExporterList {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrPrometheusExporterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SolrPrometheusExporterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrPrometheusExporterSpec) DeepCopyInto(out *SolrPrometheusExporterSpec) {
+	*out = *in
+	in.SolrReference.DeepCopyInto(&out.SolrReference)
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(ContainerImage)
+		**out = **in
+	}
+	in.CustomKubeOptions.DeepCopyInto(&out.CustomKubeOptions)
+	if in.BusyBoxImage != nil {
+		in, out := &in.BusyBoxImage, &out.BusyBoxImage
+		*out = new(ContainerImage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPrometheusExporterSpec.
+func (in *SolrPrometheusExporterSpec) DeepCopy() *SolrPrometheusExporterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrPrometheusExporterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrPrometheusExporterStatus) DeepCopyInto(out *SolrPrometheusExporterStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrPrometheusExporterStatus.
+func (in *SolrPrometheusExporterStatus) DeepCopy() *SolrPrometheusExporterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrPrometheusExporterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrReference) DeepCopyInto(out *SolrReference) {
+	*out = *in
+	if in.Cloud != nil {
+		in, out := &in.Cloud, &out.Cloud
+		*out = new(SolrCloudReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Standalone != nil {
+		in, out := &in.Standalone, &out.Standalone
+		*out = new(StandaloneSolrReference)
+		**out = **in
+	}
+	if in.SolrTLS != nil {
+		in, out := &in.SolrTLS, &out.SolrTLS
+		*out = new(SolrTLSOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrReference.
+func (in *SolrReference) DeepCopy() *SolrReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrRestore) DeepCopyInto(out *SolrRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrRestore.
+func (in *SolrRestore) DeepCopy() *SolrRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SolrRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrRestoreList) DeepCopyInto(out *SolrRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SolrRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrRestoreList.
+func (in *SolrRestoreList) DeepCopy() *SolrRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SolrRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrRestoreSpec) DeepCopyInto(out *SolrRestoreSpec) {
+	*out = *in
+	if in.Collections != nil {
+		in, out := &in.Collections, &out.Collections
+		*out = make([]RestoreCollection, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrRestoreSpec.
+func (in *SolrRestoreSpec) DeepCopy() *SolrRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrRestoreStatus) DeepCopyInto(out *SolrRestoreStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CollectionRestoreStatuses != nil {
+		in, out := &in.CollectionRestoreStatuses, &out.CollectionRestoreStatuses
+		*out = make([]CollectionRestoreStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Successful != nil {
+		in, out := &in.Successful, &out.Successful
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrRestoreStatus.
+func (in *SolrRestoreStatus) DeepCopy() *SolrRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrScalingOptions) DeepCopyInto(out *SolrScalingOptions) {
+	*out = *in
+	if in.VacatePodsOnScaleDown != nil {
+		in, out := &in.VacatePodsOnScaleDown, &out.VacatePodsOnScaleDown
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PopulatePodsOnScaleUp != nil {
+		in, out := &in.PopulatePodsOnScaleUp, &out.PopulatePodsOnScaleUp
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Autoscaler != nil {
+		in, out := &in.Autoscaler, &out.Autoscaler
+		*out = new(SolrAutoscalerOptions)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1264,11 +2487,31 @@ func (in *SolrScalingOptions) DeepCopy() *SolrScalingOptions {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SolrSecurityOptions) DeepCopyInto(out *SolrSecurityOptions) {
 	*out = *in
+	if in.Cert != nil {
+		in, out := &in.Cert, &out.Cert
+		*out = new(SolrCertAuthOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(SolrJWTOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BootstrapSecurityJson != nil {
 		in, out := &in.BootstrapSecurityJson, &out.BootstrapSecurityJson
 		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ManagedSecurity != nil {
+		in, out := &in.ManagedSecurity, &out.ManagedSecurity
+		*out = new(SolrManagedSecurityOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialRotation != nil {
+		in, out := &in.CredentialRotation, &out.CredentialRotation
+		*out = new(SolrCredentialRotationOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrSecurityOptions.
@@ -1281,6 +2524,78 @@ func (in *SolrSecurityOptions) DeepCopy() *SolrSecurityOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrSecurityPermission) DeepCopyInto(out *SolrSecurityPermission) {
+	*out = *in
+	if in.Collection != nil {
+		in, out := &in.Collection, &out.Collection
+		*out = new(string)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Method != nil {
+		in, out := &in.Method, &out.Method
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrSecurityPermission.
+func (in *SolrSecurityPermission) DeepCopy() *SolrSecurityPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrSecurityPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrSecurityUser) DeepCopyInto(out *SolrSecurityUser) {
+	*out = *in
+	in.PasswordSecret.DeepCopyInto(&out.PasswordSecret)
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrSecurityUser.
+func (in *SolrSecurityUser) DeepCopy() *SolrSecurityUser {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrSecurityUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SolrTLSOptions) DeepCopyInto(out *SolrTLSOptions) {
 	*out = *in
@@ -1337,6 +2652,27 @@ func (in *SolrUpdateStrategy) DeepCopy() *SolrUpdateStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SolrVolumeResizeStatus) DeepCopyInto(out *SolrVolumeResizeStatus) {
+	*out = *in
+	out.RequestedSize = in.RequestedSize.DeepCopy()
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SolrVolumeResizeStatus.
+func (in *SolrVolumeResizeStatus) DeepCopy() *SolrVolumeResizeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SolrVolumeResizeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StandaloneSolrReference) DeepCopyInto(out *StandaloneSolrReference) {
 	*out = *in
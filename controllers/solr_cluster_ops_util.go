@@ -21,17 +21,21 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	solrv1beta1 "github.com/apache/solr-operator/api/v1beta1"
 	"github.com/apache/solr-operator/controllers/util"
 	"github.com/apache/solr-operator/controllers/util/solr_api"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	"net/url"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -49,10 +53,11 @@ type SolrClusterOp struct {
 type SolrClusterOperationType string
 
 const (
-	ScaleDownLock       SolrClusterOperationType = "ScalingDown"
-	ScaleUpLock         SolrClusterOperationType = "ScalingUp"
-	UpdateLock          SolrClusterOperationType = "RollingUpdate"
-	BalanceReplicasLock SolrClusterOperationType = "BalanceReplicas"
+	ScaleDownLock        SolrClusterOperationType = "ScalingDown"
+	ScaleUpLock          SolrClusterOperationType = "ScalingUp"
+	UpdateLock           SolrClusterOperationType = "RollingUpdate"
+	BalanceReplicasLock  SolrClusterOperationType = "BalanceReplicas"
+	StorageMigrationLock SolrClusterOperationType = "StorageMigration"
 )
 
 // RollingUpdateMetadata contains metadata for rolling update cluster operations.
@@ -61,6 +66,70 @@ type RollingUpdateMetadata struct {
 	RequiresReplicaMigration bool `json:"requiresReplicaMigration"`
 }
 
+// scaleClusterOpMetadata returns the metadata for a ScaleUp or ScaleDown cluster operation, the number of nodes to scale a node pool to.
+// The node pool is only named for additional node pools, so the metadata for the default node pool is just the number of nodes.
+func scaleClusterOpMetadata(nodePool string, scaleTo int) string {
+	if nodePool == "" {
+		return strconv.Itoa(scaleTo)
+	}
+	return nodePool + ":" + strconv.Itoa(scaleTo)
+}
+
+// parseScaleClusterOpMetadata returns the node pool and number of nodes to scale to, from the metadata of a ScaleUp or ScaleDown cluster operation.
+func parseScaleClusterOpMetadata(metadata string) (nodePool string, scaleTo int, err error) {
+	if separator := strings.LastIndex(metadata, ":"); separator >= 0 {
+		nodePool = metadata[:separator]
+		metadata = metadata[separator+1:]
+	}
+	scaleTo, err = strconv.Atoi(metadata)
+	return
+}
+
+// nodePoolStatefulSet returns the StatefulSet for the given node pool, or nil if it does not exist.
+func nodePoolStatefulSet(instance *solrv1beta1.SolrCloud, statefulSets []*appsv1.StatefulSet, nodePool string) *appsv1.StatefulSet {
+	statefulSetName := instance.NodePoolStatefulSetName(nodePool)
+	for _, statefulSet := range statefulSets {
+		if statefulSet.Name == statefulSetName {
+			return statefulSet
+		}
+	}
+	return nil
+}
+
+// desiredNodePoolReplicas returns the number of Solr Nodes that the given node pool should have.
+// Node pools that have been removed from the SolrCloud should not have any Solr Nodes.
+func desiredNodePoolReplicas(instance *solrv1beta1.SolrCloud, nodePool string) int {
+	for _, pool := range instance.AllNodePools() {
+		if pool.Name == nodePool && pool.Replicas != nil {
+			return int(*pool.Replicas)
+		}
+	}
+	return 0
+}
+
+// podsOwnedByStatefulSet returns the pods in the podList that belong to the given StatefulSet.
+func podsOwnedByStatefulSet(podList []corev1.Pod, statefulSet *appsv1.StatefulSet) (ownedPods []corev1.Pod) {
+	for _, pod := range podList {
+		if owningStatefulSet(&pod, []*appsv1.StatefulSet{statefulSet}) != nil {
+			ownedPods = append(ownedPods, pod)
+		}
+	}
+	return ownedPods
+}
+
+// owningStatefulSet returns the StatefulSet, out of the given StatefulSets, that owns the pod.
+// If the pod is not owned by any of the StatefulSets, nil is returned.
+func owningStatefulSet(pod *corev1.Pod, statefulSets []*appsv1.StatefulSet) *appsv1.StatefulSet {
+	for _, ownerRef := range pod.OwnerReferences {
+		for _, statefulSet := range statefulSets {
+			if ownerRef.UID == statefulSet.UID {
+				return statefulSet
+			}
+		}
+	}
+	return nil
+}
+
 func clearClusterOpLock(statefulSet *appsv1.StatefulSet) {
 	delete(statefulSet.Annotations, util.ClusterOpsLockAnnotation)
 }
@@ -103,6 +172,102 @@ func GetClusterOpRetryQueue(statefulSet *appsv1.StatefulSet) (clusterOpQueue []S
 	return
 }
 
+// setClusterOpStatus sets the running and queued cluster operations in the SolrCloud status, along with the ClusterOperationInProgress condition,
+// using the clusterOp annotations on the StatefulSet.
+func setClusterOpStatus(solrCloud *solrv1beta1.SolrCloud, status *solrv1beta1.SolrCloudStatus, statefulSet *appsv1.StatefulSet, podList []corev1.Pod) {
+	clusterOp, err := GetCurrentClusterOp(statefulSet)
+	if err != nil {
+		return
+	}
+	queuedOps, _ := GetClusterOpRetryQueue(statefulSet)
+
+	status.QueuedClusterOperations = nil
+	for _, queuedOp := range queuedOps {
+		status.QueuedClusterOperations = append(status.QueuedClusterOperations, solrv1beta1.SolrClusterOperationStatus{
+			Operation: string(queuedOp.Operation),
+			StartTime: queuedOp.LastStartTime,
+			Metadata:  queuedOp.Metadata,
+			Summary:   string(queuedOp.Operation),
+		})
+	}
+
+	if clusterOp != nil {
+		status.ClusterOperation = generateClusterOpStatus(solrCloud, status, clusterOp, podList)
+
+		message := fmt.Sprintf("The %s cluster operation was started at %s", clusterOp.Operation, clusterOp.LastStartTime.UTC().Format(time.RFC3339))
+		if len(queuedOps) > 0 {
+			message += fmt.Sprintf(", with %d operation(s) queued for retry", len(queuedOps))
+		}
+		util.SetSolrCloudCondition(status, solrCloud.Generation, solrv1beta1.SolrCloudClusterOperationInProgress, metav1.ConditionTrue, string(clusterOp.Operation), message)
+	} else if previousOp := solrCloud.Status.ClusterOperation; previousOp != nil && previousOp.Operation == string(ScaleDownLock) && len(queuedOps) == 0 && int32(len(podList)) > solrCloud.TotalReplicas() {
+		// A scale down removes one pod per operation, and the next operation is started in the following reconcile.
+		// Keep showing the scale down in between these operations, so that its progress is not lost.
+		status.ClusterOperation = generateClusterOpStatus(solrCloud, status, &SolrClusterOp{Operation: ScaleDownLock, LastStartTime: previousOp.StartTime, Metadata: previousOp.Metadata}, podList)
+		util.SetSolrCloudCondition(status, solrCloud.Generation, solrv1beta1.SolrCloudClusterOperationInProgress, metav1.ConditionTrue, string(ScaleDownLock), "The ScalingDown cluster operation is continuing with the next Solr Node")
+	} else {
+		status.ClusterOperation = nil
+
+		message := "No cluster operation is running"
+		if len(queuedOps) > 0 {
+			message += fmt.Sprintf(", %d operation(s) queued for retry", len(queuedOps))
+		}
+		util.SetSolrCloudCondition(status, solrCloud.Generation, solrv1beta1.SolrCloudClusterOperationInProgress, metav1.ConditionFalse, solrv1beta1.SolrCloudReasonNoClusterOperation, message)
+	}
+}
+
+// generateClusterOpStatus creates the status for a running cluster operation, including its progress when the operation acts on individual Solr Nodes.
+// The node information in the status must already be populated.
+func generateClusterOpStatus(solrCloud *solrv1beta1.SolrCloud, status *solrv1beta1.SolrCloudStatus, clusterOp *SolrClusterOp, podList []corev1.Pod) *solrv1beta1.SolrClusterOperationStatus {
+	opStatus := &solrv1beta1.SolrClusterOperationStatus{
+		Operation: string(clusterOp.Operation),
+		StartTime: clusterOp.LastStartTime,
+		Metadata:  clusterOp.Metadata,
+		Summary:   string(clusterOp.Operation),
+	}
+
+	hasProgress := true
+	var nodesDone, nodesTotal int32
+	switch clusterOp.Operation {
+	case UpdateLock:
+		nodesDone = status.UpToDateNodes
+		nodesTotal = status.Replicas
+	case ScaleUpLock:
+		if nodePool, desiredPods, err := parseScaleClusterOpMetadata(clusterOp.Metadata); err == nil {
+			nodesTotal = int32(desiredPods)
+			for _, node := range status.SolrNodes {
+				if node.NodePool == nodePool && node.Ready {
+					nodesDone++
+				}
+			}
+			if nodesDone > nodesTotal {
+				nodesDone = nodesTotal
+			}
+		} else {
+			hasProgress = false
+		}
+	case ScaleDownLock:
+		// A scale down only removes one pod per operation, so use the number of pods that need to be removed for the SolrCloud to reach its desired size.
+		// The total is kept from the previous status, so that the progress is not reset as each pod is removed.
+		nodesRemaining := int32(len(podList)) - solrCloud.TotalReplicas()
+		if nodesRemaining < 0 {
+			nodesRemaining = 0
+		}
+		nodesTotal = nodesRemaining
+		if previousOp := solrCloud.Status.ClusterOperation; previousOp != nil && previousOp.Operation == string(ScaleDownLock) && previousOp.NodesTotal != nil && *previousOp.NodesTotal > nodesTotal {
+			nodesTotal = *previousOp.NodesTotal
+		}
+		nodesDone = nodesTotal - nodesRemaining
+	default:
+		hasProgress = false
+	}
+	if hasProgress {
+		opStatus.NodesDone = pointer.Int32(nodesDone)
+		opStatus.NodesTotal = pointer.Int32(nodesTotal)
+		opStatus.Summary = fmt.Sprintf("%s %d/%d", clusterOp.Operation, nodesDone, nodesTotal)
+	}
+	return opStatus
+}
+
 func enqueueCurrentClusterOpForRetry(statefulSet *appsv1.StatefulSet) (hasOp bool, err error) {
 	clusterOp, err := GetCurrentClusterOp(statefulSet)
 	if err != nil || clusterOp == nil {
@@ -150,28 +315,36 @@ func retryNextQueuedClusterOpWithQueue(statefulSet *appsv1.StatefulSet, clusterO
 	return hasOp, err
 }
 
-func determineScaleClusterOpLockIfNecessary(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, statefulSet *appsv1.StatefulSet, scaleDownOpIsQueued bool, podList []corev1.Pod, blockReconciliationOfStatefulSet bool, logger logr.Logger) (clusterOp *SolrClusterOp, retryLaterDuration time.Duration, err error) {
-	desiredPods := int(*instance.Spec.Replicas)
-	configuredPods := int(*statefulSet.Spec.Replicas)
-	if desiredPods != configuredPods {
-		// We do not do a "managed" scale-to-zero operation.
-		// Only do a managed scale down if the desiredPods is positive.
-		// The VacatePodsOnScaleDown option is enabled by default, so treat "nil" like "true"
-		if desiredPods < configuredPods && desiredPods > 0 &&
-			(instance.Spec.Scaling.VacatePodsOnScaleDown == nil || *instance.Spec.Scaling.VacatePodsOnScaleDown) {
-			if len(podList) > configuredPods {
+func determineScaleClusterOpLockIfNecessary(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, statefulSets []*appsv1.StatefulSet, scaleDownOpIsQueued bool, podList []corev1.Pod, blockReconciliationOfStatefulSet bool, logger logr.Logger) (clusterOp *SolrClusterOp, retryLaterDuration time.Duration, err error) {
+	// Each node pool is scaled separately, starting with the first node pool that does not have the desired number of pods
+	for _, statefulSet := range statefulSets {
+		nodePool := statefulSet.Labels[util.SolrNodePoolLabel]
+		desiredPods := desiredNodePoolReplicas(instance, nodePool)
+		configuredPods := int(*statefulSet.Spec.Replicas)
+		if desiredPods == configuredPods {
+			continue
+		}
+		nodePoolPods := podsOwnedByStatefulSet(podList, statefulSet)
+		// We do not do a "managed" scale-to-zero operation for the whole SolrCloud.
+		// Only do a managed scale down if the SolrCloud will still have pods to move the replicas to.
+		// The VacatePodsOnScaleDown option is enabled by default, so treat "nil" like "true".
+		// Scale downs requested by the autoscaler are always managed, so that replicas are not lost.
+		if desiredPods < configuredPods && instance.TotalReplicas() > 0 &&
+			(instance.Spec.Scaling.VacatePodsOnScaleDown == nil || *instance.Spec.Scaling.VacatePodsOnScaleDown || instance.Spec.Scaling.Autoscaler != nil) {
+			if len(nodePoolPods) > configuredPods {
 				// There are too many pods, the statefulSet controller has yet to delete unwanted pods.
 				// Do not start the scale down until these extra pods are deleted.
 				return nil, time.Second * 5, nil
 			}
 			clusterOp = &SolrClusterOp{
 				Operation: ScaleDownLock,
-				Metadata:  strconv.Itoa(configuredPods - 1),
+				Metadata:  scaleClusterOpMetadata(nodePool, configuredPods-1),
 			}
+			return
 		} else if desiredPods > configuredPods && (instance.Spec.Scaling.PopulatePodsOnScaleUp == nil || *instance.Spec.Scaling.PopulatePodsOnScaleUp) {
 			// We need to wait for all pods to become healthy to scale up in a managed fashion, otherwise
 			// the balancing will skip some pods
-			if len(podList) < configuredPods {
+			if len(nodePoolPods) < configuredPods {
 				// There are not enough pods, the statefulSet controller has yet to create the previously desired pods.
 				// Do not start the scale up until these missing pods are created.
 				return nil, time.Second * 5, nil
@@ -183,7 +356,7 @@ func determineScaleClusterOpLockIfNecessary(ctx context.Context, r *SolrCloudRec
 			// We need to make sure that the StatefulSet is updated with these new hostAliases before the scale up occurs.
 			if instance.UsesIndividualNodeServices() && instance.Spec.SolrAddressability.External.UseExternalAddress {
 				for _, pod := range podList {
-					if len(pod.Spec.HostAliases) < desiredPods {
+					if len(pod.Spec.HostAliases) < int(instance.TotalReplicas()) {
 						return nil, time.Second * 5, nil
 					}
 				}
@@ -191,13 +364,15 @@ func determineScaleClusterOpLockIfNecessary(ctx context.Context, r *SolrCloudRec
 
 			clusterOp = &SolrClusterOp{
 				Operation: ScaleUpLock,
-				Metadata:  strconv.Itoa(desiredPods),
+				Metadata:  scaleClusterOpMetadata(nodePool, desiredPods),
 			}
-		} else {
-			err = scaleCloudUnmanaged(ctx, r, statefulSet, desiredPods, logger)
+			return
+		} else if err = scaleCloudUnmanaged(ctx, r, instance, statefulSet, desiredPods, logger); err != nil {
+			return
 		}
-	} else if scaleDownOpIsQueued {
-		// If the statefulSet and the solrCloud have the same number of pods configured, and the queued operation is a scaleDown,
+	}
+	if scaleDownOpIsQueued {
+		// If the statefulSets and the solrCloud have the same number of pods configured, and the queued operation is a scaleDown,
 		// that means the scaleDown was reverted. So there's no reason to change the number of pods.
 		// However, a Replica Balancing should be done just in case, so start it via a new ClusterOperation.
 		clusterOp = &SolrClusterOp{
@@ -210,13 +385,20 @@ func determineScaleClusterOpLockIfNecessary(ctx context.Context, r *SolrCloudRec
 
 // handleManagedCloudScaleDown does the logic of a managed and "locked" cloud scale down operation.
 // This will likely take many reconcile loops to complete, as it is moving replicas away from the pods that will be scaled down.
-func handleManagedCloudScaleDown(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, statefulSet *appsv1.StatefulSet, clusterOp *SolrClusterOp, podList []corev1.Pod, logger logr.Logger) (operationComplete bool, requestInProgress bool, retryLaterDuration time.Duration, err error) {
+func handleManagedCloudScaleDown(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, statefulSets []*appsv1.StatefulSet, clusterOp *SolrClusterOp, podList []corev1.Pod, logger logr.Logger) (operationComplete bool, requestInProgress bool, retryLaterDuration time.Duration, err error) {
+	var nodePool string
 	var scaleDownTo int
-	if scaleDownTo, err = strconv.Atoi(clusterOp.Metadata); err != nil {
+	if nodePool, scaleDownTo, err = parseScaleClusterOpMetadata(clusterOp.Metadata); err != nil {
 		logger.Error(err, "Could not convert ScaleDown metadata to int, as it represents the number of nodes to scale to", "metadata", clusterOp.Metadata)
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonClusterOpInvalidMetadata, "Invalid metadata %q for the %s cluster operation, it must be the number of nodes to scale to", clusterOp.Metadata, clusterOp.Operation)
 		return
-		// TODO: Create event for the CRD.
 	}
+	statefulSet := nodePoolStatefulSet(instance, statefulSets, nodePool)
+	if statefulSet == nil {
+		// The StatefulSet for the node pool no longer exists, so there is nothing left to scale down
+		return true, false, 0, nil
+	}
+	podList = podsOwnedByStatefulSet(podList, statefulSet)
 
 	if len(podList) <= scaleDownTo {
 		// The number of pods is less than we are trying to scaleDown to, so we are done
@@ -248,12 +430,14 @@ func handleManagedCloudScaleDown(ctx context.Context, r *SolrCloudReconciler, in
 	// Only evict the last pod, even if we are trying to scale down multiple pods.
 	// Scale down will happen one pod at a time.
 	var replicaManagementComplete bool
-	if replicaManagementComplete, requestInProgress, err = evictSinglePod(ctx, r, instance, scaleDownTo, podList, podStoppedReadinessConditions, logger); err == nil {
+	if replicaManagementComplete, requestInProgress, err = evictSinglePod(ctx, r, instance, instance.GetSolrPodNameForNodePool(nodePool, scaleDownTo), podList, podStoppedReadinessConditions, "scaleDown", logger); err == nil {
 		if replicaManagementComplete {
 			originalStatefulSet := statefulSet.DeepCopy()
 			statefulSet.Spec.Replicas = pointer.Int32(int32(scaleDownTo))
 			if err = r.Patch(ctx, statefulSet, client.StrategicMergeFrom(originalStatefulSet)); err != nil {
 				logger.Error(err, "Error while patching StatefulSet to scale down pods after eviction", "newStatefulSetReplicas", scaleDownTo)
+			} else {
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonScaledStatefulSet, "Scaled the StatefulSet %s down to %d pods, after migrating replicas off of the removed pod", statefulSet.Name, scaleDownTo)
 			}
 			// Return and wait for the pods to be created, which will call another reconcile
 			retryLaterDuration = 0
@@ -290,21 +474,35 @@ func cleanupManagedCloudScaleDown(ctx context.Context, r *SolrCloudReconciler, p
 
 // handleManagedCloudScaleUp does the logic of a managed and "locked" cloud scale up operation.
 // This will likely take many reconcile loops to complete, as it is moving replicas to the pods that have recently been scaled up.
-func handleManagedCloudScaleUp(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, statefulSet *appsv1.StatefulSet, clusterOp *SolrClusterOp, podList []corev1.Pod, logger logr.Logger) (operationComplete bool, nextClusterOperation *SolrClusterOp, err error) {
-	desiredPods, err := strconv.Atoi(clusterOp.Metadata)
+func handleManagedCloudScaleUp(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, statefulSets []*appsv1.StatefulSet, clusterOp *SolrClusterOp, podList []corev1.Pod, logger logr.Logger) (operationComplete bool, nextClusterOperation *SolrClusterOp, err error) {
+	nodePool, desiredPods, err := parseScaleClusterOpMetadata(clusterOp.Metadata)
 	if err != nil {
 		logger.Error(err, "Could not convert ScaleUp metadata to int, as it represents the number of nodes to scale to", "metadata", clusterOp.Metadata)
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonClusterOpInvalidMetadata, "Invalid metadata %q for the %s cluster operation, it must be the number of nodes to scale to", clusterOp.Metadata, clusterOp.Operation)
 		return
 	}
+	statefulSet := nodePoolStatefulSet(instance, statefulSets, nodePool)
+	if statefulSet == nil {
+		// The StatefulSet for the node pool no longer exists, so it cannot be scaled up
+		return true, nil, nil
+	}
+	podList = podsOwnedByStatefulSet(podList, statefulSet)
 	configuredPods := int(*statefulSet.Spec.Replicas)
 	if configuredPods < desiredPods {
-		// The first thing to do is increase the number of pods the statefulSet is running
+		// The first thing to do is increase the number of pods the statefulSet is running.
+		// The new pods should start with the data of their previous PVCs, if snapshots were taken of them.
+		if err = r.restoreDataVolumesFromSnapshots(ctx, instance, statefulSet, desiredPods, logger); err != nil {
+			logger.Error(err, "Error while restoring data PVCs from VolumeSnapshots for the ScaleUp")
+			return
+		}
 		originalStatefulSet := statefulSet.DeepCopy()
 		statefulSet.Spec.Replicas = pointer.Int32(int32(desiredPods))
 
 		err = r.Patch(ctx, statefulSet, client.StrategicMergeFrom(originalStatefulSet))
 		if err != nil {
 			logger.Error(err, "Error while patching StatefulSet to increase the number of pods for the ScaleUp")
+		} else {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonScaledStatefulSet, "Scaled the StatefulSet %s up to %d pods", statefulSet.Name, desiredPods)
 		}
 	} else if len(podList) >= configuredPods {
 		nextClusterOperation = &SolrClusterOp{
@@ -316,6 +514,130 @@ func handleManagedCloudScaleUp(ctx context.Context, r *SolrCloudReconciler, inst
 	return
 }
 
+// determineStorageMigrationClusterOpLockIfNecessary starts a StorageMigration cluster operation if any Solr pod has a data PVC
+// that does not match the data volumeClaimTemplate of its StatefulSet, such as after the StorageClass has been changed or the size has been decreased.
+// The operation is not started if the storage cannot be migrated safely, which is reported through the StorageMigrationBlocked condition.
+func determineStorageMigrationClusterOpLockIfNecessary(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, status *solrv1beta1.SolrCloudStatus, statefulSets []*appsv1.StatefulSet, pvcLabelSelector map[string]string) (clusterOp *SolrClusterOp, err error) {
+	if !instance.UsesPersistentStorage() {
+		util.RemoveSolrCloudCondition(status, solrv1beta1.SolrCloudStorageMigrationBlocked)
+		return nil, nil
+	}
+	pvcList, err := r.getPVCList(ctx, instance, pvcLabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	podsToMigrate := 0
+	for _, statefulSet := range statefulSets {
+		podsToMigrate += len(util.PodsRequiringStorageMigration(statefulSet, pvcList.Items))
+	}
+	if blockedMessage := storageMigrationBlockedMessage(instance, podsToMigrate); blockedMessage != "" {
+		if condition := meta.FindStatusCondition(status.Conditions, string(solrv1beta1.SolrCloudStorageMigrationBlocked)); condition == nil {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, util.EventReasonStorageMigrationBlocked, blockedMessage)
+		}
+		util.SetSolrCloudCondition(status, instance.Generation, solrv1beta1.SolrCloudStorageMigrationBlocked, metav1.ConditionTrue, solrv1beta1.SolrCloudReasonTooFewSolrNodes, blockedMessage)
+		return nil, nil
+	}
+	util.RemoveSolrCloudCondition(status, solrv1beta1.SolrCloudStorageMigrationBlocked)
+	if podsToMigrate > 0 {
+		clusterOp = &SolrClusterOp{
+			Operation: StorageMigrationLock,
+			Metadata:  strconv.Itoa(podsToMigrate),
+		}
+	}
+	return clusterOp, nil
+}
+
+// storageMigrationBlockedMessage returns why the data PVCs of the SolrCloud cannot be migrated, or an empty string if they can be.
+// The replicas of each pod must be moved to another Solr Node before its data PVC is deleted, which is impossible with a single Solr Node.
+func storageMigrationBlockedMessage(instance *solrv1beta1.SolrCloud, podsToMigrate int) string {
+	if podsToMigrate > 0 && instance.TotalReplicas() < 2 {
+		return fmt.Sprintf("The data PVCs of %d pods need to be migrated to the new data volume template, but the SolrCloud has fewer than 2 Solr Nodes to move the replicas to. Scale up the SolrCloud to migrate its storage", podsToMigrate)
+	}
+	return ""
+}
+
+// handleManagedCloudStorageMigration does the logic of a managed and "locked" storage migration operation.
+// One pod at a time, the replicas are moved off of the pod, and then the pod and its data PVC are deleted,
+// so that the StatefulSet recreates them with the current data volumeClaimTemplate.
+// Once every pod has been migrated, the replicas are balanced across the SolrCloud.
+func handleManagedCloudStorageMigration(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, statefulSets []*appsv1.StatefulSet, podList []corev1.Pod, pvcLabelSelector map[string]string, logger logr.Logger) (operationComplete bool, requestInProgress bool, retryLaterDuration time.Duration, nextClusterOp *SolrClusterOp, err error) {
+	pvcList, err := r.getPVCList(ctx, instance, pvcLabelSelector)
+	if err != nil {
+		return false, false, 0, nil, err
+	}
+	var podName string
+	var pvc *corev1.PersistentVolumeClaim
+	for _, statefulSet := range statefulSets {
+		if podsToMigrate := util.PodsRequiringStorageMigration(statefulSet, pvcList.Items); len(podsToMigrate) > 0 {
+			podName = podsToMigrate[0]
+			pvcName := util.DataVolumeClaimName(statefulSet, podName)
+			for i := range pvcList.Items {
+				if pvcList.Items[i].Name == pvcName {
+					pvc = &pvcList.Items[i]
+				}
+			}
+			break
+		}
+	}
+
+	// Wait for the previously migrated pod to be recreated and become ready before starting the next pod.
+	configuredPods, _ := util.StatefulSetReplicaCounts(statefulSets)
+	if len(podList) < int(configuredPods) {
+		return false, false, time.Second * 5, nil, nil
+	}
+	for _, pod := range podList {
+		if pod.Name != podName && (pod.DeletionTimestamp != nil || !isPodReady(&pod)) {
+			return false, false, time.Second * 5, nil, nil
+		}
+	}
+
+	if podName == "" {
+		// Every pod has been migrated, so balance the replicas onto the migrated pods
+		return true, false, 0, &SolrClusterOp{
+			Operation: BalanceReplicasLock,
+			Metadata:  "StorageMigration",
+		}, nil
+	}
+	logger = logger.WithValues("pod", podName)
+
+	// The SolrCloud may have been scaled down since the operation started, and its replicas cannot be moved without another Solr Node
+	if blockedMessage := storageMigrationBlockedMessage(instance, 1); blockedMessage != "" {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, util.EventReasonStorageMigrationBlocked, blockedMessage)
+		return true, false, 0, nil, nil
+	}
+
+	// Before doing anything to the pod, make sure that users cannot send requests to the pod anymore.
+	podStoppedReadinessConditions := map[corev1.PodConditionType]podReadinessConditionChange{
+		util.SolrIsNotStoppedReadinessCondition: {
+			reason:  StorageMigration,
+			message: "Pod is being deleted to migrate its data volume, traffic to the pod must be stopped",
+			status:  false,
+		},
+	}
+	var podIsEmpty bool
+	if podIsEmpty, requestInProgress, err = evictSinglePod(ctx, r, instance, podName, podList, podStoppedReadinessConditions, "storageMigration", logger); err != nil || !podIsEmpty {
+		// Retry after five seconds to check if the replica management commands have been completed
+		return false, requestInProgress, time.Second * 5, nil, err
+	}
+
+	// The PVC cannot be removed until the pod is deleted, and the StatefulSet will not recreate the pod until the PVC has been removed.
+	// So the StatefulSet will create a new PVC for the pod, using the current data volumeClaimTemplate.
+	// A VolumeSnapshot is taken of the PVC first, if the node pool has deletionSnapshots enabled.
+	logger.Info("Deleting data PVC to migrate the storage of the pod", "persistentVolumeClaim", pvc.Name)
+	if deleted := r.deletePVC(ctx, instance, *pvc, logger); !deleted {
+		// Retry after five seconds to check if the VolumeSnapshot of the PVC is ready to use
+		return false, false, time.Second * 5, nil, nil
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: instance.Namespace}}
+	logger.Info("Deleting pod to migrate its storage")
+	if err = r.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonDeletePodFailed, "Failed to delete pod %s to migrate its data volume: %s", podName, err.Error())
+		return false, false, time.Second * 5, nil, err
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonMigratingStorage, "Deleted pod %s and its data volume, after migrating its replicas, so that it is recreated with the new data volume template", podName)
+	return false, false, time.Second * 5, nil, nil
+}
+
 // hasAnyEphemeralData returns true if any of the given pods uses ephemeral Data for Solr storage, and false if all pods use persistent storage.
 func hasAnyEphemeralData(solrPods []corev1.Pod) bool {
 	for _, pod := range solrPods {
@@ -348,12 +670,13 @@ func determineRollingUpdateClusterOpLockIfNecessary(instance *solrv1beta1.SolrCl
 
 // handleManagedCloudRollingUpdate does the logic of a managed and "locked" cloud rolling update operation.
 // This will take many reconcile loops to complete, as it is deleting pods/moving replicas.
-func handleManagedCloudRollingUpdate(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, statefulSet *appsv1.StatefulSet, clusterOp *SolrClusterOp, outOfDatePods util.OutOfDatePodSegmentation, hasReadyPod bool, availableUpdatedPodCount int, logger logr.Logger) (operationComplete bool, requestInProgress bool, retryLaterDuration time.Duration, nextClusterOp *SolrClusterOp, err error) {
+func handleManagedCloudRollingUpdate(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, statefulSets []*appsv1.StatefulSet, clusterOp *SolrClusterOp, outOfDatePods util.OutOfDatePodSegmentation, hasReadyPod bool, availableUpdatedPodCount int, logger logr.Logger) (operationComplete bool, requestInProgress bool, retryLaterDuration time.Duration, nextClusterOp *SolrClusterOp, err error) {
 	// Manage the updating of out-of-spec pods, if the Managed UpdateStrategy has been specified.
 	updateLogger := logger.WithName("ManagedUpdateSelector")
 
-	// First check if all pods are up to date and ready. If so the rolling update is complete
-	configuredPods := int(*statefulSet.Spec.Replicas)
+	// First check if all pods, across all node pools, are up to date and ready. If so the rolling update is complete
+	totalConfiguredPods, _ := util.StatefulSetReplicaCounts(statefulSets)
+	configuredPods := int(totalConfiguredPods)
 	if configuredPods == availableUpdatedPodCount {
 		updateMetadata := &RollingUpdateMetadata{}
 		if clusterOp.Metadata != "" {
@@ -364,7 +687,7 @@ func handleManagedCloudRollingUpdate(ctx context.Context, r *SolrCloudReconciler
 		operationComplete = true
 		// Only do a re-balancing for rolling restarts that migrated replicas
 		// If a scale-up will occur afterwards, skip the re-balancing, because it will occur after the scale-up anyway
-		if updateMetadata.RequiresReplicaMigration && *instance.Spec.Replicas <= *statefulSet.Spec.Replicas {
+		if updateMetadata.RequiresReplicaMigration && instance.TotalReplicas() <= totalConfiguredPods {
 			nextClusterOp = &SolrClusterOp{
 				Operation: BalanceReplicasLock,
 				Metadata:  "RollingUpdateComplete",
@@ -386,7 +709,7 @@ func handleManagedCloudRollingUpdate(ctx context.Context, r *SolrCloudReconciler
 		// We won't kill pods that we need the cluster state for, but we can kill the pods that are already not running.
 		// This is important for scenarios where there is a bad pod config and nothing is running, but we need to do
 		// a restart to get a working pod config.
-		state, retryLater, apiError := util.GetNodeReplicaState(ctx, instance, statefulSet, hasReadyPod, logger)
+		state, retryLater, apiError := util.GetNodeReplicaState(ctx, instance, statefulSets, hasReadyPod, logger)
 		if apiError != nil {
 			return false, true, 0, nil, apiError
 		} else if !retryLater {
@@ -398,7 +721,7 @@ func handleManagedCloudRollingUpdate(ctx context.Context, r *SolrCloudReconciler
 			// Pick which pods should be deleted for an update.
 			var additionalPodsToUpdate []corev1.Pod
 			additionalPodsToUpdate, retryLater =
-				util.DeterminePodsSafeToUpdate(instance, int(*statefulSet.Spec.Replicas), outOfDatePods, state, availableUpdatedPodCount, updateLogger)
+				util.DeterminePodsSafeToUpdate(instance, configuredPods, outOfDatePods, state, availableUpdatedPodCount, updateLogger)
 			// If we do not have the clusterState, it's not safe to update pods that are running
 			if !retryLater {
 				podsToUpdate = append(podsToUpdate, additionalPodsToUpdate...)
@@ -518,7 +841,11 @@ func retryNextQueuedClusterOpWithPatch(ctx context.Context, r *SolrCloudReconcil
 
 // scaleCloudUnmanaged does simple scaling of a SolrCloud without moving replicas.
 // This is not a "locked" cluster operation, and does not block other cluster operations from taking place.
-func scaleCloudUnmanaged(ctx context.Context, r *SolrCloudReconciler, statefulSet *appsv1.StatefulSet, scaleTo int, logger logr.Logger) (err error) {
+func scaleCloudUnmanaged(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, statefulSet *appsv1.StatefulSet, scaleTo int, logger logr.Logger) (err error) {
+	if err = r.restoreDataVolumesFromSnapshots(ctx, instance, statefulSet, scaleTo, logger); err != nil {
+		logger.Error(err, "Error while restoring data PVCs from VolumeSnapshots before scaling up SolrCloud.")
+		return err
+	}
 	// Before doing anything to the pod, make sure that users cannot send requests to the pod anymore.
 	patchedStatefulSet := statefulSet.DeepCopy()
 	patchedStatefulSet.Spec.Replicas = pointer.Int32(int32(scaleTo))
@@ -555,9 +882,8 @@ func evictAllPods(ctx context.Context, r *SolrCloudReconciler, instance *solrv1b
 	return
 }
 
-func evictSinglePod(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, scaleDownTo int, podList []corev1.Pod, readinessConditions map[corev1.PodConditionType]podReadinessConditionChange, logger logr.Logger) (podIsEmpty bool, requestInProgress bool, err error) {
+func evictSinglePod(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, podName string, podList []corev1.Pod, readinessConditions map[corev1.PodConditionType]podReadinessConditionChange, evictionReason string, logger logr.Logger) (podIsEmpty bool, requestInProgress bool, err error) {
 	var pod *corev1.Pod
-	podName := instance.GetSolrPodName(scaleDownTo)
 	for _, p := range podList {
 		if p.Name == podName {
 			pod = &p
@@ -574,7 +900,7 @@ func evictSinglePod(ctx context.Context, r *SolrCloudReconciler, instance *solrv
 
 	// The pod doesn't exist, we cannot empty it
 	if pod == nil {
-		return !podHasReplicas, false, errors.New("Could not find pod " + podName + " when trying to migrate replicas to scale down pod.")
+		return !podHasReplicas, false, errors.New("Could not find pod " + podName + " when trying to migrate replicas off of the pod, reason: " + evictionReason)
 	}
 
 	if updatedPod, e := EnsurePodReadinessConditions(ctx, r, pod, readinessConditions, logger); e != nil {
@@ -586,8 +912,8 @@ func evictSinglePod(ctx context.Context, r *SolrCloudReconciler, instance *solrv
 
 	// Only evict from the pod if it contains replicas in the clusterState
 	var canDeletePod bool
-	if err, canDeletePod, requestInProgress = util.EvictReplicasForPodIfNecessary(ctx, instance, pod, podHasReplicas, "scaleDown", logger); err != nil {
-		logger.Error(err, "Error while evicting replicas on Pod, when scaling down SolrCloud", "pod", pod.Name)
+	if err, canDeletePod, requestInProgress = util.EvictReplicasForPodIfNecessary(ctx, instance, pod, podHasReplicas, evictionReason, r.Recorder, logger); err != nil {
+		logger.Error(err, "Error while evicting replicas on Pod", "pod", pod.Name, "evictionReason", evictionReason)
 	} else if canDeletePod {
 		// The pod previously had replicas, so loop back in the next reconcile to make sure that the pod doesn't
 		// have replicas anymore even if the previous evict command was successful.
@@ -0,0 +1,204 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	solrv1beta1 "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestStorageMigrationIsBlockedWithOneSolrNode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if !assert.NoError(t, clientgoscheme.AddToScheme(scheme), "Could not build scheme") || !assert.NoError(t, solrv1beta1.AddToScheme(scheme), "Could not build scheme") {
+		return
+	}
+	pvcSpec := func(storageClass string) corev1.PersistentVolumeClaimSpec {
+		return corev1.PersistentVolumeClaimSpec{
+			StorageClassName: pointer.String(storageClass),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		}
+	}
+	solrCloud := &solrv1beta1.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solrv1beta1.SolrCloudSpec{
+			Replicas: pointer.Int32(1),
+			StorageOptions: solrv1beta1.SolrDataStorageOptions{
+				PersistentStorage: &solrv1beta1.SolrPersistentDataStorageOptions{},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	pvcLabels := map[string]string{"technology": "solr-cloud", "solr-cloud": "foo"}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-solrcloud", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32(1),
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "data"}, Spec: pvcSpec("fast")},
+			},
+		},
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-foo-solrcloud-0", Namespace: "default", Labels: pvcLabels},
+		Spec:       pvcSpec("slow"),
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &SolrCloudReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(pvc).Build(),
+		Scheme:   scheme,
+		Recorder: recorder,
+	}
+	status := &solrv1beta1.SolrCloudStatus{}
+
+	clusterOp, err := determineStorageMigrationClusterOpLockIfNecessary(context.Background(), r, solrCloud, status, []*appsv1.StatefulSet{statefulSet}, pvcLabels)
+	assert.NoError(t, err, "Determining the storage migration should not fail")
+	assert.Nil(t, clusterOp, "The storage of a SolrCloud with a single Solr Node must not be migrated, since its replicas cannot be moved")
+	if condition := meta.FindStatusCondition(status.Conditions, string(solrv1beta1.SolrCloudStorageMigrationBlocked)); assert.NotNil(t, condition, "The blocked storage migration should be reported in a condition") {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status, "Wrong status for the StorageMigrationBlocked condition")
+		assert.Equal(t, solrv1beta1.SolrCloudReasonTooFewSolrNodes, condition.Reason, "Wrong reason for the StorageMigrationBlocked condition")
+	}
+	assert.Len(t, recorder.Events, 1, "A Warning event should be emitted when the storage migration is blocked")
+
+	// The event is only emitted when the migration becomes blocked
+	_, err = determineStorageMigrationClusterOpLockIfNecessary(context.Background(), r, solrCloud, status, []*appsv1.StatefulSet{statefulSet}, pvcLabels)
+	assert.NoError(t, err, "Determining the storage migration should not fail")
+	assert.Len(t, recorder.Events, 1, "The Warning event should not be repeated while the storage migration is still blocked")
+
+	solrCloud.Spec.Replicas = pointer.Int32(2)
+	clusterOp, err = determineStorageMigrationClusterOpLockIfNecessary(context.Background(), r, solrCloud, status, []*appsv1.StatefulSet{statefulSet}, pvcLabels)
+	assert.NoError(t, err, "Determining the storage migration should not fail")
+	if assert.NotNil(t, clusterOp, "The storage migration should be started once the SolrCloud has multiple Solr Nodes") {
+		assert.Equal(t, StorageMigrationLock, clusterOp.Operation, "Wrong cluster operation started")
+	}
+	assert.Nil(t, meta.FindStatusCondition(status.Conditions, string(solrv1beta1.SolrCloudStorageMigrationBlocked)), "The StorageMigrationBlocked condition should be removed once the migration can be done")
+}
+
+func TestGenerateClusterOpStatusForScaleDown(t *testing.T) {
+	solrCloud := &solrv1beta1.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solrv1beta1.SolrCloudSpec{
+			Replicas: pointer.Int32(2),
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	podList := make([]corev1.Pod, 5)
+	clusterOp := &SolrClusterOp{Operation: ScaleDownLock, Metadata: "2"}
+
+	opStatus := generateClusterOpStatus(solrCloud, &solrv1beta1.SolrCloudStatus{}, clusterOp, podList)
+	assert.Equal(t, string(ScaleDownLock), opStatus.Operation, "Wrong operation in the cluster operation status")
+	assert.Equal(t, pointer.Int32(0), opStatus.NodesDone, "No Solr Nodes have been removed when the scale down starts")
+	assert.Equal(t, pointer.Int32(3), opStatus.NodesTotal, "The total should be the number of Solr Nodes to remove")
+	assert.Equal(t, "ScalingDown 0/3", opStatus.Summary, "Wrong summary for the cluster operation status")
+
+	// The total is carried over from the previous status, as each pod is removed
+	solrCloud.Status.ClusterOperation = opStatus
+	opStatus = generateClusterOpStatus(solrCloud, &solrv1beta1.SolrCloudStatus{}, clusterOp, podList[:3])
+	assert.Equal(t, pointer.Int32(2), opStatus.NodesDone, "The removed Solr Nodes should be counted as done")
+	assert.Equal(t, pointer.Int32(3), opStatus.NodesTotal, "The total should be kept from the previous status")
+	assert.Equal(t, "ScalingDown 2/3", opStatus.Summary, "Wrong summary for the cluster operation status")
+}
+
+func TestSetClusterOpStatus(t *testing.T) {
+	solrCloud := &solrv1beta1.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", Generation: 3},
+		Spec: solrv1beta1.SolrCloudSpec{
+			Replicas: pointer.Int32(3),
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-solrcloud", Namespace: "default", Annotations: map[string]string{}},
+	}
+	podList := make([]corev1.Pod, 3)
+
+	// A running cluster operation is taken from the StatefulSet annotations
+	assert.NoError(t, setClusterOpLock(statefulSet, SolrClusterOp{Operation: UpdateLock}), "Could not set the cluster operation lock")
+	status := &solrv1beta1.SolrCloudStatus{Replicas: 3, UpToDateNodes: 1}
+	setClusterOpStatus(solrCloud, status, statefulSet, podList)
+	if assert.NotNil(t, status.ClusterOperation, "The running cluster operation should be in the status") {
+		assert.Equal(t, "RollingUpdate 1/3", status.ClusterOperation.Summary, "The progress of a rolling update should use the up-to-date nodes")
+	}
+	if condition := meta.FindStatusCondition(status.Conditions, string(solrv1beta1.SolrCloudClusterOperationInProgress)); assert.NotNil(t, condition, "The ClusterOperationInProgress condition should be set") {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status, "Wrong status for the ClusterOperationInProgress condition")
+		assert.Equal(t, string(UpdateLock), condition.Reason, "The reason should be the running cluster operation")
+		assert.Equal(t, int64(3), condition.ObservedGeneration, "Wrong observed generation for the ClusterOperationInProgress condition")
+	}
+
+	// No cluster operation is running, and the SolrCloud is at its desired size
+	clearClusterOpLock(statefulSet)
+	status = &solrv1beta1.SolrCloudStatus{}
+	setClusterOpStatus(solrCloud, status, statefulSet, podList)
+	assert.Nil(t, status.ClusterOperation, "No cluster operation should be in the status")
+	if condition := meta.FindStatusCondition(status.Conditions, string(solrv1beta1.SolrCloudClusterOperationInProgress)); assert.NotNil(t, condition, "The ClusterOperationInProgress condition should be set") {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status, "Wrong status for the ClusterOperationInProgress condition")
+		assert.Equal(t, solrv1beta1.SolrCloudReasonNoClusterOperation, condition.Reason, "Wrong reason for the ClusterOperationInProgress condition")
+	}
+
+	// A scale down is shown in between the removal of each pod, while there are more pods than desired
+	solrCloud.Spec.Replicas = pointer.Int32(1)
+	solrCloud.Status.ClusterOperation = &solrv1beta1.SolrClusterOperationStatus{
+		Operation:  string(ScaleDownLock),
+		Metadata:   "1",
+		NodesDone:  pointer.Int32(1),
+		NodesTotal: pointer.Int32(3),
+	}
+	status = &solrv1beta1.SolrCloudStatus{}
+	setClusterOpStatus(solrCloud, status, statefulSet, podList)
+	if assert.NotNil(t, status.ClusterOperation, "The scale down should be kept in the status in between its operations") {
+		assert.Equal(t, string(ScaleDownLock), status.ClusterOperation.Operation, "Wrong cluster operation in the status")
+		assert.Equal(t, "1", status.ClusterOperation.Metadata, "The metadata of the scale down should be carried over")
+		assert.Equal(t, "ScalingDown 1/3", status.ClusterOperation.Summary, "The progress of the scale down should be carried over")
+	}
+	if condition := meta.FindStatusCondition(status.Conditions, string(solrv1beta1.SolrCloudClusterOperationInProgress)); assert.NotNil(t, condition, "The ClusterOperationInProgress condition should be set") {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status, "The scale down should still be in progress")
+		assert.Equal(t, string(ScaleDownLock), condition.Reason, "Wrong reason for the ClusterOperationInProgress condition")
+	}
+
+	// Queued operations take precedence over continuing the scale down
+	assert.NoError(t, setClusterOpRetryQueue(statefulSet, []SolrClusterOp{{Operation: UpdateLock}}), "Could not set the cluster operation retry queue")
+	status = &solrv1beta1.SolrCloudStatus{}
+	setClusterOpStatus(solrCloud, status, statefulSet, podList)
+	assert.Nil(t, status.ClusterOperation, "The scale down should not be shown while other operations are queued")
+	if assert.Len(t, status.QueuedClusterOperations, 1, "The queued cluster operation should be in the status") {
+		assert.Equal(t, string(UpdateLock), status.QueuedClusterOperations[0].Operation, "Wrong queued cluster operation in the status")
+	}
+
+	// The scale down is over once the SolrCloud reaches its desired size
+	assert.NoError(t, setClusterOpRetryQueue(statefulSet, nil), "Could not clear the cluster operation retry queue")
+	status = &solrv1beta1.SolrCloudStatus{}
+	setClusterOpStatus(solrCloud, status, statefulSet, podList[:1])
+	assert.Nil(t, status.ClusterOperation, "The scale down should be removed from the status once it is done")
+	if condition := meta.FindStatusCondition(status.Conditions, string(solrv1beta1.SolrCloudClusterOperationInProgress)); assert.NotNil(t, condition, "The ClusterOperationInProgress condition should be set") {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status, "No cluster operation should be in progress")
+	}
+}
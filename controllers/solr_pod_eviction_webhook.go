@@ -0,0 +1,168 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	solrv1beta1 "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util"
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"net/http"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const SolrPodEvictionWebhookPath = "/validate-v1-pod-eviction"
+
+//+kubebuilder:webhook:path=/validate-v1-pod-eviction,mutating=false,failurePolicy=ignore,sideEffects=None,groups="",resources=pods/eviction,verbs=create,versions=v1,name=vpodeviction.solr.apache.org,admissionReviewVersions=v1
+
+// SolrPodEvictionWebhook refuses evictions of SolrCloud pods that would take too many replicas of a shard offline.
+// It is only used for SolrClouds that use the Shard PodDisruptionBudget method.
+type SolrPodEvictionWebhook struct {
+	client.Client
+	Recorder record.EventRecorder
+}
+
+// SetupWebhookWithManager registers the pod eviction webhook with the Manager's webhook server.
+func (w *SolrPodEvictionWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(SolrPodEvictionWebhookPath, &webhook.Admission{Handler: w})
+	return nil
+}
+
+// Handle allows or refuses the eviction of a pod.
+// Evictions are only refused for pods of SolrClouds using the Shard PodDisruptionBudget method, when the eviction is not safe for the shards on the pod.
+func (w *SolrPodEvictionWebhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create || req.SubResource != "eviction" {
+		return admission.Allowed("")
+	}
+	logger := log.FromContext(ctx).WithValues("pod", req.Name, "namespace", req.Namespace)
+
+	pod := &corev1.Pod{}
+	if err := w.Get(ctx, types.NamespacedName{Name: req.Name, Namespace: req.Namespace}, pod); err != nil {
+		// The pod cannot be found if it does not exist, or if it is in a namespace that the Solr Operator is not watching.
+		// Either way, it cannot be a pod that the Solr Operator manages.
+		return admission.Allowed("Pod is not managed by the Solr Operator")
+	}
+	cloudName, isSolrPod := pod.Labels["solr-cloud"]
+	if !isSolrPod || pod.Labels["technology"] != solrv1beta1.SolrTechnologyLabel {
+		return admission.Allowed("Pod is not a SolrCloud pod")
+	}
+
+	solrCloud := &solrv1beta1.SolrCloud{}
+	if err := w.Get(ctx, types.NamespacedName{Name: cloudName, Namespace: req.Namespace}, solrCloud); err != nil {
+		if errors.IsNotFound(err) {
+			return admission.Allowed("SolrCloud does not exist")
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	pdbOptions := solrCloud.Spec.Availability.PodDisruptionBudget
+	if pdbOptions.Enabled == nil || !*pdbOptions.Enabled || pdbOptions.Method != solrv1beta1.ShardPDB {
+		return admission.Allowed("SolrCloud does not use the Shard PodDisruptionBudget method")
+	}
+	if !isPodReady(pod) {
+		return admission.Allowed("Pod is not ready, so its replicas are already unavailable")
+	} else if solrCloud.Status.ReadyReplicas == 0 {
+		return admission.Allowed("SolrCloud has no ready Solr Nodes, so the cluster state cannot be checked")
+	}
+
+	isSafe, reason, err := w.isPodSafeToEvict(ctx, solrCloud, pod.Name, logger)
+	if err != nil {
+		logger.Error(err, "Could not determine whether the pod can be safely evicted")
+		return refuseEviction(fmt.Sprintf("Cannot determine whether evicting pod %s is safe for the shards of SolrCloud %s: %s", pod.Name, solrCloud.Name, err.Error()))
+	}
+	if !isSafe {
+		logger.Info("Refused pod eviction", "reason", reason)
+		w.Recorder.Eventf(solrCloud, corev1.EventTypeWarning, util.EventReasonEvictionRefused, "Refused the eviction of pod %s: %s", pod.Name, reason)
+		return refuseEviction(fmt.Sprintf("Evicting pod %s would violate the Shard PodDisruptionBudget of SolrCloud %s: %s", pod.Name, solrCloud.Name, reason))
+	}
+	logger.Info("Allowed pod eviction", "reason", reason)
+	return admission.Allowed(reason)
+}
+
+// isPodSafeToEvict fetches the cluster state of the SolrCloud, and determines whether the pod can be evicted without taking too many replicas of a shard offline.
+func (w *SolrPodEvictionWebhook) isPodSafeToEvict(ctx context.Context, solrCloud *solrv1beta1.SolrCloud, podName string, logger logr.Logger) (isSafe bool, reason string, err error) {
+	var statefulSets []*appsv1.StatefulSet
+	for _, nodePool := range solrCloud.AllNodePools() {
+		statefulSet := &appsv1.StatefulSet{}
+		if err = w.Get(ctx, types.NamespacedName{Name: solrCloud.NodePoolStatefulSetName(nodePool.Name), Namespace: solrCloud.Namespace}, statefulSet); err == nil {
+			statefulSets = append(statefulSets, statefulSet)
+		} else if !errors.IsNotFound(err) {
+			return false, "", err
+		}
+	}
+
+	// Pods that are already being deleted have not necessarily been removed from the cluster state yet
+	podSelector := solrCloud.SharedLabels()
+	podSelector["technology"] = solrv1beta1.SolrTechnologyLabel
+	podList := &corev1.PodList{}
+	if err = w.List(ctx, podList, &client.ListOptions{Namespace: solrCloud.Namespace, LabelSelector: labels.SelectorFromSet(podSelector)}); err != nil {
+		return false, "", err
+	}
+	var terminatingPods []string
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil && pod.Name != podName {
+			terminatingPods = append(terminatingPods, pod.Name)
+		}
+	}
+
+	if ctx, err = util.AddAuthToContext(ctx, &w.Client, solrCloud); err != nil {
+		return false, "", err
+	}
+	state, _, err := util.GetNodeReplicaState(ctx, solrCloud, statefulSets, true, logger)
+	if err != nil {
+		return false, "", err
+	}
+	isSafe, reason = util.IsPodSafeToEvict(solrCloud, podName, terminatingPods, state)
+	return isSafe, reason, nil
+}
+
+// refuseEviction returns a TooManyRequests response, the same as a PodDisruptionBudget would, so that clients such as "kubectl drain" will retry the eviction later.
+func refuseEviction(message string) admission.Response {
+	return admission.Response{
+		AdmissionResponse: admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Code:    http.StatusTooManyRequests,
+				Reason:  metav1.StatusReasonTooManyRequests,
+				Message: message,
+			},
+		},
+	}
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
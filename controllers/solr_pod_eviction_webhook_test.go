@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	solrv1beta1 "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"io"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"testing"
+)
+
+// solrApiRoundTripper answers the Collections API calls of the Solr Operator with the given responses, keyed by action
+type solrApiRoundTripper map[string]interface{}
+
+func (responses solrApiRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	response, hasResponse := responses[req.URL.Query().Get("action")]
+	if !hasResponse {
+		return nil, fmt.Errorf("connection refused")
+	}
+	body, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body)), Header: http.Header{}, Request: req}, nil
+}
+
+// newEvictionWebhookTest returns a webhook for a SolrCloud with 3 ready pods, along with the request to evict its first pod
+func newEvictionWebhookTest(t *testing.T, pdbMethod solrv1beta1.SolrPodDisruptionBudgetMethod) (webhook *SolrPodEvictionWebhook, recorder *record.FakeRecorder, solrCloud *solrv1beta1.SolrCloud, req admission.Request) {
+	scheme := runtime.NewScheme()
+	if !assert.NoError(t, clientgoscheme.AddToScheme(scheme), "Could not build scheme") || !assert.NoError(t, solrv1beta1.AddToScheme(scheme), "Could not build scheme") {
+		return nil, nil, nil, req
+	}
+	solrCloud = &solrv1beta1.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solrv1beta1.SolrCloudSpec{
+			Replicas: pointer.Int32(3),
+			Availability: solrv1beta1.SolrAvailabilityOptions{
+				PodDisruptionBudget: solrv1beta1.SolrPodDisruptionBudgetOptions{Enabled: pointer.Bool(true), Method: pdbMethod},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	solrCloud.Status.ReadyReplicas = 3
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: solrCloud.StatefulSetName(), Namespace: "default"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32(3)},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      solrCloud.GetSolrPodName(0),
+			Namespace: "default",
+			Labels:    map[string]string{"solr-cloud": "foo", "technology": solrv1beta1.SolrTechnologyLabel},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	recorder = record.NewFakeRecorder(10)
+	webhook = &SolrPodEvictionWebhook{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(solrCloud, statefulSet, pod).Build(),
+		Recorder: recorder,
+	}
+	req = admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation:   admissionv1.Create,
+			SubResource: "eviction",
+			Name:        pod.Name,
+			Namespace:   pod.Namespace,
+		},
+	}
+	return webhook, recorder, solrCloud, req
+}
+
+// clusterStateResponses returns the responses of a SolrCloud with a single shard, that has a replica on each of the 3 pods.
+// The replica on the second pod has the given state.
+func clusterStateResponses(solrCloud *solrv1beta1.SolrCloud, secondReplicaState solr_api.SolrReplicaState) solrApiRoundTripper {
+	replicas := map[string]solr_api.SolrReplicaStatus{}
+	var liveNodes []string
+	for i := 0; i < 3; i++ {
+		nodeName := util.SolrNodeName(solrCloud, solrCloud.GetSolrPodName(i))
+		liveNodes = append(liveNodes, nodeName)
+		state := solr_api.ReplicaActive
+		if i == 1 {
+			state = secondReplicaState
+		}
+		replicas[fmt.Sprintf("core_node%d", i+1)] = solr_api.SolrReplicaStatus{
+			State:    state,
+			Core:     fmt.Sprintf("books_shard1_replica_n%d", i+1),
+			NodeName: nodeName,
+			Leader:   i == 0,
+			Type:     solr_api.NRT,
+		}
+	}
+	return solrApiRoundTripper{
+		"CLUSTERSTATUS": solr_api.SolrClusterStatusResponse{
+			ClusterStatus: solr_api.SolrClusterStatus{
+				Collections: map[string]solr_api.SolrCollectionStatus{
+					"books": {Shards: map[string]solr_api.SolrShardStatus{"shard1": {Replicas: replicas, State: solr_api.ShardActive}}},
+				},
+				LiveNodes: liveNodes,
+			},
+		},
+		"OVERSEERSTATUS": solr_api.SolrOverseerStatusResponse{Leader: liveNodes[2]},
+	}
+}
+
+func contextWithSolrApi(responses solrApiRoundTripper) context.Context {
+	return context.WithValue(context.Background(), solr_api.HTTP_CLIENT_CONTEXT_KEY, &http.Client{Transport: responses})
+}
+
+func TestEvictionWebhookAllowsEvictionsForClusterWidePDB(t *testing.T) {
+	webhook, _, _, req := newEvictionWebhookTest(t, solrv1beta1.ClusterWidePDB)
+	if webhook == nil {
+		return
+	}
+
+	// The cluster state is never fetched, since the Shard PodDisruptionBudget method is not used
+	resp := webhook.Handle(contextWithSolrApi(solrApiRoundTripper{}), req)
+	assert.True(t, resp.Allowed, "Evictions should always be allowed for SolrClouds that do not use the Shard PodDisruptionBudget method")
+}
+
+func TestEvictionWebhookAllowsSafeEvictions(t *testing.T) {
+	webhook, _, solrCloud, req := newEvictionWebhookTest(t, solrv1beta1.ShardPDB)
+	if webhook == nil {
+		return
+	}
+
+	resp := webhook.Handle(contextWithSolrApi(clusterStateResponses(solrCloud, solr_api.ReplicaActive)), req)
+	assert.True(t, resp.Allowed, "The eviction should be allowed when every other replica of the shard is active")
+}
+
+func TestEvictionWebhookRefusesEvictionsOverMaxShardReplicasUnavailable(t *testing.T) {
+	webhook, recorder, solrCloud, req := newEvictionWebhookTest(t, solrv1beta1.ShardPDB)
+	if webhook == nil {
+		return
+	}
+
+	resp := webhook.Handle(contextWithSolrApi(clusterStateResponses(solrCloud, solr_api.ReplicaDown)), req)
+	assert.False(t, resp.Allowed, "The eviction should be refused when it would put the shard over maxShardReplicasUnavailable")
+	if assert.NotNil(t, resp.Result, "The refusal should have a result") {
+		assert.Equal(t, int32(http.StatusTooManyRequests), resp.Result.Code, "The refusal should be retried, like a refusal by a PodDisruptionBudget")
+		assert.Contains(t, resp.Result.Message, "Shard books|shard1 already has 1 replicas not active", "The refusal should explain which shard is not available")
+	}
+	if assert.Len(t, recorder.Events, 1, "An event should be emitted for the refused eviction") {
+		assert.Contains(t, <-recorder.Events, "Warning EvictionRefused Refused the eviction of pod foo-solrcloud-0", "Wrong event for the refused eviction")
+	}
+}
+
+func TestEvictionWebhookRefusesEvictionsWithoutClusterState(t *testing.T) {
+	webhook, _, _, req := newEvictionWebhookTest(t, solrv1beta1.ShardPDB)
+	if webhook == nil {
+		return
+	}
+
+	resp := webhook.Handle(contextWithSolrApi(solrApiRoundTripper{}), req)
+	assert.False(t, resp.Allowed, "The eviction should be refused when the cluster state cannot be fetched")
+	if assert.NotNil(t, resp.Result, "The refusal should have a result") {
+		assert.Equal(t, int32(http.StatusTooManyRequests), resp.Result.Code, "The eviction should be retried once the cluster state can be fetched")
+	}
+}
@@ -44,6 +44,7 @@ const (
 	PodUpdate        PodConditionChangeReason = "PodUpdate"
 	EvictingReplicas PodConditionChangeReason = "EvictingReplicas"
 	ScaleDown        PodConditionChangeReason = "ScaleDown"
+	StorageMigration PodConditionChangeReason = "StorageMigration"
 )
 
 func DeletePodForUpdate(ctx context.Context, r *SolrCloudReconciler, instance *solrv1beta1.SolrCloud, pod *corev1.Pod, podHasReplicas bool, logger logr.Logger) (requeueAfterDuration time.Duration, requestInProgress bool, err error) {
@@ -75,7 +76,7 @@ func DeletePodForUpdate(ctx context.Context, r *SolrCloudReconciler, instance *s
 	deletePod := false
 	if PodConditionEquals(pod, util.SolrReplicasNotEvictedReadinessCondition, EvictingReplicas) {
 		// Only evict pods that contain replicas in the clusterState
-		if evictError, canDeletePod, inProgTmp := util.EvictReplicasForPodIfNecessary(ctx, instance, pod, podHasReplicas, "podUpdate", logger); evictError != nil {
+		if evictError, canDeletePod, inProgTmp := util.EvictReplicasForPodIfNecessary(ctx, instance, pod, podHasReplicas, "podUpdate", r.Recorder, logger); evictError != nil {
 			requestInProgress = true
 			err = evictError
 			logger.Error(err, "Error while evicting replicas on pod", "pod", pod.Name)
@@ -107,9 +108,10 @@ func DeletePodForUpdate(ctx context.Context, r *SolrCloudReconciler, instance *s
 		})
 		if err != nil {
 			logger.Error(err, "Error while killing solr pod for update", "pod", pod.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonDeletePodFailed, "Could not delete pod %s for update: %s", pod.Name, err.Error())
+		} else {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonDeletingPodForUpdate, "Deleted pod %s, so that it is recreated with the latest pod spec", pod.Name)
 		}
-
-		// TODO: Create event for the CRD.
 	}
 
 	return
@@ -137,7 +139,7 @@ func EnsurePodReadinessConditions(ctx context.Context, r *SolrCloudReconciler, p
 			logger.Error(err, "Could not patch readiness condition(s) for pod to stop traffic", "pod", pod.Name)
 			updatedPod = pod
 
-			// TODO: Create event for the CRD.
+			r.Recorder.Eventf(pod, corev1.EventTypeWarning, util.EventReasonPodReadinessFailed, "Could not update the readiness conditions of the pod: %s", err.Error())
 		}
 	} else {
 		updatedPod = pod
@@ -29,11 +29,13 @@ import (
 
 	"github.com/apache/solr-operator/controllers/util"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -42,15 +44,20 @@ import (
 	solrv1beta1 "github.com/apache/solr-operator/api/v1beta1"
 )
 
+// How often the catalog of backup points in the backup repository is refreshed
+const backupCatalogRefreshInterval = time.Minute * 10
+
 // SolrBackupReconciler reconciles a SolrBackup object
 type SolrBackupReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Config *rest.Config
+	Scheme   *runtime.Scheme
+	Config   *rest.Config
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=solr.apache.org,resources=solrclouds,verbs=get;list;watch
 //+kubebuilder:rbac:groups=solr.apache.org,resources=solrclouds/status,verbs=get
 //+kubebuilder:rbac:groups=solr.apache.org,resources=solrbackups,verbs=get;list;watch;create;update;patch;delete
@@ -87,6 +94,18 @@ func (r *SolrBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return reconcile.Result{Requeue: true}, nil
 	}
 
+	// If the reclaimPolicy is "Delete", then use a finalizer to delete the backup data from the repository once the SolrBackup is deleted
+	if !backup.ObjectMeta.DeletionTimestamp.IsZero() {
+		return r.reconcileBackupDeletion(ctx, backup, logger)
+	} else if backup.Spec.ReclaimPolicy == solrv1beta1.BackupReclaimPolicyDelete && !util.ContainsString(backup.ObjectMeta.Finalizers, util.SolrBackupDataFinalizer) {
+		backup.ObjectMeta.Finalizers = append(backup.ObjectMeta.Finalizers, util.SolrBackupDataFinalizer)
+		return reconcile.Result{}, r.Update(ctx, backup)
+	} else if backup.Spec.ReclaimPolicy != solrv1beta1.BackupReclaimPolicyDelete && util.ContainsString(backup.ObjectMeta.Finalizers, util.SolrBackupDataFinalizer) {
+		// There is no longer a need to delete the backup data after the SolrBackup is deleted
+		backup.ObjectMeta.Finalizers = util.RemoveString(backup.ObjectMeta.Finalizers, util.SolrBackupDataFinalizer)
+		return reconcile.Result{}, r.Update(ctx, backup)
+	}
+
 	unmodifiedBackupResource := backup.DeepCopy()
 
 	requeueOrNot := reconcile.Result{}
@@ -100,6 +119,9 @@ func (r *SolrBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if !backup.Spec.Recurrence.IsEnabled() {
 			backup.Status.NextScheduledTime = nil
 			doBackupWork = false
+		} else if len(util.CollectionBackupsToVerify(&backup.Status.IndividualSolrBackupStatus, backup.Spec.Verify != nil)) > 0 {
+			// The current backup must be verified, and its temporary collections deleted, before it is moved to the history
+			doBackupWork = false
 		} else if backup.Status.NextScheduledTime.UTC().Before(time.Now().UTC()) {
 			// We have hit the next scheduled restart time.
 			doBackupWork = true
@@ -129,6 +151,7 @@ func (r *SolrBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if err1 != nil {
 			// TODO Should we be failing the backup for some sub-set of errors here?
 			logger.Error(err1, "Error while taking SolrCloud backup")
+			r.Recorder.Eventf(backup, corev1.EventTypeWarning, util.EventReasonBackupError, "Error while taking backup: %s", err1.Error())
 
 			// Requeue after 10 seconds for errors.
 			updateRequeueAfter(&requeueOrNot, time.Second*10)
@@ -136,6 +159,11 @@ func (r *SolrBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			// Set finish time
 			now := metav1.Now()
 			backup.Status.IndividualSolrBackupStatus.FinishTime = &now
+			if backup.Status.IndividualSolrBackupStatus.Successful != nil && *backup.Status.IndividualSolrBackupStatus.Successful {
+				r.Recorder.Event(backup, corev1.EventTypeNormal, util.EventReasonBackupFinished, "Successfully backed up all collections")
+			} else {
+				r.Recorder.Event(backup, corev1.EventTypeWarning, util.EventReasonBackupFailed, "Backup finished, but not all collections were backed up successfully")
+			}
 		} else if solrCloud != nil {
 			// When working with the collection backups, auto-requeue after 5 seconds
 			// to check on the status of the async solr backup calls
@@ -143,6 +171,30 @@ func (r *SolrBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
+	// Verify the backup of each collection once the backup has finished, by restoring it into a temporary collection
+	if collectionsToVerify := util.CollectionBackupsToVerify(&backup.Status.IndividualSolrBackupStatus, backup.Spec.Verify != nil); len(collectionsToVerify) > 0 {
+		var err1 error
+		if util.BackupVerificationTimedOut(&backup.Status.IndividualSolrBackupStatus, backup.Spec.Verify, time.Now()) {
+			r.failBackupVerification(ctx, backup, collectionsToVerify, logger)
+		} else {
+			err1 = r.reconcileBackupVerification(ctx, backup, collectionsToVerify, logger)
+		}
+		if err1 != nil {
+			logger.Error(err1, "Error while verifying SolrCloud backup")
+			r.Recorder.Eventf(backup, corev1.EventTypeWarning, util.EventReasonBackupError, "Error while verifying backup: %s", err1.Error())
+			updateRequeueAfter(&requeueOrNot, time.Second*10)
+		} else if len(util.CollectionBackupsToVerify(&backup.Status.IndividualSolrBackupStatus, backup.Spec.Verify != nil)) > 0 {
+			// Auto-requeue after 5 seconds to check on the status of the async solr restore calls
+			updateRequeueAfter(&requeueOrNot, time.Second*5)
+		} else if util.UpdateStatusOfBackupVerification(&backup.Status.IndividualSolrBackupStatus) && backup.Status.Verified != nil {
+			if *backup.Status.Verified {
+				r.Recorder.Event(backup, corev1.EventTypeNormal, util.EventReasonBackupVerified, "Successfully verified the backups of all collections")
+			} else {
+				r.Recorder.Event(backup, corev1.EventTypeWarning, util.EventReasonBackupVerifyFailed, "Verification finished, but not all collection backups were verified successfully")
+			}
+		}
+	}
+
 	// Schedule the next backupTime, if it doesn't have a next scheduled time, it has recurrence and the current backup is finished
 	if backup.Status.IndividualSolrBackupStatus.Finished && backup.Spec.Recurrence.IsEnabled() {
 		if nextBackupTime, err1 := util.ScheduleNextBackup(backup.Spec.Recurrence.Schedule, backup.Status.IndividualSolrBackupStatus.StartTime.Time); err1 != nil {
@@ -158,6 +210,15 @@ func (r *SolrBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
+	// Refresh the catalog of backup points in the repository, only while no backup is in progress
+	if backup.Status.IndividualSolrBackupStatus.Finished {
+		refreshIn, err1 := r.reconcileBackupCatalog(ctx, backup, logger)
+		if err1 != nil {
+			logger.Error(err1, "Error while listing the backup points in the backup repository")
+		}
+		updateRequeueAfter(&requeueOrNot, refreshIn)
+	}
+
 	if !reflect.DeepEqual(unmodifiedBackupResource.Status, backup.Status) {
 		logger.Info("Updating status for solr-backup", "newStatus", backup.Status, "oldStatus", unmodifiedBackupResource.Status)
 		err = r.Status().Patch(ctx, backup, client.MergeFrom(unmodifiedBackupResource))
@@ -220,6 +281,7 @@ func (r *SolrBackupReconciler) reconcileSolrCloudBackup(ctx context.Context, bac
 		// Only set the solr version at the start of the backup. This shouldn't change throughout the backup.
 		currentBackupStatus.SolrVersion = solrCloud.Status.Version
 		currentBackupStatus.StartTime = metav1.Now()
+		r.Recorder.Eventf(backup, corev1.EventTypeNormal, util.EventReasonBackupStarted, "Starting backup of SolrCloud %s to the %s repository", solrCloud.Name, backupRepository.Name)
 	}
 
 	collectionsToBackup := backup.Spec.Collections
@@ -235,7 +297,7 @@ func (r *SolrBackupReconciler) reconcileSolrCloudBackup(ctx context.Context, bac
 	// Go through each collection specified and reconcile the backup.
 	for _, collection := range collectionsToBackup {
 		// This will in-place update the CollectionBackupStatus in the backup object
-		if _, err = reconcileSolrCollectionBackup(ctx, backup, currentBackupStatus, solrCloud, backupRepository, collection, logger); err != nil {
+		if _, err = r.reconcileSolrCollectionBackup(ctx, backup, currentBackupStatus, solrCloud, backupRepository, collection, logger); err != nil {
 			break
 		}
 	}
@@ -246,7 +308,7 @@ func (r *SolrBackupReconciler) reconcileSolrCloudBackup(ctx context.Context, bac
 	return solrCloud, actionTaken, err
 }
 
-func reconcileSolrCollectionBackup(ctx context.Context, backup *solrv1beta1.SolrBackup, currentBackupStatus *solrv1beta1.IndividualSolrBackupStatus, solrCloud *solrv1beta1.SolrCloud, backupRepository *solrv1beta1.SolrBackupRepository, collection string, logger logr.Logger) (finished bool, err error) {
+func (r *SolrBackupReconciler) reconcileSolrCollectionBackup(ctx context.Context, backup *solrv1beta1.SolrBackup, currentBackupStatus *solrv1beta1.IndividualSolrBackupStatus, solrCloud *solrv1beta1.SolrCloud, backupRepository *solrv1beta1.SolrBackupRepository, collection string, logger logr.Logger) (finished bool, err error) {
 	now := metav1.Now()
 	collectionBackupStatus := solrv1beta1.CollectionBackupStatus{}
 	collectionBackupStatus.Collection = collection
@@ -267,6 +329,7 @@ func reconcileSolrCollectionBackup(ctx context.Context, backup *solrv1beta1.Solr
 		var started bool
 		started, err = util.StartBackupForCollection(ctx, solrCloud, backupRepository, backup, collection, logger)
 		if err != nil {
+			r.Recorder.Eventf(backup, corev1.EventTypeWarning, util.EventReasonCollectionBackupFailed, "Could not start the backup for collection %s: %s", collection, err.Error())
 			return true, err
 		}
 		collectionBackupStatus.InProgress = started
@@ -287,11 +350,21 @@ func reconcileSolrCollectionBackup(ctx context.Context, backup *solrv1beta1.Solr
 			collectionBackupStatus.InProgress = false
 			if collectionBackupStatus.Successful == nil {
 				collectionBackupStatus.Successful = &successful
+				if !successful {
+					r.Recorder.Eventf(backup, corev1.EventTypeWarning, util.EventReasonCollectionBackupFailed, "Backup for collection %s failed with async status %q", collection, asyncStatus)
+				}
 			}
 			collectionBackupStatus.AsyncBackupStatus = ""
 			if collectionBackupStatus.FinishTime == nil {
 				collectionBackupStatus.FinishTime = &now
 			}
+			// Count the source collection right away, since documents indexed before the backup is verified would otherwise fail the verification
+			if *collectionBackupStatus.Successful && backup.Spec.Verify != nil && collectionBackupStatus.Verification == nil {
+				collectionBackupStatus.Verification = util.NewCollectionBackupVerification(backup, collection)
+				if countErr := util.CountSourceDocumentsForVerification(ctx, solrCloud, backup.Spec.Verify, collection, collectionBackupStatus.Verification); countErr != nil {
+					logger.Error(countErr, "Could not count the documents of the backed up collection, they will be counted when the backup is verified", "solrCloud", solrCloud.Name, "collection", collection)
+				}
+			}
 
 			err = util.DeleteAsyncInfoForBackup(ctx, solrCloud, collection, backup.Name, logger)
 		} else {
@@ -308,6 +381,318 @@ func reconcileSolrCollectionBackup(ctx context.Context, backup *solrv1beta1.Solr
 	return collectionBackupStatus.Finished, err
 }
 
+// reconcileBackupVerification verifies the backups of the given collections, by restoring the latest backup point of each into a temporary collection,
+// comparing its documents with the source collection, and then deleting the temporary collection.
+func (r *SolrBackupReconciler) reconcileBackupVerification(ctx context.Context, backup *solrv1beta1.SolrBackup, collectionsToVerify []int, logger logr.Logger) (err error) {
+	sourceCloud, sourceCtx, err := r.getCloudForVerification(ctx, backup.Namespace, backup.Spec.SolrCloud)
+	if err != nil {
+		return err
+	}
+	backupRepository := util.GetBackupRepositoryByName(sourceCloud.Spec.BackupRepositories, backup.Spec.RepositoryName)
+	if backupRepository == nil {
+		return fmt.Errorf("unable to find the backup repository [%s] of backup [%s] in SolrCloud [%s]", backup.Spec.RepositoryName, backup.Name, sourceCloud.Name)
+	}
+
+	verify := &solrv1beta1.BackupVerification{}
+	if backup.Spec.Verify != nil {
+		verify = backup.Spec.Verify
+	}
+
+	for _, i := range collectionsToVerify {
+		collectionStatus := &backup.Status.CollectionBackupStatuses[i]
+		if collectionStatus.Verification == nil {
+			collectionStatus.Verification = util.NewCollectionBackupVerification(backup, collectionStatus.Collection)
+		}
+		// A verification that has started must be finished on the same SolrCloud, even if the verification SolrCloud has since changed
+		verificationCloud, verificationCtx := sourceCloud, sourceCtx
+		if collectionStatus.Verification.SolrCloud != sourceCloud.Name {
+			if verificationCloud, verificationCtx, err = r.getCloudForVerification(ctx, backup.Namespace, collectionStatus.Verification.SolrCloud); err != nil {
+				return err
+			}
+		}
+		if err = r.reconcileCollectionBackupVerification(ctx, backup, sourceCtx, sourceCloud, verificationCtx, verificationCloud, backupRepository.Name, verify, collectionStatus, logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileCollectionBackupVerification takes the next step of the verification of a collection's backup, updating its verification status in-place
+func (r *SolrBackupReconciler) reconcileCollectionBackupVerification(ctx context.Context, backup *solrv1beta1.SolrBackup, sourceCtx context.Context, sourceCloud *solrv1beta1.SolrCloud, verificationCtx context.Context, verificationCloud *solrv1beta1.SolrCloud, repositoryName string, verify *solrv1beta1.BackupVerification, collectionStatus *solrv1beta1.CollectionBackupStatus, logger logr.Logger) (err error) {
+	now := metav1.Now()
+	collection := collectionStatus.Collection
+	verification := collectionStatus.Verification
+
+	// Start the restore into the temporary collection
+	if !verification.InProgress && verification.Successful == nil {
+		backupRepository := util.GetBackupRepositoryByName(verificationCloud.Spec.BackupRepositories, repositoryName)
+		if backupRepository == nil {
+			return fmt.Errorf("unable to find the backup repository [%s] in SolrCloud [%s], which is used to verify backup [%s]", repositoryName, verificationCloud.Name, backup.Name)
+		}
+		if !verificationCloud.Status.BackupRepositoriesAvailable[backupRepository.Name] {
+			return errors.NewServiceUnavailable(fmt.Sprintf("Cloud %s is not ready for restores from the %s repository", verificationCloud.Name, backupRepository.Name))
+		}
+		if err = util.StartVerificationForCollection(verificationCtx, verificationCloud, backupRepository, backup, collection, logger); err != nil {
+			return err
+		}
+		verification.InProgress = true
+		verification.StartTime = &now
+		return nil
+	}
+
+	// Check on the restore, and compare the restored collection with the source collection once it has been restored
+	if verification.InProgress {
+		finished, successful, asyncStatus, checkErr := util.CheckVerificationForCollection(verificationCtx, verificationCloud, collection, backup.Name, logger)
+		if checkErr != nil {
+			return checkErr
+		}
+		if !finished {
+			verification.AsyncRestoreStatus = asyncStatus
+			return nil
+		}
+		if successful {
+			if err = util.CompareVerificationCollection(sourceCtx, sourceCloud, verificationCtx, verificationCloud, verify, collection, verification); err != nil {
+				return err
+			}
+		} else {
+			verification.Successful = &successful
+			verification.Message = fmt.Sprintf("restoring the backup failed with async status %q", asyncStatus)
+		}
+		if err = util.DeleteAsyncInfoForVerification(verificationCtx, verificationCloud, collection, backup.Name, logger); err != nil {
+			return err
+		}
+		verification.InProgress = false
+		verification.AsyncRestoreStatus = ""
+	}
+
+	// Delete the temporary collection, which a failed restore may also have left behind
+	if err = util.DeleteVerificationCollection(verificationCtx, verificationCloud, collection, backup.Name, logger); err != nil {
+		return err
+	}
+	verification.Finished = true
+	verification.FinishTime = &now
+	if !*verification.Successful {
+		r.Recorder.Eventf(backup, corev1.EventTypeWarning, util.EventReasonBackupVerifyFailed, "Verification of the backup of collection %s failed: %s", collection, verification.Message)
+	}
+	return nil
+}
+
+// failBackupVerification fails the verifications of the given collection backups, because they did not finish in time,
+// so that they do not stop the next recurring backups from being taken.
+// The temporary collections are deleted on a best-effort basis, since the verification SolrCloud may not be available.
+// A temporary collection that is left behind is deleted when the backup of that collection is next verified.
+func (r *SolrBackupReconciler) failBackupVerification(ctx context.Context, backup *solrv1beta1.SolrBackup, collectionsToVerify []int, logger logr.Logger) {
+	now := metav1.Now()
+	message := fmt.Sprintf("the verification did not finish within %d minutes of the backup finishing", int(backup.Spec.Verify.Timeout().Minutes()))
+
+	for _, i := range collectionsToVerify {
+		collectionStatus := &backup.Status.CollectionBackupStatuses[i]
+		if collectionStatus.Verification == nil {
+			collectionStatus.Verification = util.NewCollectionBackupVerification(backup, collectionStatus.Collection)
+		}
+		collection := collectionStatus.Collection
+		verification := collectionStatus.Verification
+
+		if verificationCloud, verificationCtx, err := r.getCloudForVerification(ctx, backup.Namespace, verification.SolrCloud); err != nil {
+			logger.Error(err, "Could not clean up the backup verification that timed out", "solrCloud", verification.SolrCloud, "collection", collection)
+		} else {
+			if verification.InProgress {
+				// Errors are logged, and must not stop the verification from being failed
+				_ = util.DeleteAsyncInfoForVerification(verificationCtx, verificationCloud, collection, backup.Name, logger)
+			}
+			if err = util.DeleteVerificationCollection(verificationCtx, verificationCloud, collection, backup.Name, logger); err != nil {
+				logger.Error(err, "Could not delete the temporary collection of the backup verification that timed out", "solrCloud", verification.SolrCloud, "collection", collection)
+			}
+		}
+
+		if verification.Successful == nil {
+			successful := false
+			verification.Successful = &successful
+			verification.Message = message
+		}
+		verification.InProgress = false
+		verification.AsyncRestoreStatus = ""
+		verification.Finished = true
+		verification.FinishTime = &now
+		if !*verification.Successful {
+			r.Recorder.Eventf(backup, corev1.EventTypeWarning, util.EventReasonBackupVerifyFailed, "Verification of the backup of collection %s failed: %s", collection, verification.Message)
+		}
+	}
+}
+
+// getCloudForVerification returns the SolrCloud, along with a context that is authenticated to call its Solr APIs
+func (r *SolrBackupReconciler) getCloudForVerification(ctx context.Context, namespace string, name string) (solrCloud *solrv1beta1.SolrCloud, cloudCtx context.Context, err error) {
+	solrCloud = &solrv1beta1.SolrCloud{}
+	if err = r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, solrCloud); err != nil {
+		return nil, ctx, err
+	}
+	cloudCtx = ctx
+	if solrCloud.Spec.SolrSecurity != nil {
+		if cloudCtx, err = util.AddAuthToContext(ctx, &r.Client, solrCloud); err != nil {
+			return nil, ctx, err
+		}
+	}
+	return solrCloud, cloudCtx, nil
+}
+
+// reconcileBackupCatalog lists the backup points that exist in the backup repository for each collection that has been backed up.
+// The catalog is refreshed whenever a backup finishes, and otherwise every backupCatalogRefreshInterval.
+// If the SolrCloud cannot be listed from, the refresh is retried after a minute.
+func (r *SolrBackupReconciler) reconcileBackupCatalog(ctx context.Context, backup *solrv1beta1.SolrBackup, logger logr.Logger) (refreshIn time.Duration, err error) {
+	lastRefresh := backup.Status.CatalogRefreshTime
+	finishTime := backup.Status.IndividualSolrBackupStatus.FinishTime
+	if lastRefresh != nil && (finishTime == nil || finishTime.Before(lastRefresh)) {
+		if sinceRefresh := time.Since(lastRefresh.Time); sinceRefresh < backupCatalogRefreshInterval {
+			return backupCatalogRefreshInterval - sinceRefresh, nil
+		}
+	}
+
+	collections := util.BackupCollectionsInStatus(&backup.Status)
+	if len(collections) == 0 {
+		return backupCatalogRefreshInterval, nil
+	}
+
+	solrCloud := &solrv1beta1.SolrCloud{}
+	if err = r.Get(ctx, types.NamespacedName{Namespace: backup.Namespace, Name: backup.Spec.SolrCloud}, solrCloud); err != nil {
+		return time.Minute, err
+	}
+	backupRepository := util.GetBackupRepositoryByName(solrCloud.Spec.BackupRepositories, backup.Spec.RepositoryName)
+	if backupRepository == nil {
+		return time.Minute, fmt.Errorf("unable to find the backup repository [%s] of backup [%s] in SolrCloud [%s]", backup.Spec.RepositoryName, backup.Name, solrCloud.Name)
+	}
+	if !solrCloud.Status.BackupRepositoriesAvailable[backupRepository.Name] {
+		return time.Minute, errors.NewServiceUnavailable(fmt.Sprintf("Cloud is not ready for backups in the %s repository", backupRepository.Name))
+	}
+	if solrCloud.Spec.SolrSecurity != nil {
+		if ctx, err = util.AddAuthToContext(ctx, &r.Client, solrCloud); err != nil {
+			return time.Minute, err
+		}
+	}
+
+	// If a collection cannot be listed, e.g. because its backup was deleted from the repository,
+	// then its previous entry in the catalog is kept until the next refresh
+	previousCatalog := make(map[string]solrv1beta1.CollectionBackupCatalog, len(backup.Status.Catalog))
+	for _, collectionCatalog := range backup.Status.Catalog {
+		previousCatalog[collectionCatalog.Collection] = collectionCatalog
+	}
+	catalog := make([]solrv1beta1.CollectionBackupCatalog, 0, len(collections))
+	for _, collection := range collections {
+		collectionCatalog, listErr := util.ListBackupPointsForCollection(ctx, solrCloud, backupRepository, backup, collection, logger)
+		if listErr != nil {
+			err = listErr
+			if previous, hasPrevious := previousCatalog[collection]; hasPrevious {
+				catalog = append(catalog, previous)
+			}
+			continue
+		}
+		if backup.Spec.Recurrence.HasRetention() {
+			if listErr = r.enforceBackupRetention(ctx, backup, solrCloud, backupRepository, &collectionCatalog, logger); listErr != nil {
+				err = listErr
+			}
+		}
+		catalog = append(catalog, collectionCatalog)
+	}
+	now := metav1.Now()
+	backup.Status.Catalog = catalog
+	backup.Status.CatalogRefreshTime = &now
+	return backupCatalogRefreshInterval, err
+}
+
+// enforceBackupRetention deletes the backup points of the collection that are not kept by the retention, and removes them from its catalog entry
+func (r *SolrBackupReconciler) enforceBackupRetention(ctx context.Context, backup *solrv1beta1.SolrBackup, solrCloud *solrv1beta1.SolrCloud, backupRepository *solrv1beta1.SolrBackupRepository, collectionCatalog *solrv1beta1.CollectionBackupCatalog, logger logr.Logger) (err error) {
+	backupIds := util.BackupPointsToDelete(backup.Spec.Recurrence.Retention, collectionCatalog.BackupPoints, time.Now())
+	if len(backupIds) == 0 {
+		return nil
+	}
+	if err = util.DeleteBackupPointsForCollection(ctx, solrCloud, backupRepository, backup, collectionCatalog.Collection, backupIds, logger); err != nil {
+		r.Recorder.Eventf(backup, corev1.EventTypeWarning, util.EventReasonBackupDeletionFailed, "Could not delete the backup points %v of collection %s: %s", backupIds, collectionCatalog.Collection, err.Error())
+		return err
+	}
+	r.Recorder.Eventf(backup, corev1.EventTypeNormal, util.EventReasonBackupPointsDeleted, "Deleted the backup points %v of collection %s, which are no longer kept by the retention", backupIds, collectionCatalog.Collection)
+
+	deleted := make(map[int]bool, len(backupIds))
+	for _, backupId := range backupIds {
+		deleted[backupId] = true
+	}
+	var remaining []solrv1beta1.BackupPoint
+	for _, backupPoint := range collectionCatalog.BackupPoints {
+		if !deleted[backupPoint.BackupId] {
+			remaining = append(remaining, backupPoint)
+		}
+	}
+	collectionCatalog.BackupPoints = remaining
+	return nil
+}
+
+// reconcileBackupDeletion deletes the backup data of every collection from the repository, if the reclaimPolicy is "Delete",
+// and then removes the finalizer so that the SolrBackup can be deleted.
+// A backup that is in progress is finished first, so that its data can also be deleted.
+func (r *SolrBackupReconciler) reconcileBackupDeletion(ctx context.Context, backup *solrv1beta1.SolrBackup, logger logr.Logger) (requeueOrNot reconcile.Result, err error) {
+	if !util.ContainsString(backup.ObjectMeta.Finalizers, util.SolrBackupDataFinalizer) {
+		return requeueOrNot, nil
+	}
+	if backup.Spec.ReclaimPolicy == solrv1beta1.BackupReclaimPolicyDelete {
+		solrCloud := &solrv1beta1.SolrCloud{}
+		if err = r.Get(ctx, types.NamespacedName{Namespace: backup.Namespace, Name: backup.Spec.SolrCloud}, solrCloud); err != nil && !errors.IsNotFound(err) {
+			return requeueOrNot, err
+		} else if err != nil {
+			// Without the SolrCloud, the Solr Operator has no way of deleting the backup data
+			r.Recorder.Eventf(backup, corev1.EventTypeWarning, util.EventReasonBackupDeletionFailed, "Could not delete the backup data, since SolrCloud %s no longer exists", backup.Spec.SolrCloud)
+		} else if backupStatus := &backup.Status.IndividualSolrBackupStatus; !backupStatus.StartTime.IsZero() && !backupStatus.Finished {
+			unmodifiedBackupResource := backup.DeepCopy()
+			if _, _, err = r.reconcileSolrCloudBackup(ctx, backup, backupStatus, logger); err == nil {
+				err = r.Status().Patch(ctx, backup, client.MergeFrom(unmodifiedBackupResource))
+			}
+			logger.Info("Waiting for the backup to finish, before deleting the backup data")
+			return reconcile.Result{RequeueAfter: time.Second * 5}, err
+		} else if err = r.deleteBackupData(ctx, backup, solrCloud, logger); err != nil {
+			r.Recorder.Eventf(backup, corev1.EventTypeWarning, util.EventReasonBackupDeletionFailed, "Could not delete the backup data: %s", err.Error())
+			return reconcile.Result{RequeueAfter: time.Second * 10}, nil
+		}
+	}
+
+	backup.ObjectMeta.Finalizers = util.RemoveString(backup.ObjectMeta.Finalizers, util.SolrBackupDataFinalizer)
+	return requeueOrNot, r.Update(ctx, backup)
+}
+
+// deleteBackupData deletes every backup point of each collection that has been backed up
+func (r *SolrBackupReconciler) deleteBackupData(ctx context.Context, backup *solrv1beta1.SolrBackup, solrCloud *solrv1beta1.SolrCloud, logger logr.Logger) (err error) {
+	backupRepository := util.GetBackupRepositoryByName(solrCloud.Spec.BackupRepositories, backup.Spec.RepositoryName)
+	if backupRepository == nil {
+		return fmt.Errorf("unable to find the backup repository [%s] in SolrCloud [%s]", backup.Spec.RepositoryName, solrCloud.Name)
+	}
+	if solrCloud.Spec.SolrSecurity != nil {
+		if ctx, err = util.AddAuthToContext(ctx, &r.Client, solrCloud); err != nil {
+			return err
+		}
+	}
+
+	// Only collections that are in the catalog are known to have backup data,
+	// a collection that cannot be listed otherwise has nothing to delete
+	listedCollections := make(map[string]bool, len(backup.Status.Catalog))
+	for _, collectionCatalog := range backup.Status.Catalog {
+		listedCollections[collectionCatalog.Collection] = len(collectionCatalog.BackupPoints) > 0
+	}
+	for _, collection := range util.BackupCollectionsInStatus(&backup.Status) {
+		collectionCatalog, listErr := util.ListBackupPointsForCollection(ctx, solrCloud, backupRepository, backup, collection, logger)
+		if listErr != nil {
+			if listedCollections[collection] {
+				return listErr
+			}
+			continue
+		}
+		backupIds := make([]int, 0, len(collectionCatalog.BackupPoints))
+		for _, backupPoint := range collectionCatalog.BackupPoints {
+			backupIds = append(backupIds, backupPoint.BackupId)
+		}
+		if err = util.DeleteBackupPointsForCollection(ctx, solrCloud, backupRepository, backup, collection, backupIds, logger); err != nil {
+			return err
+		}
+	}
+	r.Recorder.Eventf(backup, corev1.EventTypeNormal, util.EventReasonBackupDataDeleted, "Deleted the backup data of SolrBackup %s from the %s repository", backup.Name, backupRepository.Name)
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SolrBackupReconciler) SetupWithManager(mgr ctrl.Manager) (err error) {
 	r.Config = mgr.GetConfig()
@@ -0,0 +1,138 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	solrv1beta1 "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+// newBackupWithUnfinishedVerification returns a recurring SolrBackup, whose latest backup finished two hours ago,
+// but whose verification cannot finish because the verification SolrCloud does not exist.
+func newBackupWithUnfinishedVerification(timeoutMinutes int32) *solrv1beta1.SolrBackup {
+	startTime := metav1.NewTime(time.Now().Add(-3 * time.Hour).Truncate(time.Second))
+	finishTime := metav1.NewTime(time.Now().Add(-2 * time.Hour).Truncate(time.Second))
+	nextScheduledTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	return &solrv1beta1.SolrBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "books-backup", Namespace: "default"},
+		Spec: solrv1beta1.SolrBackupSpec{
+			SolrCloud:      "foo",
+			RepositoryName: "local-repo",
+			Collections:    []string{"books"},
+			Recurrence:     &solrv1beta1.BackupRecurrence{Schedule: "@every 1h", MaxSaved: 5},
+			Verify:         &solrv1beta1.BackupVerification{SolrCloud: "missing", TimeoutMinutes: timeoutMinutes},
+			ReclaimPolicy:  solrv1beta1.BackupReclaimPolicyRetain,
+		},
+		Status: solrv1beta1.SolrBackupStatus{
+			IndividualSolrBackupStatus: solrv1beta1.IndividualSolrBackupStatus{
+				StartTime:  startTime,
+				FinishTime: &finishTime,
+				Finished:   true,
+				Successful: pointer.Bool(true),
+				CollectionBackupStatuses: []solrv1beta1.CollectionBackupStatus{
+					{
+						Collection: "books",
+						Finished:   true,
+						Successful: pointer.Bool(true),
+						Verification: &solrv1beta1.CollectionBackupVerification{
+							SolrCloud:         "missing",
+							ScratchCollection: "books-backup-verify-books",
+						},
+					},
+				},
+			},
+			NextScheduledTime: &nextScheduledTime,
+		},
+	}
+}
+
+func reconcileBackup(t *testing.T, backup *solrv1beta1.SolrBackup) (updatedBackup *solrv1beta1.SolrBackup, recorder *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	if !assert.NoError(t, clientgoscheme.AddToScheme(scheme), "Could not build scheme") || !assert.NoError(t, solrv1beta1.AddToScheme(scheme), "Could not build scheme") {
+		return nil, nil
+	}
+	recorder = record.NewFakeRecorder(20)
+	r := &SolrBackupReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(backup).WithStatusSubresource(backup).Build(),
+		Scheme:   scheme,
+		Recorder: recorder,
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: backup.Namespace, Name: backup.Name}}
+	updatedBackup = &solrv1beta1.SolrBackup{}
+	for i := 0; i < 2; i++ {
+		_, err := r.Reconcile(context.Background(), request)
+		assert.NoError(t, err, "Reconcile of the SolrBackup failed")
+	}
+	assert.NoError(t, r.Get(context.Background(), request.NamespacedName, updatedBackup), "Could not fetch the reconciled SolrBackup")
+	return updatedBackup, recorder
+}
+
+func TestBackupVerificationTimeoutUnblocksRecurringBackups(t *testing.T) {
+	backup, recorder := reconcileBackup(t, newBackupWithUnfinishedVerification(60))
+	if backup == nil {
+		return
+	}
+
+	if assert.Len(t, backup.Status.History, 1, "The backup that could not be verified should have been moved to the history, so that the next backup can start") {
+		previous := backup.Status.History[0]
+		if assert.NotNil(t, previous.Verified, "The backup should have been marked as verified or not") {
+			assert.False(t, *previous.Verified, "The backup should not be verified, since its verification timed out")
+		}
+		verification := previous.CollectionBackupStatuses[0].Verification
+		assert.True(t, verification.Finished, "The verification of the collection backup should have been finished")
+		if assert.NotNil(t, verification.Successful, "The verification of the collection backup should have a result") {
+			assert.False(t, *verification.Successful, "The verification of the collection backup should have failed")
+		}
+		assert.Equal(t, "the verification did not finish within 60 minutes of the backup finishing", verification.Message, "Wrong reason for the failed verification")
+	}
+	assert.False(t, backup.Status.IndividualSolrBackupStatus.Finished, "The next backup should have been started")
+	assert.Contains(t, drainEvents(recorder), "Warning BackupVerificationFailed Verification of the backup of collection books failed: the verification did not finish within 60 minutes of the backup finishing", "An event should be emitted for the failed verification")
+}
+
+func TestBackupVerificationBlocksRecurringBackupsUntilTimeout(t *testing.T) {
+	backup, _ := reconcileBackup(t, newBackupWithUnfinishedVerification(240))
+	if backup == nil {
+		return
+	}
+
+	assert.Empty(t, backup.Status.History, "The next backup should not start before the current backup has been verified")
+	assert.Nil(t, backup.Status.Verified, "The backup should not be marked as verified or not before its verification finishes")
+	assert.False(t, backup.Status.CollectionBackupStatuses[0].Verification.Finished, "The verification should not be finished before it times out")
+}
+
+func drainEvents(recorder *record.FakeRecorder) (events []string) {
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
@@ -33,13 +33,19 @@ import (
 	"github.com/go-logr/logr"
 	zkApi "github.com/pravega/zookeeper-operator/api/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -48,12 +54,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 // SolrCloudReconciler reconciles a SolrCloud object
 type SolrCloudReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 var useZkCRD bool
@@ -62,7 +71,22 @@ func UseZkCRD(useCRD bool) {
 	useZkCRD = useCRD
 }
 
+var operatorNamespace string
+
+// SetOperatorNamespace sets the namespace that the Solr Operator is running in, which is used to allow the Solr Operator through the NetworkPolicies of SolrClouds.
+func SetOperatorNamespace(namespace string) {
+	operatorNamespace = namespace
+}
+
+var useGatewayAPI bool
+
+// UseGatewayAPI determines whether the Solr Operator manages Gateway API routes, which requires the Gateway API CRDs to be installed.
+func UseGatewayAPI(useGateway bool) {
+	useGatewayAPI = useGateway
+}
+
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups="",resources=pods/status,verbs=get;patch
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=services/status,verbs=get
@@ -70,10 +94,15 @@ func UseZkCRD(useCRD bool) {
 //+kubebuilder:rbac:groups=apps,resources=statefulsets/status,verbs=get
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=get
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrprometheusexporters,verbs=get;list;watch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;tlsroutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=configmaps/status,verbs=get
-//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;update;patch;delete
+//+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;delete
 //+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=zookeeper.pravega.io,resources=zookeeperclusters,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=zookeeper.pravega.io,resources=zookeeperclusters/status,verbs=get
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
@@ -95,6 +124,7 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		if errors.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			util.ForgetOperatorHttpClient(req.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the req.
@@ -114,9 +144,21 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	requeueOrNot := reconcile.Result{}
 
 	newStatus := solrv1beta1.SolrCloudStatus{}
+	// Keep the existing conditions, so that their lastTransitionTimes are not changed unless their statuses change
+	newStatus.Conditions = append([]metav1.Condition(nil), instance.Status.Conditions...)
+
+	// The rest of the status is only updated once the reconcile has fully completed,
+	// but the conditions should reflect why a reconcile was not able to complete.
+	defer func() {
+		r.updateStatusConditions(ctx, instance, newStatus.Conditions, logger)
+	}()
 
 	blockReconciliationOfStatefulSet := false
 	if err = r.reconcileZk(ctx, logger, instance, &newStatus); err != nil {
+		// The condition message is kept stable, so that it does not change with every error. The error itself is in the logs and events.
+		logger.Error(err, "Could not determine the Zookeeper connection information")
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonZookeeperReconcileFailed, "Could not determine the Zookeeper connection information: %s", err.Error())
+		util.SetSolrCloudCondition(&newStatus, instance.Generation, solrv1beta1.SolrCloudZookeeperReady, metav1.ConditionFalse, solrv1beta1.SolrCloudReasonZookeeperNotReady, "The Zookeeper connection information could not be determined, see the events of the SolrCloud for details")
 		return requeueOrNot, err
 	}
 
@@ -166,7 +208,7 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			if instance.Spec.SolrAddressability.External.UseExternalAddress {
 				if ip == "" {
 					// If we are using this IP in the hostAliases of the statefulSet, it needs to be set for every service before trying to update the statefulSet
-					// TODO: Make an event here
+					r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonWaitingForNodeServices, "Waiting for the Service of Solr Node %s to be assigned an IP, before updating the StatefulSet", nodeName)
 					blockReconciliationOfStatefulSet = true
 				} else {
 					hostNameIpMap[instance.AdvertisedNodeHost(nodeName)] = ip
@@ -224,17 +266,19 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 			// if there's a user-provided config, it must have one of the expected keys
 			if !hasLogXml && !hasSolrXml {
-				// TODO: Create event for the CRD.
-				return requeueOrNot, fmt.Errorf("user provided ConfigMap %s must have one of 'solr.xml' and/or 'log4j2.xml'",
+				err = fmt.Errorf("user provided ConfigMap %s must have one of 'solr.xml' and/or 'log4j2.xml'",
 					providedConfigMapName)
+				r.Recorder.Event(instance, corev1.EventTypeWarning, util.EventReasonInvalidConfiguration, err.Error())
+				return requeueOrNot, err
 			}
 
 			if hasSolrXml {
 				// make sure the user-provided solr.xml is valid
 				if !(strings.Contains(solrXml, "${solr.port.advertise:") || strings.Contains(solrXml, "${hostPort:")) {
-					return requeueOrNot,
-						fmt.Errorf("custom solr.xml in ConfigMap %s must contain a placeholder for either 'solr.port.advertise', or its deprecated alternative 'hostPort', e.g. <int name=\"hostPort\">${solr.port.advertise:80}</int>",
-							providedConfigMapName)
+					err = fmt.Errorf("custom solr.xml in ConfigMap %s must contain a placeholder for either 'solr.port.advertise', or its deprecated alternative 'hostPort', e.g. <int name=\"hostPort\">${solr.port.advertise:80}</int>",
+						providedConfigMapName)
+					r.Recorder.Event(instance, corev1.EventTypeWarning, util.EventReasonInvalidConfiguration, err.Error())
+					return requeueOrNot, err
 				}
 				// stored in the pod spec annotations on the statefulset so that we get a restart when solr.xml changes
 				reconcileConfigInfo[util.SolrXmlMd5Annotation] = fmt.Sprintf("%x", md5.Sum([]byte(solrXml)))
@@ -250,7 +294,9 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			}
 
 		} else {
-			return requeueOrNot, fmt.Errorf("provided ConfigMap %s has no data", providedConfigMapName)
+			err = fmt.Errorf("provided ConfigMap %s has no data", providedConfigMapName)
+			r.Recorder.Event(instance, corev1.EventTypeWarning, util.EventReasonInvalidConfiguration, err.Error())
+			return requeueOrNot, err
 		}
 	}
 
@@ -298,8 +344,14 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			}
 		}
 		if err != nil {
+			logger.Error(err, "Could not bootstrap the security config")
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonSecurityBootstrapFailed, "Could not bootstrap the security config: %s", err.Error())
+			util.SetSolrCloudCondition(&newStatus, instance.Generation, solrv1beta1.SolrCloudSecurityBootstrapped, metav1.ConditionFalse, solrv1beta1.SolrCloudReasonSecurityBootstrapFailed, "The security.json or the credentials used by the Solr Operator could not be bootstrapped, see the events of the SolrCloud for details")
 			return requeueOrNot, err
 		}
+		util.SetSolrCloudCondition(&newStatus, instance.Generation, solrv1beta1.SolrCloudSecurityBootstrapped, metav1.ConditionTrue, solrv1beta1.SolrCloudReasonSecurityBootstrapped, "The security.json and the credentials used by the Solr Operator are bootstrapped")
+	} else {
+		util.RemoveSolrCloudCondition(&newStatus, solrv1beta1.SolrCloudSecurityBootstrapped)
 	}
 
 	// Only create stateful set if zkConnectionString can be found (must contain a host before the chroot)
@@ -307,6 +359,9 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if len(zkConnectionString) < 2 || strings.HasPrefix(zkConnectionString, "/") {
 		blockReconciliationOfStatefulSet = true
 		logger.Info("Will not create/update the StatefulSet because the zookeeperConnectionString has no host", "zookeeperConnectionString", zkConnectionString)
+		util.SetSolrCloudCondition(&newStatus, instance.Generation, solrv1beta1.SolrCloudZookeeperReady, metav1.ConditionFalse, solrv1beta1.SolrCloudReasonZookeeperNotReady, "The Zookeeper connection string has no host")
+	} else {
+		util.SetSolrCloudCondition(&newStatus, instance.Generation, solrv1beta1.SolrCloudZookeeperReady, metav1.ConditionTrue, solrv1beta1.SolrCloudReasonZookeeperConnectionFound, "Zookeeper connection information is available")
 	}
 
 	// Holds TLS config info for a server cert and optionally a client cert as well
@@ -325,74 +380,44 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	// The StatefulSet of the default node pool holds the cluster operation information for the whole SolrCloud,
+	// while statefulSets contains the StatefulSets of all node pools, including the default node pool.
 	var statefulSet *appsv1.StatefulSet
-	if !blockReconciliationOfStatefulSet {
-		// Generate StatefulSet that should exist
-		expectedStatefulSet := util.GenerateStatefulSet(instance, &newStatus, hostNameIpMap, reconcileConfigInfo, tls, security)
-
-		// Check if the StatefulSet already exists
-		statefulSetLogger := logger.WithValues("statefulSet", expectedStatefulSet.Name)
-		foundStatefulSet := &appsv1.StatefulSet{}
-		err = r.Get(ctx, types.NamespacedName{Name: expectedStatefulSet.Name, Namespace: expectedStatefulSet.Namespace}, foundStatefulSet)
-
-		// TODO: Move this logic down to the cluster ops and save the existing annotation in util.MaintainPreservedStatefulSetFields()
-		// Set the annotation for a scheduled restart, if necessary.
-		if nextRestartAnnotation, reconcileWaitDuration, schedulingErr := util.ScheduleNextRestart(instance.Spec.UpdateStrategy.RestartSchedule, foundStatefulSet.Spec.Template.Annotations); schedulingErr != nil {
-			logger.Error(schedulingErr, "Cannot parse restartSchedule cron", "cron", instance.Spec.UpdateStrategy.RestartSchedule)
+	var statefulSets []*appsv1.StatefulSet
+	for _, nodePool := range instance.AllNodePools() {
+		var poolStatefulSet *appsv1.StatefulSet
+		if !blockReconciliationOfStatefulSet {
+			poolStatefulSet, err = r.reconcileNodePoolStatefulSet(ctx, instance, nodePool, &newStatus, hostNameIpMap, reconcileConfigInfo, tls, security, &requeueOrNot, logger)
 		} else {
-			if nextRestartAnnotation != "" {
-				// Set the new restart time annotation
-				expectedStatefulSet.Spec.Template.Annotations[util.SolrScheduledRestartAnnotation] = nextRestartAnnotation
-				// TODO: Create event for the CRD.
-			} else if existingRestartAnnotation, exists := foundStatefulSet.Spec.Template.Annotations[util.SolrScheduledRestartAnnotation]; exists {
-				// Keep the existing nextRestart annotation if it exists and we aren't setting a new one.
-				expectedStatefulSet.Spec.Template.Annotations[util.SolrScheduledRestartAnnotation] = existingRestartAnnotation
-			}
-			if reconcileWaitDuration != nil {
-				// Set the requeueAfter if it has not been set, or is greater than the time we need to wait to restart again
-				updateRequeueAfter(&requeueOrNot, *reconcileWaitDuration)
+			// If we are blocking the reconciliation of the statefulSet, we still want to find information about it.
+			poolStatefulSet = &appsv1.StatefulSet{}
+			if e := r.Get(ctx, types.NamespacedName{Name: instance.NodePoolStatefulSetName(nodePool.Name), Namespace: instance.Namespace}, poolStatefulSet); e != nil {
+				poolStatefulSet = nil
+				if !errors.IsNotFound(e) {
+					err = e
+				}
 			}
 		}
-
-		// Update or Create the StatefulSet
-		if err != nil && errors.IsNotFound(err) {
-			statefulSetLogger.Info("Creating StatefulSet")
-			if err = controllerutil.SetControllerReference(instance, expectedStatefulSet, r.Scheme); err == nil {
-				err = r.Create(ctx, expectedStatefulSet)
-			}
-			// Wait for the next reconcile loop
-			statefulSet = nil
-		} else if err == nil {
-			util.MaintainPreservedStatefulSetFields(expectedStatefulSet, foundStatefulSet)
-
-			// Check to see if the StatefulSet needs an update
-			var needsUpdate bool
-			needsUpdate, err = util.OvertakeControllerRef(instance, foundStatefulSet, r.Scheme)
-			needsUpdate = util.CopyStatefulSetFields(expectedStatefulSet, foundStatefulSet, statefulSetLogger) || needsUpdate
-
-			// Update the found StatefulSet and write the result back if there are any changes
-			if needsUpdate && err == nil {
-				statefulSetLogger.Info("Updating StatefulSet")
-				err = r.Update(ctx, foundStatefulSet)
-			}
-			statefulSet = foundStatefulSet
+		if err != nil {
+			break
 		}
-	} else {
-		// If we are blocking the reconciliation of the statefulSet, we still want to find information about it.
-		statefulSet = &appsv1.StatefulSet{}
-		if e := r.Get(ctx, types.NamespacedName{Name: instance.StatefulSetName(), Namespace: instance.Namespace}, statefulSet); e != nil {
-			if errors.IsNotFound(e) {
-				statefulSet = nil
-			} else {
-				err = e
+		if poolStatefulSet != nil {
+			statefulSets = append(statefulSets, poolStatefulSet)
+			if nodePool.Name == "" {
+				statefulSet = poolStatefulSet
 			}
 		}
 	}
+	if err == nil && statefulSet != nil {
+		var removedStatefulSets []*appsv1.StatefulSet
+		removedStatefulSets, err = r.reconcileRemovedNodePools(ctx, instance, logger)
+		statefulSets = append(statefulSets, removedStatefulSets...)
+	}
 	if err != nil {
 		return requeueOrNot, err
 	}
-	if statefulSet != nil && statefulSet.Spec.Replicas != nil {
-		solrNodeNames = instance.GetSolrPodNames(int(*statefulSet.Spec.Replicas))
+	if statefulSet != nil {
+		solrNodeNames = util.GetStatefulSetPodNames(statefulSets)
 	}
 
 	extAddressabilityOpts := instance.Spec.SolrAddressability.External
@@ -436,27 +461,44 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	if extAddressabilityOpts != nil && extAddressabilityOpts.Method == solrv1beta1.GatewayAPI && !useGatewayAPI {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonInvalidConfiguration, "The %s external addressability method requires the Solr Operator to be run with --gateway-api=true, no routes will be created", solrv1beta1.GatewayAPI)
+	} else if useGatewayAPI {
+		if err = r.reconcileGatewayRoutes(ctx, instance, solrNodeNames, logger); err != nil {
+			return requeueOrNot, err
+		}
+	}
+
+	if err = r.reconcileNetworkPolicies(ctx, instance, logger); err != nil {
+		return requeueOrNot, err
+	}
+
 	// *********************************************************
 	// The operations after this require a statefulSet to exist,
 	// including updating the solrCloud status
 	// *********************************************************
 	if statefulSet == nil {
+		util.SetSolrCloudCondition(&newStatus, instance.Generation, solrv1beta1.SolrCloudAvailable, metav1.ConditionFalse, solrv1beta1.SolrCloudReasonStatefulSetNotCreated, "The StatefulSet for the SolrCloud has not been created")
+		util.SetSolrCloudCondition(&newStatus, instance.Generation, solrv1beta1.SolrCloudProgressing, metav1.ConditionTrue, solrv1beta1.SolrCloudReasonStatefulSetNotCreated, "The StatefulSet for the SolrCloud has not been created")
 		return requeueOrNot, err
 	}
 
 	// Do not reconcile the storage finalizer unless we have PVC Labels that we know the Solr data PVCs are using.
 	// Otherwise it will delete all PVCs possibly
 	if len(statefulSet.Spec.Selector.MatchLabels) > 0 {
-		if err = r.reconcileStorageFinalizer(ctx, instance, statefulSet, logger); err != nil {
+		if waitingForSnapshots, err := r.reconcileStorageFinalizer(ctx, instance, statefulSet, statefulSets, logger); err != nil {
 			logger.Error(err, "Cannot delete PVCs while garbage collecting after deletion.")
 			updateRequeueAfter(&requeueOrNot, time.Second*15)
+		} else if waitingForSnapshots {
+			logger.Info("Waiting for VolumeSnapshots of data PVCs to be ready before deleting the PVCs")
+			updateRequeueAfter(&requeueOrNot, time.Second*10)
 		}
 	}
 
 	// Get the SolrCloud's Pods and initialize them if necessary
 	var podList []corev1.Pod
 	var podSelector labels.Selector
-	if podSelector, podList, err = r.initializePods(ctx, instance, statefulSet, logger); err != nil {
+	if podSelector, podList, err = r.initializePods(ctx, instance, statefulSets, logger); err != nil {
 		return requeueOrNot, err
 	}
 
@@ -464,7 +506,7 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	var outOfDatePods util.OutOfDatePodSegmentation
 	var availableUpdatedPodCount int
 	var shouldRequeue bool
-	outOfDatePods, availableUpdatedPodCount, shouldRequeue, err = createCloudStatus(instance, &newStatus, statefulSet.Status, podSelector, podList)
+	outOfDatePods, availableUpdatedPodCount, shouldRequeue, err = createCloudStatus(instance, &newStatus, statefulSets, podSelector, podList)
 	if err != nil {
 		return requeueOrNot, err
 	} else if shouldRequeue {
@@ -473,6 +515,13 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return requeueOrNot, nil
 	}
 
+	// Report the progress of data volume expansions, which is checked regularly until all expansions are complete
+	if resizeInProgress, e := r.reportDataVolumeResizes(ctx, instance, &newStatus, statefulSets, statefulSet.Spec.Selector.MatchLabels); e != nil {
+		logger.Error(e, "Could not fetch the PVCs to report the progress of data volume expansions")
+	} else if resizeInProgress {
+		updateRequeueAfter(&requeueOrNot, time.Second*10)
+	}
+
 	// We only want to do one cluster operation at a time, so we use a lock to ensure that.
 	// Update or Scale, one-at-a-time. We do not want to do both.
 	hasReadyPod := newStatus.ReadyReplicas > 0
@@ -484,20 +533,25 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		shortTimeoutForRequeue := true
 		switch clusterOp.Operation {
 		case UpdateLock:
-			operationComplete, requestInProgress, retryLaterDuration, nextClusterOperation, err = handleManagedCloudRollingUpdate(ctx, r, instance, statefulSet, clusterOp, outOfDatePods, hasReadyPod, availableUpdatedPodCount, logger)
+			operationComplete, requestInProgress, retryLaterDuration, nextClusterOperation, err = handleManagedCloudRollingUpdate(ctx, r, instance, statefulSets, clusterOp, outOfDatePods, hasReadyPod, availableUpdatedPodCount, logger)
 			// Rolling Updates should not be requeued quickly. The operation is expected to take a long time and thus should have a longTimeout if errors are not seen.
 			shortTimeoutForRequeue = false
 		case ScaleDownLock:
-			operationComplete, requestInProgress, retryLaterDuration, err = handleManagedCloudScaleDown(ctx, r, instance, statefulSet, clusterOp, podList, logger)
+			operationComplete, requestInProgress, retryLaterDuration, err = handleManagedCloudScaleDown(ctx, r, instance, statefulSets, clusterOp, podList, logger)
 		case ScaleUpLock:
-			operationComplete, nextClusterOperation, err = handleManagedCloudScaleUp(ctx, r, instance, statefulSet, clusterOp, podList, logger)
+			operationComplete, nextClusterOperation, err = handleManagedCloudScaleUp(ctx, r, instance, statefulSets, clusterOp, podList, logger)
 		case BalanceReplicasLock:
-			operationComplete, requestInProgress, retryLaterDuration, err = util.BalanceReplicasForCluster(ctx, instance, statefulSet, clusterOp.Metadata, clusterOp.Metadata, logger)
+			operationComplete, requestInProgress, retryLaterDuration, err = util.BalanceReplicasForCluster(ctx, instance, statefulSets, clusterOp.Metadata, clusterOp.Metadata, r.Recorder, logger)
+		case StorageMigrationLock:
+			operationComplete, requestInProgress, retryLaterDuration, nextClusterOperation, err = handleManagedCloudStorageMigration(ctx, r, instance, statefulSets, podList, statefulSet.Spec.Selector.MatchLabels, logger)
+			// Storage Migrations should not be requeued quickly. Each pod needs to be recreated and have its data replicated, which can take a long time.
+			shortTimeoutForRequeue = false
 		default:
 			operationFound = false
 			// This shouldn't happen, but we don't want to be stuck if it does.
 			// Just remove the cluster Op, because the solr operator version running does not support it.
 			err = clearClusterOpLockWithPatch(ctx, r, statefulSet, "clusterOp not supported", logger)
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonClusterOpNotSupported, "Removed the %s cluster operation, because it is not supported by this version of the Solr Operator", clusterOp.Operation)
 		}
 		if operationFound {
 			err = nil
@@ -510,7 +564,12 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 					err = setNextClusterOpLockWithPatch(ctx, r, statefulSet, nextClusterOperation, string(clusterOp.Operation)+" complete", logger)
 				}
 
-				// TODO: Create event for the CRD.
+				if err == nil {
+					r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonClusterOpCompleted, "Completed the %s cluster operation", clusterOp.Operation)
+					if nextClusterOperation != nil {
+						r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonClusterOpStarted, "Started the %s cluster operation, following the %s cluster operation", nextClusterOperation.Operation, clusterOp.Operation)
+					}
+				}
 			} else if !requestInProgress {
 				// If the cluster operation is in a stoppable place (not currently doing an async operation), and either:
 				//   - the operation hit an error and has taken more than 1 minute
@@ -533,14 +592,16 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 					switch clusterOp.Operation {
 					case UpdateLock:
 						err = cleanupManagedCloudRollingUpdate(ctx, r, outOfDatePods.ScheduledForDeletion, logger)
-					case ScaleDownLock:
+					case ScaleDownLock, StorageMigrationLock:
 						err = cleanupManagedCloudScaleDown(ctx, r, podList, logger)
 					}
 					if err == nil {
 						err = enqueueCurrentClusterOpForRetryWithPatch(ctx, r, statefulSet, string(clusterOp.Operation)+" "+queueForLaterReason, logger)
 					}
 
-					// TODO: Create event for the CRD.
+					if err == nil {
+						r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonClusterOpQueuedForRetry, "The %s cluster operation %s, and has been queued to retry later", clusterOp.Operation, queueForLaterReason)
+					}
 				}
 			}
 		}
@@ -565,7 +626,7 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			// a "locked" cluster operation
 			if clusterOp == nil {
 				_, scaleDownOpIsQueued := queuedRetryOps[ScaleDownLock]
-				clusterOp, retryLaterDuration, err = determineScaleClusterOpLockIfNecessary(ctx, r, instance, statefulSet, scaleDownOpIsQueued, podList, blockReconciliationOfStatefulSet, logger)
+				clusterOp, retryLaterDuration, err = determineScaleClusterOpLockIfNecessary(ctx, r, instance, statefulSets, scaleDownOpIsQueued, podList, blockReconciliationOfStatefulSet, logger)
 
 				// If the new clusterOperation is an update to a queued clusterOp, just change the operation that is already queued
 				if clusterOp != nil {
@@ -581,6 +642,13 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 				}
 			}
 
+			// Storage migrations are only started once the SolrCloud is up-to-date and has the desired number of pods
+			if clusterOp == nil && err == nil && retryLaterDuration == 0 {
+				if _, opIsQueued := queuedRetryOps[StorageMigrationLock]; !opIsQueued {
+					clusterOp, err = determineStorageMigrationClusterOpLockIfNecessary(ctx, r, instance, &newStatus, statefulSets, statefulSet.Spec.Selector.MatchLabels)
+				}
+			}
+
 			if clusterOp != nil {
 				// Starting a locked cluster operation!
 				originalStatefulSet := statefulSet.DeepCopy()
@@ -592,10 +660,14 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 					logger.Error(err, "Error while patching StatefulSet to start locked clusterOp", clusterOp.Operation, "clusterOpMetadata", clusterOp.Metadata)
 				} else {
 					logger.Info("Started locked clusterOp", "clusterOp", clusterOp.Operation, "clusterOpMetadata", clusterOp.Metadata)
+					r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonClusterOpStarted, "Started the %s cluster operation", clusterOp.Operation)
 				}
 			} else {
 				// No new clusterOperation has been started, retry the next queued clusterOp, if there are any operations in the retry queue.
 				err = retryNextQueuedClusterOpWithPatch(ctx, r, statefulSet, clusterOpQueue, logger)
+				if err == nil && len(clusterOpQueue) > 0 {
+					r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonClusterOpRetried, "Retrying the queued %s cluster operation", clusterOpQueue[0].Operation)
+				}
 			}
 
 			// After a lock is acquired, the reconcile will be started again because the StatefulSet is being watched for changes
@@ -608,6 +680,10 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if err != nil && retryLaterDuration == 0 {
 		retryLaterDuration = time.Second * 5
 	}
+
+	// The cluster operation may have been started, completed or queued above, so use the latest information from the StatefulSet
+	setClusterOpStatus(instance, &newStatus, statefulSet, podList)
+	util.UpdateSolrNodeConditions(instance, &newStatus)
 	if retryLaterDuration > 0 {
 		updateRequeueAfter(&requeueOrNot, retryLaterDuration)
 	}
@@ -648,6 +724,78 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	// Upsert or delete the HorizontalPodAutoscaler, which scales the SolrCloud through spec.replicas
+	if instance.Spec.Scaling.Autoscaler != nil && len(instance.Spec.NodePools) == 0 {
+		hpa := util.GenerateHorizontalPodAutoscaler(instance)
+		hpaLogger := logger.WithValues("horizontalPodAutoscaler", hpa.Name)
+		foundHPA := &autoscalingv2.HorizontalPodAutoscaler{}
+		err = r.Get(ctx, types.NamespacedName{Name: hpa.Name, Namespace: hpa.Namespace}, foundHPA)
+		if err != nil && errors.IsNotFound(err) {
+			hpaLogger.Info("Creating HorizontalPodAutoscaler")
+			if err = controllerutil.SetControllerReference(instance, hpa, r.Scheme); err == nil {
+				err = r.Create(ctx, hpa)
+			}
+		} else if err == nil {
+			var needsUpdate bool
+			needsUpdate, err = util.OvertakeControllerRef(instance, foundHPA, r.Scheme)
+			needsUpdate = util.CopyHorizontalPodAutoscalerFields(hpa, foundHPA, hpaLogger) || needsUpdate
+
+			if needsUpdate && err == nil {
+				hpaLogger.Info("Updating HorizontalPodAutoscaler")
+				err = r.Update(ctx, foundHPA)
+			}
+		}
+		if err != nil {
+			return requeueOrNot, err
+		}
+	} else { // The autoscaler is disabled, make sure that we delete any previously created HPA that might exist.
+		// Only an HPA that the operator created is deleted, since users may manage their own HPA with the same name.
+		foundHPA := &autoscalingv2.HorizontalPodAutoscaler{}
+		err = r.Get(ctx, types.NamespacedName{Name: instance.StatefulSetName(), Namespace: instance.Namespace}, foundHPA)
+		if err == nil && metav1.IsControlledBy(foundHPA, instance) {
+			logger.Info("Deleting HorizontalPodAutoscaler, since the autoscaler is disabled", "horizontalPodAutoscaler", foundHPA.Name)
+			err = r.Client.Delete(ctx, foundHPA)
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return requeueOrNot, err
+		}
+	}
+
+	// Keep the live security config in sync with the managed security config, once Solr can serve requests
+	if security != nil && instance.Spec.SolrSecurity.ManagedSecurity != nil {
+		if newStatus.ReadyReplicas > 0 {
+			if r.reconcileManagedSecurity(ctx, instance, &newStatus, security, logger) {
+				// Changes made through the Security API cannot be watched, so the live security config is checked periodically
+				updateRequeueAfter(&requeueOrNot, time.Minute*5)
+			} else {
+				updateRequeueAfter(&requeueOrNot, time.Second*30)
+			}
+		} else {
+			util.SetSolrCloudCondition(&newStatus, instance.Generation, solrv1beta1.SolrCloudSecurityReconciled, metav1.ConditionFalse, solrv1beta1.SolrCloudReasonSecurityNotReconciled, "Waiting for a Solr Node to be ready to serve requests")
+		}
+	} else {
+		util.RemoveSolrCloudCondition(&newStatus, solrv1beta1.SolrCloudSecurityReconciled)
+	}
+
+	// Rotate the password of the Solr Operator's user on its schedule, this requires Solr to be able to serve requests
+	if security != nil && instance.Spec.SolrSecurity.CredentialRotation != nil && instance.Spec.SolrSecurity.ProbesRequireAuth {
+		// The probes read the password from the mounted secret, which is only updated by the Kubelet after the password has changed in Solr
+		r.Recorder.Event(instance, corev1.EventTypeWarning, util.EventReasonInvalidConfiguration, "Credential rotation cannot be used with probesRequireAuth, since the probes of every Solr pod would fail after each rotation. The password will not be rotated")
+	} else if security != nil && instance.Spec.SolrSecurity.CredentialRotation != nil && newStatus.ReadyReplicas > 0 {
+		if rotated, reconcileWaitDuration, rotationErr := util.ReconcileCredentialRotation(ctx, &r.Client, instance, security, logger); rotationErr != nil {
+			logger.Error(rotationErr, "Could not rotate the password of the Solr Operator's user")
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonCredentialRotationFailed, "Could not rotate the password of the Solr Operator's user: %s", rotationErr.Error())
+			updateRequeueAfter(&requeueOrNot, time.Minute)
+		} else {
+			if rotated {
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonCredentialsRotated, "Rotated the password of the Solr Operator's user in Solr and in secret %s", security.CredentialsSecret.Name)
+			}
+			if reconcileWaitDuration != nil {
+				updateRequeueAfter(&requeueOrNot, *reconcileWaitDuration)
+			}
+		}
+	}
+
 	// Remove unused services if necessary
 	err = r.cleanupUnconfiguredServices(ctx, instance, podList, logger)
 	if err != nil && !errors.IsNotFound(err) {
@@ -667,6 +815,272 @@ func (r *SolrCloudReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	return requeueOrNot, err
 }
 
+// reconcileNodePoolStatefulSet creates or updates the StatefulSet for a node pool of the SolrCloud.
+// The StatefulSet is only returned if it already existed, since a newly created StatefulSet will be handled in the next reconcile.
+func (r *SolrCloudReconciler) reconcileNodePoolStatefulSet(ctx context.Context, instance *solrv1beta1.SolrCloud, nodePool solrv1beta1.SolrNodePool, newStatus *solrv1beta1.SolrCloudStatus, hostNameIpMap map[string]string, reconcileConfigInfo map[string]string, tls *util.TLSCerts, security *util.SecurityConfig, requeueOrNot *reconcile.Result, logger logr.Logger) (statefulSet *appsv1.StatefulSet, err error) {
+	// Generate StatefulSet that should exist
+	expectedStatefulSet := util.GenerateStatefulSet(instance, nodePool, newStatus, hostNameIpMap, reconcileConfigInfo, tls, security)
+
+	// Check if the StatefulSet already exists
+	statefulSetLogger := logger.WithValues("statefulSet", expectedStatefulSet.Name)
+	foundStatefulSet := &appsv1.StatefulSet{}
+	err = r.Get(ctx, types.NamespacedName{Name: expectedStatefulSet.Name, Namespace: expectedStatefulSet.Namespace}, foundStatefulSet)
+
+	// TODO: Move this logic down to the cluster ops and save the existing annotation in util.MaintainPreservedStatefulSetFields()
+	// Set the annotation for a scheduled restart, if necessary.
+	if nextRestartAnnotation, reconcileWaitDuration, schedulingErr := util.ScheduleNextRestart(instance.Spec.UpdateStrategy.RestartSchedule, foundStatefulSet.Spec.Template.Annotations); schedulingErr != nil {
+		logger.Error(schedulingErr, "Cannot parse restartSchedule cron", "cron", instance.Spec.UpdateStrategy.RestartSchedule)
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonInvalidConfiguration, "Cannot parse restartSchedule cron %q: %s", instance.Spec.UpdateStrategy.RestartSchedule, schedulingErr.Error())
+	} else {
+		if nextRestartAnnotation != "" {
+			// Set the new restart time annotation
+			expectedStatefulSet.Spec.Template.Annotations[util.SolrScheduledRestartAnnotation] = nextRestartAnnotation
+			// All node pools are restarted on the same schedule, so only record the event once
+			if nodePool.Name == "" {
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonScheduledRestart, "Scheduled the next restart of the Solr Nodes for %s", nextRestartAnnotation)
+			}
+		} else if existingRestartAnnotation, exists := foundStatefulSet.Spec.Template.Annotations[util.SolrScheduledRestartAnnotation]; exists {
+			// Keep the existing nextRestart annotation if it exists and we aren't setting a new one.
+			expectedStatefulSet.Spec.Template.Annotations[util.SolrScheduledRestartAnnotation] = existingRestartAnnotation
+		}
+		if reconcileWaitDuration != nil {
+			// Set the requeueAfter if it has not been set, or is greater than the time we need to wait to restart again
+			updateRequeueAfter(requeueOrNot, *reconcileWaitDuration)
+		}
+	}
+
+	// Update or Create the StatefulSet
+	if err != nil && errors.IsNotFound(err) {
+		statefulSetLogger.Info("Creating StatefulSet")
+		if err = controllerutil.SetControllerReference(instance, expectedStatefulSet, r.Scheme); err == nil {
+			err = r.Create(ctx, expectedStatefulSet)
+		}
+		// Wait for the next reconcile loop
+		statefulSet = nil
+	} else if err == nil {
+		if foundStatefulSet.DeletionTimestamp != nil {
+			// The StatefulSet is being recreated to change its data volumes, so wait for it to be deleted before creating it again
+			statefulSetLogger.Info("Waiting for the StatefulSet to be deleted, so that it can be recreated")
+			updateRequeueAfter(requeueOrNot, time.Second*5)
+			return nil, nil
+		}
+		var isRecreating bool
+		if isRecreating, err = r.reconcileStatefulSetSelector(ctx, instance, nodePool, expectedStatefulSet, foundStatefulSet, requeueOrNot, statefulSetLogger); err != nil || isRecreating {
+			return nil, err
+		}
+		if isRecreating, err = r.reconcileDataVolumeTemplate(ctx, instance, nodePool, expectedStatefulSet, foundStatefulSet, requeueOrNot, statefulSetLogger); err != nil || isRecreating {
+			return nil, err
+		}
+
+		util.MaintainPreservedStatefulSetFields(expectedStatefulSet, foundStatefulSet)
+
+		// Check to see if the StatefulSet needs an update
+		var needsUpdate bool
+		needsUpdate, err = util.OvertakeControllerRef(instance, foundStatefulSet, r.Scheme)
+		needsUpdate = util.CopyStatefulSetFields(expectedStatefulSet, foundStatefulSet, statefulSetLogger) || needsUpdate
+
+		// Update the found StatefulSet and write the result back if there are any changes
+		if needsUpdate && err == nil {
+			statefulSetLogger.Info("Updating StatefulSet")
+			err = r.Update(ctx, foundStatefulSet)
+		}
+		statefulSet = foundStatefulSet
+	}
+	return statefulSet, err
+}
+
+// reconcileStatefulSetSelector recreates the StatefulSet of the default node pool, when it was created with a selector that also matches the pods of the other node pools.
+// Since the selector of a StatefulSet cannot be changed, the StatefulSet is deleted without deleting its pods,
+// so that it can be recreated with the new selector. The pods are adopted by the new StatefulSet once it is created.
+// This is only done once the SolrCloud uses node pools, so that upgrading the Solr Operator does not recreate the StatefulSets of every SolrCloud.
+func (r *SolrCloudReconciler) reconcileStatefulSetSelector(ctx context.Context, instance *solrv1beta1.SolrCloud, nodePool solrv1beta1.SolrNodePool, expectedStatefulSet *appsv1.StatefulSet, foundStatefulSet *appsv1.StatefulSet, requeueOrNot *reconcile.Result, logger logr.Logger) (isRecreating bool, err error) {
+	if len(instance.Spec.NodePools) == 0 || util.DeepEqualWithNils(expectedStatefulSet.Spec.Selector, foundStatefulSet.Spec.Selector) {
+		return false, nil
+	}
+
+	// The cluster operation information is stored in the StatefulSet, and would be lost if it were recreated.
+	// The StatefulSet's replicas are also reset when it is recreated, which must not skip a managed scale down.
+	_, hasLock := foundStatefulSet.Annotations[util.ClusterOpsLockAnnotation]
+	_, hasRetryQueue := foundStatefulSet.Annotations[util.ClusterOpsRetryQueueAnnotation]
+	if hasLock || hasRetryQueue || foundStatefulSet.Spec.Replicas == nil || int(*foundStatefulSet.Spec.Replicas) != desiredNodePoolReplicas(instance, nodePool.Name) {
+		logger.Info("Waiting for cluster operations to complete before changing the StatefulSet selector")
+		updateRequeueAfter(requeueOrNot, time.Second*15)
+		return false, nil
+	}
+
+	logger.Info("Deleting the StatefulSet, without deleting its pods, so that it can be recreated with the new selector")
+	if err = r.Delete(ctx, foundStatefulSet, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonRecreatingStatefulSet, "Recreating StatefulSet %s, so that it does not select the pods of other node pools", foundStatefulSet.Name)
+	updateRequeueAfter(requeueOrNot, time.Second*5)
+	return true, nil
+}
+
+// reconcileDataVolumeTemplate recreates the StatefulSet when a larger size, a smaller size or a different StorageClass has been requested for the Solr data volume.
+// Since the volumeClaimTemplates of a StatefulSet cannot be changed, the StatefulSet is deleted without deleting its pods,
+// so that it can be recreated with the new template. The pods are adopted by the new StatefulSet once it is created.
+//
+// When a larger size has been requested, the existing data PVCs are expanded first.
+// Kubernetes will refuse the expansion of PVCs whose StorageClass does not allow volume expansion,
+// in which case the StatefulSet is left unchanged.
+// Otherwise, the existing data PVCs are replaced one-by-one by the StorageMigration cluster operation, once the StatefulSet has been recreated.
+func (r *SolrCloudReconciler) reconcileDataVolumeTemplate(ctx context.Context, instance *solrv1beta1.SolrCloud, nodePool solrv1beta1.SolrNodePool, expectedStatefulSet *appsv1.StatefulSet, foundStatefulSet *appsv1.StatefulSet, requeueOrNot *reconcile.Result, logger logr.Logger) (isRecreating bool, err error) {
+	desiredSize, hasPersistentData := util.DataVolumeClaimSize(expectedStatefulSet)
+	currentSize, hadPersistentData := util.DataVolumeClaimSize(foundStatefulSet)
+	if !hasPersistentData || !hadPersistentData {
+		return false, nil
+	}
+	requiresMigration := util.DataVolumeRequiresMigration(expectedStatefulSet, foundStatefulSet.Spec.VolumeClaimTemplates[0].Spec)
+	if !requiresMigration && desiredSize.Cmp(currentSize) <= 0 {
+		return false, nil
+	}
+	logger = logger.WithValues("dataVolumeSize", desiredSize.String(), "requiresMigration", requiresMigration)
+
+	// The cluster operation information is stored in the StatefulSet, and would be lost if it were recreated.
+	// The StatefulSet's replicas are also reset when it is recreated, which must not skip a managed scale down.
+	_, hasLock := foundStatefulSet.Annotations[util.ClusterOpsLockAnnotation]
+	_, hasRetryQueue := foundStatefulSet.Annotations[util.ClusterOpsRetryQueueAnnotation]
+	if hasLock || hasRetryQueue || foundStatefulSet.Spec.Replicas == nil || int(*foundStatefulSet.Spec.Replicas) != desiredNodePoolReplicas(instance, nodePool.Name) {
+		logger.Info("Waiting for cluster operations to complete before changing the data volume template")
+		updateRequeueAfter(requeueOrNot, time.Second*15)
+		return false, nil
+	}
+
+	if !requiresMigration {
+		if err = r.expandDataVolumes(ctx, instance, foundStatefulSet, desiredSize, logger); err != nil {
+			if errors.IsForbidden(err) || errors.IsInvalid(err) {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonExpandDataVolumesFailed, "Cannot expand the data volumes of StatefulSet %s to %s: %s", foundStatefulSet.Name, desiredSize.String(), err.Error())
+				updateRequeueAfter(requeueOrNot, time.Minute)
+				return false, nil
+			}
+			return false, err
+		}
+	}
+
+	logger.Info("Deleting the StatefulSet, without deleting its pods, so that it can be recreated with the new data volume template")
+	if err = r.Delete(ctx, foundStatefulSet, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonRecreatingStatefulSet, "Recreating StatefulSet %s with the new data volume template", foundStatefulSet.Name)
+	updateRequeueAfter(requeueOrNot, time.Second*5)
+	return true, nil
+}
+
+// expandDataVolumes expands each of the data PVCs of the StatefulSet that request less than the desiredSize.
+func (r *SolrCloudReconciler) expandDataVolumes(ctx context.Context, instance *solrv1beta1.SolrCloud, foundStatefulSet *appsv1.StatefulSet, desiredSize resource.Quantity, logger logr.Logger) (err error) {
+	pvcList, err := r.getNodePoolPVCList(ctx, instance, foundStatefulSet.Labels[util.SolrNodePoolLabel])
+	if err != nil {
+		return err
+	}
+	expandedPVCs := 0
+	for _, pvc := range pvcList.Items {
+		if !util.IsPVCForStatefulSet(pvc.Name, foundStatefulSet.Spec.VolumeClaimTemplates[0].Name, foundStatefulSet.Name) || !util.PVCNeedsExpansion(&pvc, desiredSize) {
+			continue
+		}
+		patch := client.MergeFrom(pvc.DeepCopy())
+		if pvc.Spec.Resources.Requests == nil {
+			pvc.Spec.Resources.Requests = corev1.ResourceList{}
+		}
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = desiredSize
+		logger.Info("Expanding data PVC", "persistentVolumeClaim", pvc.Name)
+		if err = r.Patch(ctx, &pvc, patch); err != nil {
+			logger.Error(err, "Cannot expand data PVC", "persistentVolumeClaim", pvc.Name)
+			return err
+		}
+		expandedPVCs++
+	}
+	if expandedPVCs > 0 {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonExpandingDataVolumes, "Expanding %d data volumes of StatefulSet %s to %s", expandedPVCs, foundStatefulSet.Name, desiredSize.String())
+	}
+	return nil
+}
+
+// reportDataVolumeResizes adds the progress of data volume expansions to the status of each Solr Node.
+// PVCs are not watched by the Solr Operator, so resizeInProgress is returned to signal that the status needs to be checked again later.
+func (r *SolrCloudReconciler) reportDataVolumeResizes(ctx context.Context, instance *solrv1beta1.SolrCloud, newStatus *solrv1beta1.SolrCloudStatus, statefulSets []*appsv1.StatefulSet, pvcLabelSelector map[string]string) (resizeInProgress bool, err error) {
+	if !instance.UsesPersistentStorage() {
+		return false, nil
+	}
+	pvcList, err := r.getPVCList(ctx, instance, pvcLabelSelector)
+	if err != nil {
+		return false, err
+	}
+	resizeStatuses := make(map[string]*solrv1beta1.SolrVolumeResizeStatus, len(pvcList.Items))
+	for _, statefulSet := range statefulSets {
+		for _, podName := range util.GetStatefulSetPodNames([]*appsv1.StatefulSet{statefulSet}) {
+			pvcName := util.DataVolumeClaimName(statefulSet, podName)
+			for i := range pvcList.Items {
+				if pvcList.Items[i].Name == pvcName {
+					resizeStatuses[podName] = util.GetVolumeResizeStatus(&pvcList.Items[i])
+					break
+				}
+			}
+		}
+	}
+	for i := range newStatus.SolrNodes {
+		newStatus.SolrNodes[i].DataVolumeResize = resizeStatuses[newStatus.SolrNodes[i].Name]
+		resizeInProgress = resizeInProgress || newStatus.SolrNodes[i].DataVolumeResize != nil
+	}
+	return resizeInProgress, nil
+}
+
+// reconcileRemovedNodePools finds the StatefulSets of node pools that have been removed from the SolrCloud.
+// Once a removed node pool has been scaled down to zero pods, its StatefulSet is deleted.
+// The StatefulSets that still have pods are returned, so that they can be scaled down by the cluster operations.
+func (r *SolrCloudReconciler) reconcileRemovedNodePools(ctx context.Context, instance *solrv1beta1.SolrCloud, logger logr.Logger) (removedStatefulSets []*appsv1.StatefulSet, err error) {
+	selectorLabels := instance.SharedLabels()
+	selectorLabels["technology"] = solrv1beta1.SolrTechnologyLabel
+	nodePoolRequirement, err := labels.NewRequirement(util.SolrNodePoolLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	listOps := &client.ListOptions{
+		Namespace:     instance.Namespace,
+		LabelSelector: labels.SelectorFromSet(selectorLabels).Add(*nodePoolRequirement),
+	}
+	foundStatefulSets := &appsv1.StatefulSetList{}
+	if err = r.List(ctx, foundStatefulSets, listOps); err != nil {
+		logger.Error(err, "Error listing node pool StatefulSets for SolrCloud")
+		return nil, err
+	}
+
+	nodePoolNames := make(map[string]bool, len(instance.Spec.NodePools))
+	for _, pool := range instance.Spec.NodePools {
+		nodePoolNames[pool.Name] = true
+	}
+	for i := range foundStatefulSets.Items {
+		foundStatefulSet := &foundStatefulSets.Items[i]
+		if nodePoolNames[foundStatefulSet.Labels[util.SolrNodePoolLabel]] || !metav1.IsControlledBy(foundStatefulSet, instance) {
+			continue
+		}
+		if (foundStatefulSet.Spec.Replicas == nil || *foundStatefulSet.Spec.Replicas == 0) && foundStatefulSet.Status.Replicas == 0 {
+			logger.Info("Deleting StatefulSet for removed node pool", "statefulSet", foundStatefulSet.Name)
+			if err = r.Delete(ctx, foundStatefulSet); err != nil && !errors.IsNotFound(err) {
+				logger.Error(err, "Error deleting StatefulSet for removed node pool", "statefulSet", foundStatefulSet.Name)
+				return nil, err
+			}
+			err = nil
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonDeletedNodePool, "Deleted the StatefulSet %s, since its node pool has been removed", foundStatefulSet.Name)
+		} else {
+			removedStatefulSets = append(removedStatefulSets, foundStatefulSet)
+		}
+	}
+	return removedStatefulSets, nil
+}
+
+// updateStatusConditions patches only the conditions of the SolrCloud status, if they have changed.
+func (r *SolrCloudReconciler) updateStatusConditions(ctx context.Context, solrCloud *solrv1beta1.SolrCloud, conditions []metav1.Condition, logger logr.Logger) {
+	if reflect.DeepEqual(solrCloud.Status.Conditions, conditions) {
+		return
+	}
+	oldInstance := solrCloud.DeepCopy()
+	solrCloud.Status.Conditions = conditions
+	if err := r.Status().Patch(ctx, solrCloud, client.MergeFrom(oldInstance)); err != nil {
+		logger.Error(err, "Error while updating SolrCloud status conditions")
+	}
+}
+
 // cleanupUnconfiguredServices remove services that are no longer defined by the SolrCloud resource, and no longer in use by pods.
 // This does not currently include removing per-node services that are no longer in use because the SolrCloud has been scaled down.
 func (r *SolrCloudReconciler) cleanupUnconfiguredServices(ctx context.Context, solrCloud *solrv1beta1.SolrCloud, podList []corev1.Pod, logger logr.Logger) (err error) {
@@ -737,7 +1151,7 @@ func (r *SolrCloudReconciler) deleteService(ctx context.Context, service *corev1
 }
 
 // initializePods Ensure that all SolrCloud Pods are initialized
-func (r *SolrCloudReconciler) initializePods(ctx context.Context, solrCloud *solrv1beta1.SolrCloud, statefulSet *appsv1.StatefulSet, logger logr.Logger) (podSelector labels.Selector, podList []corev1.Pod, err error) {
+func (r *SolrCloudReconciler) initializePods(ctx context.Context, solrCloud *solrv1beta1.SolrCloud, statefulSets []*appsv1.StatefulSet, logger logr.Logger) (podSelector labels.Selector, podList []corev1.Pod, err error) {
 	foundPods := &corev1.PodList{}
 	selectorLabels := solrCloud.SharedLabels()
 	selectorLabels["technology"] = solrv1beta1.SolrTechnologyLabel
@@ -755,15 +1169,8 @@ func (r *SolrCloudReconciler) initializePods(ctx context.Context, solrCloud *sol
 
 	// Initialize the pod's notStopped readinessCondition so that they can receive traffic until they are stopped
 	for _, pod := range foundPods.Items {
-		isOwnedByCurrentStatefulSet := false
-		for _, ownerRef := range pod.ObjectMeta.OwnerReferences {
-			if ownerRef.UID == statefulSet.UID {
-				isOwnedByCurrentStatefulSet = true
-				break
-			}
-		}
-		// Do not include pods that match, but are not owned by the current statefulSet
-		if !isOwnedByCurrentStatefulSet {
+		// Do not include pods that match, but are not owned by one of the current statefulSets
+		if owningStatefulSet(&pod, statefulSets) == nil {
 			continue
 		}
 		if updatedPod, podError := r.initializePod(ctx, &pod, logger); podError != nil {
@@ -794,7 +1201,7 @@ func (r *SolrCloudReconciler) initializePod(ctx context.Context, pod *corev1.Pod
 			// set the pod back to its original state since the patch failed
 			updatedPod = pod
 
-			// TODO: Create event for the CRD.
+			r.Recorder.Eventf(pod, corev1.EventTypeWarning, util.EventReasonPodReadinessFailed, "Could not initialize the readiness conditions of the pod: %s", err.Error())
 		}
 	}
 	return
@@ -802,13 +1209,21 @@ func (r *SolrCloudReconciler) initializePod(ctx context.Context, pod *corev1.Pod
 
 // Initialize the SolrCloud.Status object
 func createCloudStatus(solrCloud *solrv1beta1.SolrCloud,
-	newStatus *solrv1beta1.SolrCloudStatus, statefulSetStatus appsv1.StatefulSetStatus, podSelector labels.Selector,
+	newStatus *solrv1beta1.SolrCloudStatus, statefulSets []*appsv1.StatefulSet, podSelector labels.Selector,
 	podList []corev1.Pod) (outOfDatePods util.OutOfDatePodSegmentation, availableUpdatedPodCount int, shouldRequeue bool, err error) {
 	var otherVersions []string
 	nodeNames := make([]string, len(podList))
 	nodeStatusMap := map[string]solrv1beta1.SolrNodeStatus{}
 
-	newStatus.Replicas = statefulSetStatus.Replicas
+	// The status of the SolrCloud combines the statuses of the StatefulSets for all node pools
+	var statefulSetReplicas, statefulSetReadyReplicas, statefulSetUpdatedReplicas int32
+	for _, statefulSet := range statefulSets {
+		statefulSetReplicas += statefulSet.Status.Replicas
+		statefulSetReadyReplicas += statefulSet.Status.ReadyReplicas
+		statefulSetUpdatedReplicas += statefulSet.Status.UpdatedReplicas
+	}
+
+	newStatus.Replicas = statefulSetReplicas
 	newStatus.UpToDateNodes = int32(0)
 	newStatus.ReadyReplicas = int32(0)
 
@@ -822,8 +1237,10 @@ func createCloudStatus(solrCloud *solrv1beta1.SolrCloud,
 		nodeStatus := solrv1beta1.SolrNodeStatus{}
 		nodeStatus.Name = p.Name
 		nodeStatus.NodeName = p.Spec.NodeName
+		nodeStatus.NodePool = p.Labels[util.SolrNodePoolLabel]
 		nodeStatus.InternalAddress = solrCloud.UrlScheme(false) + "://" + solrCloud.InternalNodeUrl(nodeStatus.Name, true)
-		if solrCloud.Spec.SolrAddressability.External != nil && !solrCloud.Spec.SolrAddressability.External.HideNodes {
+		// LoadBalancer services do not have an external hostname, unless a domainName is provided
+		if solrCloud.Spec.SolrAddressability.External != nil && !solrCloud.Spec.SolrAddressability.External.HideNodes && solrCloud.Spec.SolrAddressability.External.DomainName != "" {
 			nodeStatus.ExternalAddress = solrCloud.UrlScheme(true) + "://" + solrCloud.ExternalNodeUrl(nodeStatus.Name, solrCloud.Spec.SolrAddressability.External.DomainName, true)
 		}
 		if len(p.Status.ContainerStatuses) > 0 {
@@ -856,12 +1273,15 @@ func createCloudStatus(solrCloud *solrv1beta1.SolrCloud,
 			backupReposAvailable[repo] = (availableSoFar || podIdx == 0) && backupReposAvailableForPod[repo]
 		}
 
-		// A pod is out of date if it's revision label is not equal to the statefulSetStatus' updateRevision.
+		// A pod is out of date if it's revision label is not equal to the updateRevision of the statefulSet that owns it.
 		// Assume the pod is up-to-date if we don't have an updateRevision from the statefulSet status.
 		// This should only happen when the statefulSet has just been created, so it's not a big deal.
 		// NOTE: This is usually because the statefulSet status wasn't fetched, not because the fetched updateRevision
 		//       is empty.
-		updateRevision := statefulSetStatus.UpdateRevision
+		updateRevision := ""
+		if statefulSet := owningStatefulSet(&p, statefulSets); statefulSet != nil {
+			updateRevision = statefulSet.Status.UpdateRevision
+		}
 		nodeStatus.SpecUpToDate = updateRevision == "" || p.Labels["controller-revision-hash"] == updateRevision
 		if nodeStatus.SpecUpToDate {
 			newStatus.UpToDateNodes += 1
@@ -921,11 +1341,11 @@ func createCloudStatus(solrCloud *solrv1beta1.SolrCloud,
 	}
 
 	newStatus.InternalCommonAddress = solrCloud.UrlScheme(false) + "://" + solrCloud.InternalCommonUrl(true)
-	if solrCloud.Spec.SolrAddressability.External != nil && !solrCloud.Spec.SolrAddressability.External.HideCommon {
+	if solrCloud.Spec.SolrAddressability.External != nil && !solrCloud.Spec.SolrAddressability.External.HideCommon && solrCloud.Spec.SolrAddressability.External.DomainName != "" {
 		extAddress := solrCloud.UrlScheme(true) + "://" + solrCloud.ExternalCommonUrl(solrCloud.Spec.SolrAddressability.External.DomainName, true)
 		newStatus.ExternalCommonAddress = &extAddress
 	}
-	shouldRequeue = newStatus.ReadyReplicas != statefulSetStatus.ReadyReplicas || newStatus.Replicas != statefulSetStatus.Replicas || newStatus.UpToDateNodes != statefulSetStatus.UpdatedReplicas
+	shouldRequeue = newStatus.ReadyReplicas != statefulSetReadyReplicas || newStatus.Replicas != statefulSetReplicas || newStatus.UpToDateNodes != statefulSetUpdatedReplicas
 
 	return outOfDatePods, availableUpdatedPodCount, shouldRequeue, nil
 }
@@ -963,6 +1383,171 @@ func (r *SolrCloudReconciler) reconcileNodeService(ctx context.Context, logger l
 
 	return nil, ip
 }
+
+// reconcileManagedSecurity sends the Security API commands needed for the live security config to match the managed security config,
+// and sets the SecurityReconciled condition. Errors do not stop the rest of the SolrCloud from being reconciled, so success is returned instead.
+func (r *SolrCloudReconciler) reconcileManagedSecurity(ctx context.Context, instance *solrv1beta1.SolrCloud, newStatus *solrv1beta1.SolrCloudStatus, security *util.SecurityConfig, logger logr.Logger) (success bool) {
+	appliedCommands, unsettableUsers, err := util.ReconcileManagedSecurity(ctx, &r.Client, instance, security, logger)
+	if appliedCommands > 0 {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, util.EventReasonSecurityUpdated, "Sent %d commands to the Security API to match the managed security config", appliedCommands)
+	}
+	if err != nil {
+		logger.Error(err, "Could not reconcile the managed security config")
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, util.EventReasonSecurityReconcileFailed, "Could not reconcile the managed security config: %s", err.Error())
+		util.SetSolrCloudCondition(newStatus, instance.Generation, solrv1beta1.SolrCloudSecurityReconciled, metav1.ConditionFalse, solrv1beta1.SolrCloudReasonSecurityReconcileFailed, "The managed security config could not be reconciled, see the events of the SolrCloud for details")
+		return false
+	}
+	if len(unsettableUsers) > 0 {
+		util.SetSolrCloudCondition(newStatus, instance.Generation, solrv1beta1.SolrCloudSecurityReconciled, metav1.ConditionFalse, solrv1beta1.SolrCloudReasonSecurityNotReconciled, fmt.Sprintf("The credentials of the users %s cannot be set through the Security API, since the bootstrapSecurityJson only contains their password hashes", strings.Join(unsettableUsers, ", ")))
+	} else {
+		util.SetSolrCloudCondition(newStatus, instance.Generation, solrv1beta1.SolrCloudSecurityReconciled, metav1.ConditionTrue, solrv1beta1.SolrCloudReasonSecurityReconciled, "The live security config matches the managed security config")
+	}
+	return true
+}
+
+// reconcileNetworkPolicies creates or updates the NetworkPolicies of a SolrCloud with spec.networkPolicy, and deletes them otherwise.
+// A NetworkPolicy is only created for the Zookeeper pods if the SolrCloud uses a provided Zookeeper ensemble.
+func (r *SolrCloudReconciler) reconcileNetworkPolicies(ctx context.Context, instance *solrv1beta1.SolrCloud, logger logr.Logger) (err error) {
+	var cloudExporters []solrv1beta1.SolrPrometheusExporter
+	if instance.Spec.NetworkPolicy != nil {
+		// SolrPrometheusExporters can reference SolrClouds in other namespaces
+		exporterList := &solrv1beta1.SolrPrometheusExporterList{}
+		if err = r.List(ctx, exporterList); err != nil {
+			return err
+		}
+		cloudExporters = util.SolrPrometheusExportersForCloud(instance, exporterList.Items)
+	}
+
+	usesProvidedZk := instance.Spec.ZookeeperRef != nil && instance.Spec.ZookeeperRef.ProvidedZookeeper != nil
+	if err = r.reconcileNetworkPolicy(ctx, instance, util.GenerateSolrNetworkPolicy(instance, cloudExporters, operatorNamespace), instance.Spec.NetworkPolicy != nil, logger); err != nil {
+		return err
+	}
+	return r.reconcileNetworkPolicy(ctx, instance, util.GenerateZookeeperNetworkPolicy(instance, cloudExporters), instance.Spec.NetworkPolicy != nil && usesProvidedZk, logger)
+}
+
+// reconcileNetworkPolicy upserts the given NetworkPolicy if it is enabled, otherwise it deletes any previously created policy that might exist.
+func (r *SolrCloudReconciler) reconcileNetworkPolicy(ctx context.Context, instance *solrv1beta1.SolrCloud, networkPolicy *netv1.NetworkPolicy, enabled bool, logger logr.Logger) (err error) {
+	if !enabled {
+		if err = r.Client.Delete(ctx, networkPolicy); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	policyLogger := logger.WithValues("networkPolicy", networkPolicy.Name)
+	foundPolicy := &netv1.NetworkPolicy{}
+	err = r.Get(ctx, types.NamespacedName{Name: networkPolicy.Name, Namespace: networkPolicy.Namespace}, foundPolicy)
+	if err != nil && errors.IsNotFound(err) {
+		policyLogger.Info("Creating NetworkPolicy")
+		if err = controllerutil.SetControllerReference(instance, networkPolicy, r.Scheme); err == nil {
+			err = r.Create(ctx, networkPolicy)
+		}
+	} else if err == nil {
+		var needsUpdate bool
+		needsUpdate, err = util.OvertakeControllerRef(instance, foundPolicy, r.Scheme)
+		needsUpdate = util.CopyNetworkPolicyFields(networkPolicy, foundPolicy, policyLogger) || needsUpdate
+		if needsUpdate && err == nil {
+			policyLogger.Info("Updating NetworkPolicy")
+			err = r.Update(ctx, foundPolicy)
+		}
+	}
+	return err
+}
+
+// reconcileGatewayRoutes creates or updates the Gateway API routes of a SolrCloud using the GatewayAPI external addressability method,
+// and deletes the routes that are no longer needed, such as those of removed Solr Nodes.
+// HTTPRoutes are used, unless the SolrCloud has TLS enabled, in which case TLSRoutes pass the TLS connections through to Solr.
+func (r *SolrCloudReconciler) reconcileGatewayRoutes(ctx context.Context, instance *solrv1beta1.SolrCloud, solrNodeNames []string, logger logr.Logger) (err error) {
+	var httpRoutes []*gatewayv1.HTTPRoute
+	var tlsRoutes []*gatewayv1alpha2.TLSRoute
+	if extOpts := instance.Spec.SolrAddressability.External; extOpts != nil && extOpts.Method == solrv1beta1.GatewayAPI {
+		if instance.Spec.SolrTLS != nil {
+			tlsRoutes = util.GenerateTLSRoutes(instance, solrNodeNames)
+		} else {
+			httpRoutes = util.GenerateHTTPRoutes(instance, solrNodeNames)
+		}
+	}
+
+	expectedHTTPRoutes := make(map[string]bool, len(httpRoutes))
+	for _, route := range httpRoutes {
+		expectedHTTPRoutes[route.Name] = true
+		routeLogger := logger.WithValues("httpRoute", route.Name)
+		foundRoute := &gatewayv1.HTTPRoute{}
+		err = r.Get(ctx, types.NamespacedName{Name: route.Name, Namespace: route.Namespace}, foundRoute)
+		if err != nil && errors.IsNotFound(err) {
+			routeLogger.Info("Creating HTTPRoute")
+			if err = controllerutil.SetControllerReference(instance, route, r.Scheme); err == nil {
+				err = r.Create(ctx, route)
+			}
+		} else if err == nil {
+			var needsUpdate bool
+			needsUpdate, err = util.OvertakeControllerRef(instance, foundRoute, r.Scheme)
+			needsUpdate = util.CopyHTTPRouteFields(route, foundRoute, routeLogger) || needsUpdate
+			if needsUpdate && err == nil {
+				routeLogger.Info("Updating HTTPRoute")
+				err = r.Update(ctx, foundRoute)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	expectedTLSRoutes := make(map[string]bool, len(tlsRoutes))
+	for _, route := range tlsRoutes {
+		expectedTLSRoutes[route.Name] = true
+		routeLogger := logger.WithValues("tlsRoute", route.Name)
+		foundRoute := &gatewayv1alpha2.TLSRoute{}
+		err = r.Get(ctx, types.NamespacedName{Name: route.Name, Namespace: route.Namespace}, foundRoute)
+		if err != nil && errors.IsNotFound(err) {
+			routeLogger.Info("Creating TLSRoute")
+			if err = controllerutil.SetControllerReference(instance, route, r.Scheme); err == nil {
+				err = r.Create(ctx, route)
+			}
+		} else if err == nil {
+			var needsUpdate bool
+			needsUpdate, err = util.OvertakeControllerRef(instance, foundRoute, r.Scheme)
+			needsUpdate = util.CopyTLSRouteFields(route, foundRoute, routeLogger) || needsUpdate
+			if needsUpdate && err == nil {
+				routeLogger.Info("Updating TLSRoute")
+				err = r.Update(ctx, foundRoute)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Delete the routes that are no longer needed
+	listOps := &client.ListOptions{Namespace: instance.Namespace, LabelSelector: labels.SelectorFromSet(instance.SharedLabels())}
+	foundHTTPRoutes := &gatewayv1.HTTPRouteList{}
+	if err = r.List(ctx, foundHTTPRoutes, listOps); err != nil {
+		return err
+	}
+	for i := range foundHTTPRoutes.Items {
+		route := &foundHTTPRoutes.Items[i]
+		if !expectedHTTPRoutes[route.Name] && metav1.IsControlledBy(route, instance) {
+			logger.Info("Deleting HTTPRoute", "httpRoute", route.Name)
+			if err = r.Delete(ctx, route); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	foundTLSRoutes := &gatewayv1alpha2.TLSRouteList{}
+	if err = r.List(ctx, foundTLSRoutes, listOps); err != nil {
+		return err
+	}
+	for i := range foundTLSRoutes.Items {
+		route := &foundTLSRoutes.Items[i]
+		if !expectedTLSRoutes[route.Name] && metav1.IsControlledBy(route, instance) {
+			logger.Info("Deleting TLSRoute", "tlsRoute", route.Name)
+			if err = r.Delete(ctx, route); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (r *SolrCloudReconciler) reconcileZk(ctx context.Context, logger logr.Logger, instance *solrv1beta1.SolrCloud, newStatus *solrv1beta1.SolrCloudStatus) error {
 	zkRef := instance.Spec.ZookeeperRef
 
@@ -1020,7 +1605,7 @@ func (r *SolrCloudReconciler) reconcileZk(ctx context.Context, logger logr.Logge
 // Logic derived from:
 // - https://book.kubebuilder.io/reference/using-finalizers.html
 // - https://github.com/pravega/zookeeper-operator/blob/v0.2.9/pkg/controller/zookeepercluster/zookeepercluster_controller.go#L629
-func (r *SolrCloudReconciler) reconcileStorageFinalizer(ctx context.Context, cloud *solrv1beta1.SolrCloud, statefulSet *appsv1.StatefulSet, logger logr.Logger) error {
+func (r *SolrCloudReconciler) reconcileStorageFinalizer(ctx context.Context, cloud *solrv1beta1.SolrCloud, statefulSet *appsv1.StatefulSet, statefulSets []*appsv1.StatefulSet, logger logr.Logger) (waitingForSnapshots bool, err error) {
 	// If persistentStorage is being used by the cloud, and the reclaim policy is set to "Delete",
 	// then set a finalizer for the storage on the cloud, and delete the PVCs if the solrcloud has been deleted.
 	pvcLabelSelector := statefulSet.Spec.Selector.MatchLabels
@@ -1031,36 +1616,40 @@ func (r *SolrCloudReconciler) reconcileStorageFinalizer(ctx context.Context, clo
 			// then lets add the finalizer and update the object
 			if !util.ContainsString(cloud.ObjectMeta.Finalizers, util.SolrStorageFinalizer) {
 				cloud.ObjectMeta.Finalizers = append(cloud.ObjectMeta.Finalizers, util.SolrStorageFinalizer)
-				if err := r.Update(ctx, cloud); err != nil {
-					return err
+				if err = r.Update(ctx, cloud); err != nil {
+					return false, err
 				}
 			}
-			return r.cleanupOrphanPVCs(ctx, cloud, statefulSet, pvcLabelSelector, logger)
+			if err = r.deleteExpiredDataVolumeSnapshots(ctx, cloud, logger); err != nil {
+				return false, err
+			}
+			return r.cleanupOrphanPVCs(ctx, cloud, statefulSets, pvcLabelSelector, logger)
 		} else if util.ContainsString(cloud.ObjectMeta.Finalizers, util.SolrStorageFinalizer) {
 			// The object is being deleted
 			logger.Info("Deleting PVCs for SolrCloud")
 
 			// Our finalizer is present, so let's delete all existing PVCs
-			if err := r.cleanUpAllPVCs(ctx, cloud, pvcLabelSelector, logger); err != nil {
-				return err
+			if waitingForSnapshots, err = r.cleanUpAllPVCs(ctx, cloud, pvcLabelSelector, logger); err != nil || waitingForSnapshots {
+				// Keep the finalizer until every PVC has been snapshotted and deleted
+				return waitingForSnapshots, err
 			}
 			logger.Info("Deleted PVCs for SolrCloud")
 
 			// remove our finalizer from the list and update it.
 			cloud.ObjectMeta.Finalizers = util.RemoveString(cloud.ObjectMeta.Finalizers, util.SolrStorageFinalizer)
-			if err := r.Update(ctx, cloud); err != nil {
-				return err
+			if err = r.Update(ctx, cloud); err != nil {
+				return false, err
 			}
 		}
 	} else if util.ContainsString(cloud.ObjectMeta.Finalizers, util.SolrStorageFinalizer) {
 		// remove our finalizer from the list and update it, because there is no longer a need to delete PVCs after the cloud is deleted.
 		logger.Info("Removing storage finalizer for SolrCloud")
 		cloud.ObjectMeta.Finalizers = util.RemoveString(cloud.ObjectMeta.Finalizers, util.SolrStorageFinalizer)
-		if err := r.Update(ctx, cloud); err != nil {
-			return err
+		if err = r.Update(ctx, cloud); err != nil {
+			return false, err
 		}
 	}
-	return nil
+	return false, nil
 }
 
 func (r *SolrCloudReconciler) getPVCCount(ctx context.Context, cloud *solrv1beta1.SolrCloud, pvcLabelSelector map[string]string) (pvcCount int, err error) {
@@ -1072,28 +1661,56 @@ func (r *SolrCloudReconciler) getPVCCount(ctx context.Context, cloud *solrv1beta
 	return pvcCount, nil
 }
 
-func (r *SolrCloudReconciler) cleanupOrphanPVCs(ctx context.Context, cloud *solrv1beta1.SolrCloud, statefulSet *appsv1.StatefulSet, pvcLabelSelector map[string]string, logger logr.Logger) (err error) {
+func (r *SolrCloudReconciler) cleanupOrphanPVCs(ctx context.Context, cloud *solrv1beta1.SolrCloud, statefulSets []*appsv1.StatefulSet, pvcLabelSelector map[string]string, logger logr.Logger) (waitingForSnapshots bool, err error) {
 	// this check should make sure we do not delete the PVCs before the STS has scaled down
 	if cloud.Status.ReadyReplicas == cloud.Status.Replicas {
+		// The PVCs of every node pool match the pvcLabelSelector, so the PVCs of each StatefulSet are listed by its node pool
+		pvcsWithStatefulSet := make(map[string]bool)
+		for _, statefulSet := range statefulSets {
+			if len(statefulSet.Spec.VolumeClaimTemplates) == 0 || statefulSet.Spec.Replicas == nil {
+				continue
+			}
+			poolPVCList, err := r.getNodePoolPVCList(ctx, cloud, statefulSet.Labels[util.SolrNodePoolLabel])
+			if err != nil {
+				return waitingForSnapshots, err
+			}
+			var statefulSetPVCs []corev1.PersistentVolumeClaim
+			for _, pvcItem := range poolPVCList.Items {
+				if util.IsPVCForStatefulSet(pvcItem.Name, statefulSet.Spec.VolumeClaimTemplates[0].Name, statefulSet.Name) {
+					statefulSetPVCs = append(statefulSetPVCs, pvcItem)
+					pvcsWithStatefulSet[pvcItem.Name] = true
+				}
+			}
+			// We only want to delete PVCs if we will not use them in the future, as in the user has asked for less replicas.
+			// Even if the statefulSet currently has less replicas, we don't want to delete them if we will eventually scale back up.
+			if len(statefulSetPVCs) > desiredNodePoolReplicas(cloud, statefulSet.Labels[util.SolrNodePoolLabel]) {
+				for _, pvcItem := range statefulSetPVCs {
+					// delete only Orphan PVCs
+					// for orphans, we will use the status replicas (which is derived from the statefulSet)
+					// Don't use the Spec replicas here, because we might be rolling down 1-by-1 and the PVCs for
+					// soon-to-be-deleted pods should not be deleted until the pod is deleted.
+					if util.IsPVCOrphan(pvcItem.Name, *statefulSet.Spec.Replicas) {
+						waitingForSnapshots = !r.deletePVC(ctx, cloud, pvcItem, logger) || waitingForSnapshots
+					}
+				}
+			}
+		}
+		// The StatefulSets of removed node pools are deleted once they have been scaled down, so their PVCs are left without a StatefulSet
 		pvcList, err := r.getPVCList(ctx, cloud, pvcLabelSelector)
 		if err != nil {
-			return err
+			return waitingForSnapshots, err
 		}
-		// We only want to delete PVCs if we will not use them in the future, as in the user has asked for less replicas.
-		// Even if the statefulSet currently has less replicas, we don't want to delete them if we will eventually scale back up.
-		if len(pvcList.Items) > int(*cloud.Spec.Replicas) {
-			for _, pvcItem := range pvcList.Items {
-				// delete only Orphan PVCs
-				// for orphans, we will use the status replicas (which is derived from the statefulSet)
-				// Don't use the Spec replicas here, because we might be rolling down 1-by-1 and the PVCs for
-				// soon-to-be-deleted pods should not be deleted until the pod is deleted.
-				if util.IsPVCOrphan(pvcItem.Name, *statefulSet.Spec.Replicas) {
-					r.deletePVC(ctx, pvcItem, logger)
-				}
+		nodePools := make(map[string]bool, len(cloud.Spec.NodePools))
+		for _, nodePool := range cloud.Spec.NodePools {
+			nodePools[nodePool.Name] = true
+		}
+		for _, pvcItem := range pvcList.Items {
+			if nodePool, hasNodePool := pvcItem.Labels[util.SolrNodePoolLabel]; hasNodePool && !nodePools[nodePool] && !pvcsWithStatefulSet[pvcItem.Name] {
+				waitingForSnapshots = !r.deletePVC(ctx, cloud, pvcItem, logger) || waitingForSnapshots
 			}
 		}
 	}
-	return nil
+	return waitingForSnapshots, nil
 }
 
 func (r *SolrCloudReconciler) getPVCList(ctx context.Context, cloud *solrv1beta1.SolrCloud, pvcLabelSelector map[string]string) (pvList corev1.PersistentVolumeClaimList, err error) {
@@ -1109,18 +1726,44 @@ func (r *SolrCloudReconciler) getPVCList(ctx context.Context, cloud *solrv1beta1
 	return *pvcList, err
 }
 
-func (r *SolrCloudReconciler) cleanUpAllPVCs(ctx context.Context, cloud *solrv1beta1.SolrCloud, pvcLabelSelector map[string]string, logger logr.Logger) (err error) {
+// getNodePoolPVCList lists the PVCs of a single node pool, since the PVCs of the default node pool do not have a node pool label
+func (r *SolrCloudReconciler) getNodePoolPVCList(ctx context.Context, cloud *solrv1beta1.SolrCloud, nodePool string) (pvcList corev1.PersistentVolumeClaimList, err error) {
+	selector, err := metav1.LabelSelectorAsSelector(util.NodePoolSelector(cloud, nodePool))
+	if err != nil {
+		return pvcList, err
+	}
+	err = r.Client.List(ctx, &pvcList, &client.ListOptions{
+		Namespace:     cloud.Namespace,
+		LabelSelector: selector,
+	})
+	return pvcList, err
+}
+
+func (r *SolrCloudReconciler) cleanUpAllPVCs(ctx context.Context, cloud *solrv1beta1.SolrCloud, pvcLabelSelector map[string]string, logger logr.Logger) (waitingForSnapshots bool, err error) {
 	pvcList, err := r.getPVCList(ctx, cloud, pvcLabelSelector)
 	if err != nil {
-		return err
+		return false, err
 	}
 	for _, pvcItem := range pvcList.Items {
-		r.deletePVC(ctx, pvcItem, logger)
+		waitingForSnapshots = !r.deletePVC(ctx, cloud, pvcItem, logger) || waitingForSnapshots
 	}
-	return nil
+	return waitingForSnapshots, nil
 }
 
-func (r *SolrCloudReconciler) deletePVC(ctx context.Context, pvcItem corev1.PersistentVolumeClaim, logger logr.Logger) {
+// deletePVC deletes the data PVC, after taking a VolumeSnapshot of it if the node pool of the PVC has deletionSnapshots enabled.
+// False is returned if the PVC was not deleted because its snapshot is not yet ready to use.
+func (r *SolrCloudReconciler) deletePVC(ctx context.Context, cloud *solrv1beta1.SolrCloud, pvcItem corev1.PersistentVolumeClaim, logger logr.Logger) (deleted bool) {
+	if pvcItem.DeletionTimestamp != nil {
+		return true
+	}
+	if snapshotOptions := util.DataVolumeSnapshotOptions(cloud, pvcItem.Labels[util.SolrNodePoolLabel]); snapshotOptions != nil {
+		if isReady, err := r.snapshotDataVolume(ctx, cloud, &pvcItem, snapshotOptions, logger); err != nil || !isReady {
+			if err != nil {
+				logger.Error(err, "Error taking VolumeSnapshot of PVC for SolrCloud, the PVC will not be deleted", "PVC", pvcItem.Name)
+			}
+			return false
+		}
+	}
 	pvcDelete := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      pvcItem.Name,
@@ -1132,6 +1775,115 @@ func (r *SolrCloudReconciler) deletePVC(ctx context.Context, pvcItem corev1.Pers
 	if err != nil {
 		logger.Error(err, "Error deleting PVC for SolrCloud", "PVC", pvcDelete.Name)
 	}
+	return true
+}
+
+// snapshotDataVolume takes a VolumeSnapshot of the data PVC, if one has not already been taken, and returns whether it is ready to use.
+// The PVC must not be deleted until its snapshot is ready to use.
+func (r *SolrCloudReconciler) snapshotDataVolume(ctx context.Context, cloud *solrv1beta1.SolrCloud, pvc *corev1.PersistentVolumeClaim, options *solrv1beta1.SolrDataVolumeSnapshotOptions, logger logr.Logger) (isReady bool, err error) {
+	snapshot := util.GenerateDataVolumeSnapshot(cloud, pvc, options, time.Now())
+	snapshotLogger := logger.WithValues("PVC", pvc.Name, "volumeSnapshot", snapshot.GetName())
+
+	foundSnapshot := &unstructured.Unstructured{}
+	foundSnapshot.SetGroupVersionKind(util.VolumeSnapshotGVK)
+	err = r.Get(ctx, types.NamespacedName{Name: snapshot.GetName(), Namespace: snapshot.GetNamespace()}, foundSnapshot)
+	if err != nil && errors.IsNotFound(err) {
+		snapshotLogger.Info("Creating VolumeSnapshot of PVC before deleting it")
+		if err = r.Create(ctx, snapshot); err != nil {
+			r.Recorder.Eventf(cloud, corev1.EventTypeWarning, util.EventReasonSnapshotDataVolumeFailed, "Could not create a VolumeSnapshot of PVC %s, so it will not be deleted: %s", pvc.Name, err.Error())
+		} else {
+			r.Recorder.Eventf(cloud, corev1.EventTypeNormal, util.EventReasonSnapshottingDataVolume, "Creating VolumeSnapshot %s of PVC %s before deleting the PVC", snapshot.GetName(), pvc.Name)
+		}
+		return false, err
+	} else if err != nil {
+		return false, err
+	}
+
+	isReady, errorMessage := util.GetVolumeSnapshotState(foundSnapshot)
+	if errorMessage != "" {
+		// The snapshot controller may still retry, so keep the PVC until the snapshot succeeds or the user intervenes
+		snapshotLogger.Info("VolumeSnapshot of PVC has failed, the PVC will not be deleted", "error", errorMessage)
+		r.Recorder.Eventf(cloud, corev1.EventTypeWarning, util.EventReasonSnapshotDataVolumeFailed, "VolumeSnapshot %s of PVC %s has failed, so the PVC will not be deleted: %s", foundSnapshot.GetName(), pvc.Name, errorMessage)
+	}
+	return isReady, nil
+}
+
+// listDataVolumeSnapshots returns the VolumeSnapshots that the Solr Operator has taken of the data PVCs of the SolrCloud.
+func (r *SolrCloudReconciler) listDataVolumeSnapshots(ctx context.Context, cloud *solrv1beta1.SolrCloud) ([]unstructured.Unstructured, error) {
+	snapshotList := &unstructured.UnstructuredList{}
+	snapshotList.SetGroupVersionKind(util.VolumeSnapshotGVK.GroupVersion().WithKind(util.VolumeSnapshotKind + "List"))
+	err := r.List(ctx, snapshotList, client.InNamespace(cloud.Namespace), client.MatchingLabels{
+		util.SolrPVCInstanceLabel:   cloud.Name,
+		util.SolrPVCTechnologyLabel: util.SolrCloudPVCTechnology,
+	}, client.HasLabels{util.SolrSnapshotPodLabel})
+	return snapshotList.Items, err
+}
+
+// deleteExpiredDataVolumeSnapshots deletes the VolumeSnapshots of data PVCs whose retention period has passed.
+// This is only done while a node pool of the SolrCloud has deletionSnapshots enabled.
+func (r *SolrCloudReconciler) deleteExpiredDataVolumeSnapshots(ctx context.Context, cloud *solrv1beta1.SolrCloud, logger logr.Logger) error {
+	snapshotsEnabled := false
+	for _, nodePool := range cloud.AllNodePools() {
+		snapshotsEnabled = snapshotsEnabled || util.DataVolumeSnapshotOptions(cloud, nodePool.Name) != nil
+	}
+	if !snapshotsEnabled {
+		return nil
+	}
+	snapshots, err := r.listDataVolumeSnapshots(ctx, cloud)
+	if meta.IsNoMatchError(err) {
+		// The VolumeSnapshot CRDs are not installed, so no snapshots can have been taken
+		return nil
+	} else if err != nil {
+		return err
+	}
+	now := time.Now()
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+		if snapshot.GetDeletionTimestamp() == nil && util.IsVolumeSnapshotExpired(snapshot, now) {
+			logger.Info("Deleting expired VolumeSnapshot of PVC for SolrCloud", "volumeSnapshot", snapshot.GetName())
+			if err = r.Delete(ctx, snapshot); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// restoreDataVolumesFromSnapshots creates the data PVCs of the pods that the StatefulSet is about to create, from the latest VolumeSnapshots taken of those pods' previous data PVCs.
+// This is only done for node pools that have deletionSnapshots with restoreOnRecreate enabled. Pods without a ready snapshot start with an empty data PVC.
+func (r *SolrCloudReconciler) restoreDataVolumesFromSnapshots(ctx context.Context, cloud *solrv1beta1.SolrCloud, statefulSet *appsv1.StatefulSet, scaleTo int, logger logr.Logger) error {
+	snapshotOptions := util.DataVolumeSnapshotOptions(cloud, statefulSet.Labels[util.SolrNodePoolLabel])
+	if snapshotOptions == nil || !snapshotOptions.RestoreOnRecreate || len(statefulSet.Spec.VolumeClaimTemplates) == 0 || statefulSet.Spec.Replicas == nil {
+		return nil
+	}
+	var snapshots []unstructured.Unstructured
+	now := time.Now()
+	for ordinal := int(*statefulSet.Spec.Replicas); ordinal < scaleTo; ordinal++ {
+		podName := fmt.Sprintf("%s-%d", statefulSet.Name, ordinal)
+		pvcName := util.DataVolumeClaimName(statefulSet, podName)
+		if err := r.Get(ctx, types.NamespacedName{Name: pvcName, Namespace: statefulSet.Namespace}, &corev1.PersistentVolumeClaim{}); err == nil {
+			// The pod will re-use its existing data PVC
+			continue
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+		if snapshots == nil {
+			var err error
+			if snapshots, err = r.listDataVolumeSnapshots(ctx, cloud); err != nil {
+				return err
+			}
+		}
+		snapshot := util.LatestReadyVolumeSnapshot(snapshots, podName, now)
+		if snapshot == nil {
+			continue
+		}
+		logger.Info("Restoring data PVC from VolumeSnapshot", "PVC", pvcName, "volumeSnapshot", snapshot.GetName())
+		if err := r.Create(ctx, util.GenerateRestoredDataVolumeClaim(statefulSet, podName, snapshot)); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+		r.Recorder.Eventf(cloud, corev1.EventTypeNormal, util.EventReasonRestoringDataVolume, "Restoring the data PVC %s of pod %s from VolumeSnapshot %s", pvcName, podName, snapshot.GetName())
+	}
+	return nil
 }
 
 // Ensure the TLS config is ready, such as verifying the TLS secret exists, to enable TLS on SolrCloud pods
@@ -1190,7 +1942,12 @@ func (r *SolrCloudReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.Service{}).
 		Owns(&corev1.Secret{}). /* for authentication */
 		Owns(&netv1.Ingress{}).
-		Owns(&policyv1.PodDisruptionBudget{})
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&netv1.NetworkPolicy{}).
+		Watches(
+			&solrv1beta1.SolrPrometheusExporter{},
+			handler.EnqueueRequestsFromMapFunc(findSolrCloudForPrometheusExporter))
 
 	var err error
 	ctrlBuilder, err = r.indexAndWatchForProvidedConfigMaps(mgr, ctrlBuilder)
@@ -1208,6 +1965,12 @@ func (r *SolrCloudReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if useGatewayAPI {
+		ctrlBuilder = ctrlBuilder.
+			Owns(&gatewayv1.HTTPRoute{}).
+			Owns(&gatewayv1alpha2.TLSRoute{})
+	}
+
 	if useZkCRD {
 		ctrlBuilder = ctrlBuilder.Owns(&zkApi.ZookeeperCluster{})
 	}
@@ -1215,6 +1978,19 @@ func (r *SolrCloudReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrlBuilder.Complete(r)
 }
 
+// findSolrCloudForPrometheusExporter reconciles the SolrCloud that a SolrPrometheusExporter references, since the exporter is allowed through the NetworkPolicy of the SolrCloud.
+func findSolrCloudForPrometheusExporter(ctx context.Context, obj client.Object) []reconcile.Request {
+	exporter, isExporter := obj.(*solrv1beta1.SolrPrometheusExporter)
+	if !isExporter || exporter.Spec.SolrReference.Cloud == nil || exporter.Spec.SolrReference.Cloud.Name == "" {
+		return nil
+	}
+	namespace := exporter.Spec.SolrReference.Cloud.Namespace
+	if namespace == "" {
+		namespace = exporter.Namespace
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: exporter.Spec.SolrReference.Cloud.Name, Namespace: namespace}}}
+}
+
 func (r *SolrCloudReconciler) indexAndWatchForProvidedConfigMaps(mgr ctrl.Manager, ctrlBuilder *builder.Builder) (*builder.Builder, error) {
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &solrv1beta1.SolrCloud{}, ".spec.customSolrKubeOptions.configMapOptions.providedConfigMap", func(rawObj client.Object) []string {
 		// grab the SolrCloud object, extract the used configMap...
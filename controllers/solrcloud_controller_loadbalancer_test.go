@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	solrv1beta1 "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = FDescribe("SolrCloud controller - LoadBalancer", func() {
+	var (
+		solrCloud *solrv1beta1.SolrCloud
+	)
+
+	replicas := 2
+	BeforeEach(func() {
+		int32Replicas := int32(replicas)
+		solrCloud = &solrv1beta1.SolrCloud{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "default",
+			},
+			Spec: solrv1beta1.SolrCloudSpec{
+				Replicas: &int32Replicas,
+				ZookeeperRef: &solrv1beta1.ZookeeperRef{
+					ConnectionInfo: &solrv1beta1.ZookeeperConnectionInfo{
+						InternalConnectionString: "host:7271",
+					},
+				},
+				SolrAddressability: solrv1beta1.SolrAddressabilityOptions{
+					External: &solrv1beta1.ExternalAddressability{
+						Method:             solrv1beta1.LoadBalancer,
+						UseExternalAddress: true,
+						DomainName:         testDomain,
+						LoadBalancer: &solrv1beta1.SolrLoadBalancerOptions{
+							LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+							CommonServiceAnnotations: map[string]string{"metallb.universe.tf/loadBalancerIPs": "10.1.0.1"},
+							NodeServiceAnnotations: map[string]map[string]string{
+								"foo-solrcloud-0": {"metallb.universe.tf/loadBalancerIPs": "10.1.0.2"},
+							},
+						},
+					},
+					PodPort:           3000,
+					CommonServicePort: 4000,
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func(ctx context.Context) {
+		By("creating the SolrCloud")
+		Expect(k8sClient.Create(ctx, solrCloud)).To(Succeed())
+
+		By("defaulting the missing SolrCloud values")
+		expectSolrCloudWithChecks(ctx, solrCloud, func(g Gomega, found *solrv1beta1.SolrCloud) {
+			g.Expect(found.WithDefaults(logger)).To(BeFalse(), "The SolrCloud spec should not need to be defaulted eventually")
+		})
+	})
+
+	AfterEach(func(ctx context.Context) {
+		cleanupTest(ctx, solrCloud)
+	})
+
+	FContext("Common and Node LoadBalancers", func() {
+		FIt("has the correct resources", func(ctx context.Context) {
+			By("testing the Solr StatefulSet")
+			statefulSet := expectStatefulSet(ctx, solrCloud, solrCloud.StatefulSetName())
+
+			Expect(statefulSet.Spec.Template.Spec.HostAliases).To(HaveLen(replicas), "Since external address is used for advertising, host aliases should be used for every node.")
+			expectedEnvVars := map[string]string{
+				"SOLR_HOST":           solrCloud.Namespace + "-$(POD_NAME)." + testDomain,
+				"SOLR_PORT":           "3000",
+				"SOLR_NODE_PORT":      "3000",
+				"SOLR_PORT_ADVERTISE": "3000",
+			}
+			testPodEnvVariables(expectedEnvVars, statefulSet.Spec.Template.Spec.Containers[0].Env)
+
+			By("testing the Solr Common Service")
+			commonService := expectService(ctx, solrCloud, solrCloud.CommonServiceName(), statefulSet.Spec.Selector.MatchLabels, false)
+			Expect(commonService.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer), "The common service should be a LoadBalancer")
+			Expect(commonService.Spec.LoadBalancerSourceRanges).To(Equal([]string{"10.0.0.0/8"}), "Wrong loadBalancerSourceRanges on the common Service")
+			Expect(commonService.Annotations).To(HaveKeyWithValue("metallb.universe.tf/loadBalancerIPs", "10.1.0.1"), "Wrong static IP annotation on the common Service")
+			Expect(commonService.Spec.Ports[0].Port).To(Equal(int32(4000)), "Wrong port on common Service")
+
+			By("ensuring the Solr Headless Service does not exist")
+			expectNoService(ctx, solrCloud, solrCloud.HeadlessServiceName(), "Headless service shouldn't exist, but it does.")
+
+			By("making sure the individual Solr Node Services are LoadBalancers")
+			for _, nodeName := range solrCloud.GetAllSolrPodNames() {
+				service := expectService(ctx, solrCloud, nodeName, util.MergeLabelsOrAnnotations(statefulSet.Spec.Selector.MatchLabels, map[string]string{"statefulset.kubernetes.io/pod-name": nodeName}), false)
+				Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer), "The node '"+nodeName+"' service should be a LoadBalancer")
+				Expect(service.Spec.LoadBalancerSourceRanges).To(Equal([]string{"10.0.0.0/8"}), "Wrong loadBalancerSourceRanges on the node '"+nodeName+"' Service")
+				Expect(service.Spec.Ports[0].Port).To(Equal(int32(3000)), "Wrong port on node Service")
+			}
+			nodeService := expectService(ctx, solrCloud, "foo-solrcloud-0", util.MergeLabelsOrAnnotations(statefulSet.Spec.Selector.MatchLabels, map[string]string{"statefulset.kubernetes.io/pod-name": "foo-solrcloud-0"}), false)
+			Expect(nodeService.Annotations).To(HaveKeyWithValue("metallb.universe.tf/loadBalancerIPs", "10.1.0.2"), "Wrong static IP annotation on the node Service")
+
+			By("ensuring no Ingress is created")
+			expectNoIngress(ctx, solrCloud, solrCloud.CommonIngressName())
+
+			By("making sure the addresses in the Status are correct")
+			expectSolrCloudStatusWithChecks(ctx, solrCloud, func(g Gomega, found *solrv1beta1.SolrCloudStatus) {
+				g.Expect(found.ExternalCommonAddress).To(Not(BeNil()), "External common address in status should not be nil")
+				g.Expect(*found.ExternalCommonAddress).To(Equal("http://"+solrCloud.Namespace+"-"+solrCloud.Name+"-solrcloud."+testDomain+":4000"), "Wrong external common address in status")
+			})
+		})
+	})
+})
@@ -19,6 +19,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	solrv1beta1 "github.com/apache/solr-operator/api/v1beta1"
 	"github.com/apache/solr-operator/controllers/util"
 	. "github.com/onsi/ginkgo/v2"
@@ -27,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = FDescribe("SolrCloud controller - Storage", func() {
@@ -315,4 +317,79 @@ var _ = FDescribe("SolrCloud controller - Storage", func() {
 			})
 		})
 	})
+
+	FContext("Persistent Data - Node Pools", func() {
+		BeforeEach(func() {
+			solrCloud.Spec.Replicas = pointer.Int32(2)
+			solrCloud.Spec.Scaling = solrv1beta1.SolrScalingOptions{
+				VacatePodsOnScaleDown: pointer.Bool(false),
+			}
+			solrCloud.Spec.NodePools = []solrv1beta1.SolrNodePool{
+				{Name: "search", Replicas: pointer.Int32(2)},
+			}
+			solrCloud.Spec.StorageOptions = solrv1beta1.SolrDataStorageOptions{
+				PersistentStorage: &solrv1beta1.SolrPersistentDataStorageOptions{
+					VolumeReclaimPolicy: solrv1beta1.VolumeReclaimPolicyDelete,
+					PersistentVolumeClaimTemplate: solrv1beta1.PersistentVolumeClaimTemplate{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.VolumeResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+							},
+						},
+					},
+				},
+			}
+		})
+		FIt("only removes the PVCs of the node pool that is scaled down", func(ctx context.Context) {
+			By("testing that each node pool has its own StatefulSet, whose selector only matches the pods of that node pool")
+			defaultStatefulSet := expectStatefulSetWithChecks(ctx, solrCloud, solrCloud.StatefulSetName(), func(g Gomega, found *appsv1.StatefulSet) {
+				g.Expect(found.Spec.Selector.MatchLabels).To(Not(HaveKey(util.SolrNodePoolLabel)), "The default node pool should not select by a node pool")
+				g.Expect(found.Spec.Selector.MatchExpressions).To(ContainElement(metav1.LabelSelectorRequirement{Key: util.SolrNodePoolLabel, Operator: metav1.LabelSelectorOpDoesNotExist}), "The default node pool should not select the pods of other node pools")
+			})
+			searchStatefulSet := expectStatefulSetWithChecks(ctx, solrCloud, solrCloud.NodePoolStatefulSetName("search"), func(g Gomega, found *appsv1.StatefulSet) {
+				g.Expect(found.Spec.Selector.MatchLabels).To(HaveKeyWithValue(util.SolrNodePoolLabel, "search"), "The node pool should select the pods of its node pool")
+				g.Expect(found.Spec.Template.Labels).To(HaveKeyWithValue(util.SolrNodePoolLabel, "search"), "The pods of the node pool should have the node pool label")
+			})
+
+			By("creating the PVCs of both node pools, as the StatefulSet controller would")
+			for _, statefulSet := range []*appsv1.StatefulSet{defaultStatefulSet, searchStatefulSet} {
+				for ordinal := 0; ordinal < 2; ordinal++ {
+					template := statefulSet.Spec.VolumeClaimTemplates[0]
+					pvc := &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fmt.Sprintf("%s-%s-%d", template.Name, statefulSet.Name, ordinal),
+							Namespace: solrCloud.Namespace,
+							Labels:    util.MergeLabelsOrAnnotations(template.Labels, statefulSet.Spec.Selector.MatchLabels),
+						},
+						Spec: template.Spec,
+					}
+					Expect(k8sClient.Create(ctx, pvc)).To(Succeed(), "Could not create PVC %s", pvc.Name)
+				}
+			}
+
+			By("scaling down only the search node pool")
+			foundSolrCloud := expectSolrCloud(ctx, solrCloud)
+			foundSolrCloud.Spec.NodePools[0].Replicas = pointer.Int32(1)
+			Expect(k8sClient.Update(ctx, foundSolrCloud)).To(Succeed(), "Could not scale down the search node pool")
+
+			expectStatefulSetWithChecks(ctx, solrCloud, searchStatefulSet.Name, func(g Gomega, found *appsv1.StatefulSet) {
+				g.Expect(found.Spec.Replicas).To(HaveValue(BeEquivalentTo(1)), "The search node pool should have been scaled down")
+			})
+			searchPVCPrefix := searchStatefulSet.Spec.VolumeClaimTemplates[0].Name + "-" + searchStatefulSet.Name
+			Eventually(func() error {
+				return k8sClient.Get(ctx, resourceKey(solrCloud, searchPVCPrefix+"-1"), &corev1.PersistentVolumeClaim{})
+			}).Should(MatchError(ContainSubstring("not found")), "The PVC of the removed pod of the search node pool should be deleted")
+
+			By("testing that the PVCs of the default node pool, and the remaining pod of the search node pool, are kept")
+			defaultPVCPrefix := defaultStatefulSet.Spec.VolumeClaimTemplates[0].Name + "-" + defaultStatefulSet.Name
+			Consistently(func(g Gomega) {
+				for _, pvcName := range []string{defaultPVCPrefix + "-0", defaultPVCPrefix + "-1", searchPVCPrefix + "-0"} {
+					g.Expect(k8sClient.Get(ctx, resourceKey(solrCloud, pvcName), &corev1.PersistentVolumeClaim{})).To(Succeed(), "PVC %s should not be deleted", pvcName)
+				}
+			}).Should(Succeed())
+			expectStatefulSetWithChecks(ctx, solrCloud, defaultStatefulSet.Name, func(g Gomega, found *appsv1.StatefulSet) {
+				g.Expect(found.Spec.Replicas).To(HaveValue(BeEquivalentTo(2)), "The default node pool should not have been scaled down")
+			})
+		})
+	})
 })
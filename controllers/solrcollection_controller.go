@@ -0,0 +1,278 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"k8s.io/apimachinery/pkg/fields"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"strings"
+	"time"
+
+	"github.com/apache/solr-operator/controllers/util"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	solrv1beta1 "github.com/apache/solr-operator/api/v1beta1"
+)
+
+const (
+	// How often to refresh the health of a collection, when nothing is being changed
+	collectionStatusRefreshInterval = time.Minute
+)
+
+// SolrCollectionReconciler reconciles a SolrCollection object
+type SolrCollectionReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrclouds,verbs=get;list;watch
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrclouds/status,verbs=get
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrcollections,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrcollections/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrcollections/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
+func (r *SolrCollectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the SolrCollection instance
+	collection := &solrv1beta1.SolrCollection{}
+	err := r.Get(ctx, req.NamespacedName, collection)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the req.
+		return reconcile.Result{}, err
+	}
+
+	changed := collection.WithDefaults()
+	if changed {
+		logger.Info("Setting default settings for solr-collection")
+		if err = r.Update(ctx, collection); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	unmodifiedCollectionResource := collection.DeepCopy()
+
+	requeueOrNot := reconcile.Result{}
+
+	actionTaken, err1 := r.reconcileSolrCollection(ctx, collection, logger)
+	if err1 != nil {
+		logger.Error(err1, "Error while reconciling Solr collection")
+
+		// Requeue after 10 seconds for errors.
+		updateRequeueAfter(&requeueOrNot, time.Second*10)
+	} else if actionTaken {
+		// Check back soon, to see if more changes are needed for the collection
+		updateRequeueAfter(&requeueOrNot, time.Second*5)
+	} else {
+		// Solr does not notify us of changes to the replicas, so the health of the collection needs to be checked periodically
+		updateRequeueAfter(&requeueOrNot, collectionStatusRefreshInterval)
+	}
+
+	if !reflect.DeepEqual(unmodifiedCollectionResource.Status, collection.Status) {
+		logger.Info("Updating status for solr-collection", "newStatus", collection.Status, "oldStatus", unmodifiedCollectionResource.Status)
+		err = r.Status().Patch(ctx, collection, client.MergeFrom(unmodifiedCollectionResource))
+	}
+
+	return requeueOrNot, err
+}
+
+func (r *SolrCollectionReconciler) reconcileSolrCollection(ctx context.Context, collection *solrv1beta1.SolrCollection, logger logr.Logger) (actionTaken bool, err error) {
+	// Get the solrCloud that this collection is for.
+	solrCloud := &solrv1beta1.SolrCloud{}
+
+	err = r.Get(ctx, types.NamespacedName{Namespace: collection.Namespace, Name: collection.Spec.SolrCloud}, solrCloud)
+	if err != nil && errors.IsNotFound(err) {
+		logger.Error(err, "Could not find cloud to create collection in", "solrCloud", collection.Spec.SolrCloud)
+		return false, err
+	} else if err != nil {
+		return false, err
+	}
+
+	if solrCloud.Status.ReadyReplicas == 0 {
+		return false, errors.NewServiceUnavailable(fmt.Sprintf("Cloud %s has no ready Solr nodes to manage the collection with", solrCloud.Name))
+	}
+
+	// The collection cannot be renamed in Solr, so do not manage a different collection than the one that was created
+	if collection.Status.Created && collection.Status.CollectionName != collection.Spec.CollectionName {
+		return false, fmt.Errorf("cannot change the name of collection %s to %s, once it has been created", collection.Status.CollectionName, collection.Spec.CollectionName)
+	}
+
+	// PULL replicas cannot become leaders, so a shard without NRT or TLOG replicas would lose all of its data
+	if !collection.Spec.HasLeaderEligibleReplicas() {
+		return false, fmt.Errorf("collection %s must have at least one NRT or TLOG replica per shard", collection.Spec.CollectionName)
+	}
+
+	// The shards and router of a collection cannot be changed in Solr, so refuse to manage a collection whose spec no longer matches them
+	if changedOptions := util.ChangedCollectionCreationOptions(collection); len(changedOptions) > 0 {
+		r.Recorder.Eventf(collection, corev1.EventTypeWarning, util.EventReasonCollectionCreationOptionChanged, "Cannot change %s of collection %s, once it has been created", strings.Join(changedOptions, ", "), collection.Status.CollectionName)
+		return false, fmt.Errorf("cannot change %s of collection %s, once it has been created", strings.Join(changedOptions, ", "), collection.Status.CollectionName)
+	}
+
+	// Add any additional values needed to Authn to Solr to the Context used when invoking the API
+	if solrCloud.Spec.SolrSecurity != nil {
+		ctx, err = util.AddAuthToContext(ctx, &r.Client, solrCloud)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	clusterStatus, err := util.GetSolrClusterStatus(ctx, solrCloud)
+	if err != nil {
+		return false, err
+	}
+
+	collectionState, exists := clusterStatus.Collections[collection.Spec.CollectionName]
+	if !exists && collection.Status.Created {
+		// Do not re-create a collection that has been deleted outside the operator, since it would come back without its data
+		if collection.Status.Health != solrv1beta1.CollectionMissing {
+			logger.Info("Collection no longer exists in Solr, it will not be re-created", "solrCloud", solrCloud.Name, "collection", collection.Spec.CollectionName)
+			r.Recorder.Eventf(collection, corev1.EventTypeWarning, util.EventReasonCollectionMissing, "Collection %s no longer exists in SolrCloud %s. Delete and re-create the SolrCollection to create the collection again", collection.Spec.CollectionName, solrCloud.Name)
+		}
+		collection.Status = solrv1beta1.SolrCollectionStatus{
+			CollectionName:  collection.Status.CollectionName,
+			Created:         true,
+			CreationOptions: collection.Status.CreationOptions,
+			ConfigSet:       collection.Status.ConfigSet,
+			Health:          solrv1beta1.CollectionMissing,
+			ReplicasReady:   "0/0",
+		}
+		return false, nil
+	} else if !exists {
+		collection.Status = solrv1beta1.SolrCollectionStatus{CollectionName: collection.Spec.CollectionName}
+		if err = util.CreateCollection(ctx, solrCloud, collection, logger); err != nil {
+			return true, err
+		}
+		collection.Status.Created = true
+		collection.Status.CreationOptions = collection.Spec.CreationOptions()
+		return true, nil
+	}
+
+	creationOptions := collection.Status.CreationOptions
+	if creationOptions == nil {
+		// The collection was created before its creation options were recorded, or outside the operator
+		creationOptions = collection.Spec.CreationOptions()
+	}
+	collection.Status = util.GenerateCollectionStatus(collection.Spec.CollectionName, &collectionState, clusterStatus.LiveNodes)
+	collection.Status.CreationOptions = creationOptions
+
+	changes := util.DetermineCollectionReplicaChanges(collection, &collectionState, clusterStatus.LiveNodes)
+	if changes.IsEmpty() {
+		return false, nil
+	}
+
+	// Create shards and replicas before deleting any, so that the collection does not lose availability
+	for _, shard := range changes.ShardsToCreate {
+		if err = util.CreateShardForCollection(ctx, solrCloud, collection, shard, logger); err != nil {
+			return true, err
+		}
+	}
+	for _, addition := range changes.ReplicasToAdd {
+		if err = util.AddReplicasToShard(ctx, solrCloud, collection.Spec.CollectionName, addition, logger); err != nil {
+			return true, err
+		}
+	}
+	if len(changes.ShardsToCreate) > 0 || len(changes.ReplicasToAdd) > 0 {
+		return true, nil
+	}
+	for _, deletion := range changes.ReplicasToDelete {
+		if err = util.DeleteReplicaFromShard(ctx, solrCloud, collection.Spec.CollectionName, deletion, logger); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SolrCollectionReconciler) SetupWithManager(mgr ctrl.Manager) (err error) {
+	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&solrv1beta1.SolrCollection{})
+
+	ctrlBuilder, err = r.indexAndWatchForSolrClouds(mgr, ctrlBuilder)
+	if err != nil {
+		return err
+	}
+
+	return ctrlBuilder.Complete(r)
+}
+
+func (r *SolrCollectionReconciler) indexAndWatchForSolrClouds(mgr ctrl.Manager, ctrlBuilder *builder.Builder) (*builder.Builder, error) {
+	solrCloudField := ".spec.solrCloud"
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &solrv1beta1.SolrCollection{}, solrCloudField, func(rawObj client.Object) []string {
+		// grab the SolrCollection object, extract the used SolrCloud...
+		return []string{rawObj.(*solrv1beta1.SolrCollection).Spec.SolrCloud}
+	}); err != nil {
+		return ctrlBuilder, err
+	}
+
+	return ctrlBuilder.Watches(
+		&solrv1beta1.SolrCloud{},
+		handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			foundCollections := &solrv1beta1.SolrCollectionList{}
+			listOps := &client.ListOptions{
+				FieldSelector: fields.OneTermEqualSelector(solrCloudField, obj.GetName()),
+				Namespace:     obj.GetNamespace(),
+			}
+			err := r.List(ctx, foundCollections, listOps)
+			if err != nil {
+				// if no collections found, just no-op this
+				return []reconcile.Request{}
+			}
+
+			requests := make([]reconcile.Request, len(foundCollections.Items))
+			for i, item := range foundCollections.Items {
+				requests[i] = reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      item.GetName(),
+						Namespace: item.GetNamespace(),
+					},
+				}
+			}
+			return requests
+		}),
+		builder.WithPredicates(predicate.GenerationChangedPredicate{})), nil
+}
@@ -0,0 +1,351 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"time"
+
+	"github.com/apache/solr-operator/controllers/util"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	solrv1beta1 "github.com/apache/solr-operator/api/v1beta1"
+)
+
+const (
+	// How often to check that an uploaded configset still exists in Solr, when nothing is being changed
+	configSetExistenceCheckInterval = 5 * time.Minute
+)
+
+// SolrConfigSetReconciler reconciles a SolrConfigSet object
+type SolrConfigSetReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrclouds,verbs=get;list;watch
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrclouds/status,verbs=get
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrconfigsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrconfigsets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrconfigsets/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
+func (r *SolrConfigSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the SolrConfigSet instance
+	configSet := &solrv1beta1.SolrConfigSet{}
+	err := r.Get(ctx, req.NamespacedName, configSet)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the req.
+		return reconcile.Result{}, err
+	}
+
+	changed := configSet.WithDefaults()
+	if changed {
+		logger.Info("Setting default settings for solr-configset")
+		if err = r.Update(ctx, configSet); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	unmodifiedConfigSetResource := configSet.DeepCopy()
+
+	requeueOrNot := reconcile.Result{}
+
+	if err1 := r.reconcileSolrConfigSet(ctx, configSet, logger); err1 != nil {
+		logger.Error(err1, "Error while reconciling Solr configset")
+
+		// Requeue after 10 seconds for errors.
+		updateRequeueAfter(&requeueOrNot, time.Second*10)
+	} else if configSet.Status.Uploaded {
+		// The configset can be deleted in Solr without the operator being notified, so check for it periodically
+		updateRequeueAfter(&requeueOrNot, configSetExistenceCheckInterval)
+	}
+
+	if !reflect.DeepEqual(unmodifiedConfigSetResource.Status, configSet.Status) {
+		logger.Info("Updating status for solr-configset", "newStatus", configSet.Status, "oldStatus", unmodifiedConfigSetResource.Status)
+		err = r.Status().Patch(ctx, configSet, client.MergeFrom(unmodifiedConfigSetResource))
+	}
+
+	return requeueOrNot, err
+}
+
+func (r *SolrConfigSetReconciler) reconcileSolrConfigSet(ctx context.Context, configSet *solrv1beta1.SolrConfigSet, logger logr.Logger) (err error) {
+	// The configset cannot be renamed in Solr, so do not manage a different configset than the one that was uploaded
+	if configSet.Status.Uploaded && configSet.Status.ConfigSetName != configSet.Spec.ConfigSetName {
+		return fmt.Errorf("cannot change the name of configset %s to %s, once it has been uploaded", configSet.Status.ConfigSetName, configSet.Spec.ConfigSetName)
+	}
+
+	zippedConfigSet, err := r.zipConfigSet(ctx, configSet)
+	if err != nil {
+		return err
+	}
+	contentHash := util.ConfigSetContentHash(zippedConfigSet)
+	upToDate := contentHash == configSet.Status.ContentHash && len(configSet.Status.CollectionsPendingReload) == 0
+
+	// Get the solrCloud that this configset is for.
+	solrCloud := &solrv1beta1.SolrCloud{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: configSet.Namespace, Name: configSet.Spec.SolrCloud}, solrCloud)
+	if err != nil && errors.IsNotFound(err) {
+		logger.Error(err, "Could not find cloud to upload configset to", "solrCloud", configSet.Spec.SolrCloud)
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	if solrCloud.Status.ReadyReplicas == 0 {
+		if upToDate {
+			// The configset cannot be checked until the cloud is ready, but there is nothing to upload
+			return nil
+		}
+		return errors.NewServiceUnavailable(fmt.Sprintf("Cloud %s has no ready Solr nodes to upload the configset to", solrCloud.Name))
+	}
+
+	// Add any additional values needed to Authn to Solr to the Context used when invoking the API
+	if solrCloud.Spec.SolrSecurity != nil {
+		ctx, err = util.AddAuthToContext(ctx, &r.Client, solrCloud)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The configset may have been deleted in Solr since it was uploaded, in which case it needs to be uploaded again
+	needsUpload := contentHash != configSet.Status.ContentHash
+	if !needsUpload {
+		exists, err := util.ConfigSetExists(ctx, solrCloud, configSet.Spec.ConfigSetName)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			logger.Info("Configset no longer exists in Solr, it will be uploaded again", "solrCloud", solrCloud.Name, "configSet", configSet.Spec.ConfigSetName)
+			needsUpload = true
+		}
+	}
+
+	if needsUpload {
+		if err = util.UploadConfigSet(ctx, solrCloud, configSet.Spec.ConfigSetName, zippedConfigSet, logger); err != nil {
+			r.Recorder.Eventf(configSet, corev1.EventTypeWarning, util.EventReasonConfigSetUploadFailed, "Could not upload configset %s to SolrCloud %s: %s", configSet.Spec.ConfigSetName, solrCloud.Name, err.Error())
+			return err
+		}
+		// Collections only need to be reloaded if they were using a previous version of the configset
+		previouslyUploaded := configSet.Status.Uploaded
+
+		now := metav1.Now()
+		configSet.Status.ConfigSetName = configSet.Spec.ConfigSetName
+		configSet.Status.Uploaded = true
+		configSet.Status.ContentHash = contentHash
+		configSet.Status.LastUploadTime = &now
+
+		if previouslyUploaded && configSet.Spec.ReloadCollectionsOnChange {
+			clusterStatus, err := util.GetSolrClusterStatus(ctx, solrCloud)
+			if err != nil {
+				return err
+			}
+			configSet.Status.CollectionsPendingReload = util.CollectionsUsingConfigSet(clusterStatus, configSet.Spec.ConfigSetName)
+		}
+	}
+
+	// Reload the collections, keeping track of the ones that still need to be reloaded if there is a failure
+	var collectionsPendingReload []string
+	for _, collection := range configSet.Status.CollectionsPendingReload {
+		if reloadErr := util.ReloadCollection(ctx, solrCloud, collection, logger); reloadErr != nil {
+			r.Recorder.Eventf(configSet, corev1.EventTypeWarning, util.EventReasonCollectionReloadFailed, "Could not reload collection %s with the updated configset %s: %s", collection, configSet.Spec.ConfigSetName, reloadErr.Error())
+			err = reloadErr
+			collectionsPendingReload = append(collectionsPendingReload, collection)
+		}
+	}
+	configSet.Status.CollectionsPendingReload = collectionsPendingReload
+
+	return err
+}
+
+// zipConfigSet builds the zip of the configset to upload, from either the referenced ConfigMaps or Secret
+func (r *SolrConfigSetReconciler) zipConfigSet(ctx context.Context, configSet *solrv1beta1.SolrConfigSet) (zippedConfigSet []byte, err error) {
+	if configSet.Spec.ZipSecret != nil && len(configSet.Spec.ConfigMaps) > 0 {
+		return nil, fmt.Errorf("configset %s must provide either configMaps or a zipSecret, not both", configSet.Name)
+	}
+
+	if configSet.Spec.ZipSecret != nil {
+		zipSecret := &corev1.Secret{}
+		if err = r.Get(ctx, types.NamespacedName{Namespace: configSet.Namespace, Name: configSet.Spec.ZipSecret.Name}, zipSecret); err != nil {
+			return nil, err
+		}
+		var hasZip bool
+		if zippedConfigSet, hasZip = zipSecret.Data[configSet.Spec.ZipSecret.Key]; !hasZip {
+			return nil, fmt.Errorf("required key '%s' not found in the configset zip secret %s", configSet.Spec.ZipSecret.Key, zipSecret.Name)
+		}
+		return zippedConfigSet, nil
+	}
+
+	if len(configSet.Spec.ConfigMaps) == 0 {
+		return nil, fmt.Errorf("configset %s must provide either configMaps or a zipSecret", configSet.Name)
+	}
+
+	configMaps := make(map[string]*corev1.ConfigMap, len(configSet.Spec.ConfigMaps))
+	for _, source := range configSet.Spec.ConfigMaps {
+		configMap := &corev1.ConfigMap{}
+		if err = r.Get(ctx, types.NamespacedName{Namespace: configSet.Namespace, Name: source.Name}, configMap); err != nil {
+			return nil, err
+		}
+		configMaps[source.Name] = configMap
+	}
+	files, err := util.ConfigSetFilesFromConfigMaps(configSet, configMaps)
+	if err != nil {
+		return nil, err
+	}
+	return util.ZipConfigSetFiles(files)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SolrConfigSetReconciler) SetupWithManager(mgr ctrl.Manager) (err error) {
+	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&solrv1beta1.SolrConfigSet{})
+
+	ctrlBuilder, err = r.indexAndWatchForProvidedConfigMaps(mgr, ctrlBuilder)
+	if err != nil {
+		return err
+	}
+
+	ctrlBuilder, err = r.indexAndWatchForZipSecret(mgr, ctrlBuilder)
+	if err != nil {
+		return err
+	}
+
+	ctrlBuilder, err = r.indexAndWatchForSolrClouds(mgr, ctrlBuilder)
+	if err != nil {
+		return err
+	}
+
+	return ctrlBuilder.Complete(r)
+}
+
+func (r *SolrConfigSetReconciler) indexAndWatchForProvidedConfigMaps(mgr ctrl.Manager, ctrlBuilder *builder.Builder) (*builder.Builder, error) {
+	field := ".spec.configMaps"
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &solrv1beta1.SolrConfigSet{}, field, func(rawObj client.Object) []string {
+		// grab the SolrConfigSet object, extract the used configMaps...
+		configSet := rawObj.(*solrv1beta1.SolrConfigSet)
+		if len(configSet.Spec.ConfigMaps) == 0 {
+			return nil
+		}
+		// ...and if so, return them
+		configMapNames := make([]string, len(configSet.Spec.ConfigMaps))
+		for i, source := range configSet.Spec.ConfigMaps {
+			configMapNames[i] = source.Name
+		}
+		return configMapNames
+	}); err != nil {
+		return ctrlBuilder, err
+	}
+
+	return ctrlBuilder.Watches(
+		&corev1.ConfigMap{},
+		r.findSolrConfigSetByFieldValueFunc(field),
+		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})), nil
+}
+
+func (r *SolrConfigSetReconciler) indexAndWatchForZipSecret(mgr ctrl.Manager, ctrlBuilder *builder.Builder) (*builder.Builder, error) {
+	field := ".spec.zipSecret"
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &solrv1beta1.SolrConfigSet{}, field, func(rawObj client.Object) []string {
+		// grab the SolrConfigSet object, extract the used secret...
+		configSet := rawObj.(*solrv1beta1.SolrConfigSet)
+		if configSet.Spec.ZipSecret == nil {
+			return nil
+		}
+		// ...and if so, return it
+		return []string{configSet.Spec.ZipSecret.Name}
+	}); err != nil {
+		return ctrlBuilder, err
+	}
+
+	return ctrlBuilder.Watches(
+		&corev1.Secret{},
+		r.findSolrConfigSetByFieldValueFunc(field),
+		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})), nil
+}
+
+func (r *SolrConfigSetReconciler) indexAndWatchForSolrClouds(mgr ctrl.Manager, ctrlBuilder *builder.Builder) (*builder.Builder, error) {
+	field := ".spec.solrCloud"
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &solrv1beta1.SolrConfigSet{}, field, func(rawObj client.Object) []string {
+		// grab the SolrConfigSet object, extract the used SolrCloud...
+		return []string{rawObj.(*solrv1beta1.SolrConfigSet).Spec.SolrCloud}
+	}); err != nil {
+		return ctrlBuilder, err
+	}
+
+	return ctrlBuilder.Watches(
+		&solrv1beta1.SolrCloud{},
+		r.findSolrConfigSetByFieldValueFunc(field),
+		builder.WithPredicates(predicate.GenerationChangedPredicate{})), nil
+}
+
+func (r *SolrConfigSetReconciler) findSolrConfigSetByFieldValueFunc(field string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(
+		func(ctx context.Context, obj client.Object) []reconcile.Request {
+			foundConfigSets := &solrv1beta1.SolrConfigSetList{}
+			listOps := &client.ListOptions{
+				FieldSelector: fields.OneTermEqualSelector(field, obj.GetName()),
+				Namespace:     obj.GetNamespace(),
+			}
+			err := r.List(ctx, foundConfigSets, listOps)
+			if err != nil {
+				return []reconcile.Request{}
+			}
+
+			requests := make([]reconcile.Request, len(foundConfigSets.Items))
+			for i, item := range foundConfigSets.Items {
+				requests[i] = reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      item.GetName(),
+						Namespace: item.GetNamespace(),
+					},
+				}
+			}
+			return requests
+		})
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,10 +44,12 @@ import (
 // SolrPrometheusExporterReconciler reconciles a SolrPrometheusExporter object
 type SolrPrometheusExporterReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=,resources=configmaps/status,verbs=get
 //+kubebuilder:rbac:groups=,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=,resources=services/status,verbs=get
@@ -200,6 +203,7 @@ func (r *SolrPrometheusExporterReconciler) Reconcile(ctx context.Context, req ct
 
 		err = util.ValidateBasicAuthSecret(basicAuthSecret)
 		if err != nil {
+			r.Recorder.Event(prometheusExporter, corev1.EventTypeWarning, util.EventReasonInvalidConfiguration, err.Error())
 			return reconcile.Result{}, err
 		}
 		creds := fmt.Sprintf("%s:%s", basicAuthSecret.Data[corev1.BasicAuthUsernameKey], basicAuthSecret.Data[corev1.BasicAuthPasswordKey])
@@ -217,6 +221,7 @@ func (r *SolrPrometheusExporterReconciler) Reconcile(ctx context.Context, req ct
 	// Set the annotation for a scheduled restart, if necessary.
 	if nextRestartAnnotation, reconcileWaitDuration, err := util.ScheduleNextRestart(prometheusExporter.Spec.RestartSchedule, foundDeploy.Spec.Template.Annotations); err != nil {
 		logger.Error(err, "Cannot parse restartSchedule cron", "cron", prometheusExporter.Spec.RestartSchedule)
+		r.Recorder.Eventf(prometheusExporter, corev1.EventTypeWarning, util.EventReasonInvalidConfiguration, "Cannot parse restartSchedule cron %q: %s", prometheusExporter.Spec.RestartSchedule, err.Error())
 	} else {
 		if nextRestartAnnotation != "" {
 			if deploy.Spec.Template.Annotations == nil {
@@ -224,7 +229,7 @@ func (r *SolrPrometheusExporterReconciler) Reconcile(ctx context.Context, req ct
 			}
 			// Set the new restart time annotation
 			deploy.Spec.Template.Annotations[util.SolrScheduledRestartAnnotation] = nextRestartAnnotation
-			// TODO: Create event for the CRD.
+			r.Recorder.Eventf(prometheusExporter, corev1.EventTypeNormal, util.EventReasonScheduledRestart, "Scheduled the next restart of the Prometheus Exporter for %s", nextRestartAnnotation)
 		} else if existingRestartAnnotation, exists := foundDeploy.Spec.Template.Annotations[util.SolrScheduledRestartAnnotation]; exists {
 			if deploy.Spec.Template.Annotations == nil {
 				deploy.Spec.Template.Annotations = make(map[string]string, 1)
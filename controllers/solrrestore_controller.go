@@ -0,0 +1,339 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"k8s.io/apimachinery/pkg/fields"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"time"
+
+	"github.com/apache/solr-operator/controllers/util"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	solrv1beta1 "github.com/apache/solr-operator/api/v1beta1"
+)
+
+// SolrRestoreReconciler reconciles a SolrRestore object
+type SolrRestoreReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrclouds,verbs=get;list;watch
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrclouds/status,verbs=get
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrbackups,verbs=get;list;watch
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrrestores,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrrestores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=solr.apache.org,resources=solrrestores/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
+func (r *SolrRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the SolrRestore instance
+	restore := &solrv1beta1.SolrRestore{}
+	err := r.Get(ctx, req.NamespacedName, restore)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the req.
+		return reconcile.Result{}, err
+	}
+
+	// A restore is only ever done once, there is nothing to do after it has finished
+	if restore.Status.Finished {
+		return reconcile.Result{}, nil
+	}
+
+	unmodifiedRestoreResource := restore.DeepCopy()
+
+	requeueOrNot := reconcile.Result{}
+
+	solrCloud, err1 := r.reconcileSolrCloudRestore(ctx, restore, logger)
+	if err1 != nil {
+		logger.Error(err1, "Error while restoring SolrCloud collections")
+		r.Recorder.Eventf(restore, corev1.EventTypeWarning, util.EventReasonRestoreError, "Error while restoring collections: %s", err1.Error())
+
+		// Requeue after 10 seconds for errors.
+		updateRequeueAfter(&requeueOrNot, time.Second*10)
+	} else if restore.Status.Finished {
+		// Set finish time
+		now := metav1.Now()
+		restore.Status.FinishTime = &now
+		if restore.Status.Successful != nil && *restore.Status.Successful {
+			r.Recorder.Event(restore, corev1.EventTypeNormal, util.EventReasonRestoreFinished, "Successfully restored all collections")
+		} else {
+			r.Recorder.Event(restore, corev1.EventTypeWarning, util.EventReasonRestoreFailed, "Restore finished, but not all collections were restored successfully")
+		}
+	} else if solrCloud != nil {
+		// When working with the collection restores, auto-requeue after 5 seconds
+		// to check on the status of the async solr restore calls
+		updateRequeueAfter(&requeueOrNot, time.Second*5)
+	}
+
+	if !reflect.DeepEqual(unmodifiedRestoreResource.Status, restore.Status) {
+		logger.Info("Updating status for solr-restore", "newStatus", restore.Status, "oldStatus", unmodifiedRestoreResource.Status)
+		err = r.Status().Patch(ctx, restore, client.MergeFrom(unmodifiedRestoreResource))
+	}
+
+	return requeueOrNot, err
+}
+
+func (r *SolrRestoreReconciler) reconcileSolrCloudRestore(ctx context.Context, restore *solrv1beta1.SolrRestore, logger logr.Logger) (solrCloud *solrv1beta1.SolrCloud, err error) {
+	// Get the solrCloud that this restore is for.
+	solrCloud = &solrv1beta1.SolrCloud{}
+
+	err = r.Get(ctx, types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.SolrCloud}, solrCloud)
+	if err != nil && errors.IsNotFound(err) {
+		logger.Error(err, "Could not find cloud to restore into", "solrCloud", restore.Spec.SolrCloud)
+		return nil, err
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Add any additional values needed to Authn to Solr to the Context used when invoking the API
+	if solrCloud.Spec.SolrSecurity != nil {
+		ctx, err = util.AddAuthToContext(ctx, &r.Client, solrCloud)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// First check if the collection restores have been completed
+	collectionRestoresFinished := util.UpdateStatusOfCollectionRestores(&restore.Status)
+
+	// If the collectionRestores are complete, then nothing else has to be done here
+	if collectionRestoresFinished {
+		return solrCloud, nil
+	}
+
+	// Resolve where the backup lives, using the SolrBackup if one is referenced
+	var solrBackup *solrv1beta1.SolrBackup
+	repositoryName := restore.Spec.RepositoryName
+	location := restore.Spec.Location
+	backupName := restore.Spec.BackupName
+	if restore.Spec.SolrBackup != "" {
+		solrBackup = &solrv1beta1.SolrBackup{}
+		if err = r.Get(ctx, types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.SolrBackup}, solrBackup); err != nil {
+			logger.Error(err, "Could not find backup to restore from", "solrBackup", restore.Spec.SolrBackup)
+			return solrCloud, err
+		}
+		if repositoryName == "" {
+			repositoryName = solrBackup.Spec.RepositoryName
+		}
+		if location == "" {
+			location = solrBackup.Spec.Location
+		}
+		if backupName == "" {
+			backupName = solrBackup.Name
+		}
+	} else if backupName == "" {
+		return solrCloud, fmt.Errorf("restore [%s] must specify either a solrBackup or a backupName to restore from", restore.Name)
+	}
+
+	backupRepository := util.GetBackupRepositoryByName(solrCloud.Spec.BackupRepositories, repositoryName)
+	if backupRepository == nil {
+		err = fmt.Errorf("Unable to find backup repository to use for restore [%s] (which specified the repository"+
+			" [%s]).  solrcloud must define a repository matching that name (or have only 1 repository defined).",
+			restore.Name, repositoryName)
+		return solrCloud, err
+	}
+
+	collectionsToRestore, err := util.CollectionsToRestore(restore, solrBackup)
+	if err != nil {
+		return solrCloud, err
+	}
+
+	// This should only occur before the restore processes have been started
+	if restore.Status.StartTime.IsZero() {
+		// Make sure that all solr living Solr pods have the backupRepo configured
+		if !solrCloud.Status.BackupRepositoriesAvailable[backupRepository.Name] {
+			logger.Info("Cloud not ready for restore", "solrCloud", solrCloud.Name, "repository", backupRepository.Name)
+			return solrCloud, errors.NewServiceUnavailable(fmt.Sprintf("Cloud is not ready for restores in the %s repository", backupRepository.Name))
+		}
+
+		// Only set the solr version at the start of the restore. This shouldn't change throughout the restore.
+		restore.Status.SolrVersion = solrCloud.Status.Version
+		restore.Status.StartTime = metav1.Now()
+		r.Recorder.Eventf(restore, corev1.EventTypeNormal, util.EventReasonRestoreStarted, "Starting restore into SolrCloud %s from the %s repository", solrCloud.Name, backupRepository.Name)
+	}
+
+	// Go through each collection specified and reconcile the restore.
+	for i := range collectionsToRestore {
+		// This will in-place update the CollectionRestoreStatus in the restore object
+		if _, err = r.reconcileSolrCollectionRestore(ctx, restore, solrCloud, backupRepository, location, backupName, &collectionsToRestore[i], logger); err != nil {
+			break
+		}
+	}
+
+	// First check if the collection restores have been completed
+	util.UpdateStatusOfCollectionRestores(&restore.Status)
+
+	return solrCloud, err
+}
+
+func (r *SolrRestoreReconciler) reconcileSolrCollectionRestore(ctx context.Context, restore *solrv1beta1.SolrRestore, solrCloud *solrv1beta1.SolrCloud, backupRepository *solrv1beta1.SolrBackupRepository, location string, backupName string, collection *solrv1beta1.RestoreCollection, logger logr.Logger) (finished bool, err error) {
+	now := metav1.Now()
+	collectionRestoreStatus := solrv1beta1.CollectionRestoreStatus{}
+	collectionRestoreStatus.Collection = collection.Name
+	collectionRestoreStatus.RestoredAs = collection.TargetCollection()
+	restoreIndex := -1
+	// Get the restore status for this collection, if one exists
+	for i, status := range restore.Status.CollectionRestoreStatuses {
+		if status.RestoredAs == collection.TargetCollection() {
+			collectionRestoreStatus = status
+			restoreIndex = i
+		}
+	}
+
+	// If the collection restore hasn't started, start it
+	if collectionRestoreStatus.Finished {
+		return true, nil
+	} else if !collectionRestoreStatus.InProgress {
+		// Start the restore by calling solr.
+		// The status is still recorded on an error, so that the restore is not seen as finished before this collection is retried.
+		var started bool
+		started, err = util.StartRestoreForCollection(ctx, solrCloud, backupRepository, restore, location, backupName, collection, logger)
+		collectionRestoreStatus.InProgress = started
+		if err != nil {
+			r.Recorder.Eventf(restore, corev1.EventTypeWarning, util.EventReasonCollectionRestoreFailed, "Could not start the restore of collection %s: %s", collection.TargetCollection(), err.Error())
+		}
+		if started && collectionRestoreStatus.StartTime == nil {
+			collectionRestoreStatus.StartTime = &now
+		}
+		collectionRestoreStatus.BackupName = util.CollectionBackupNameForRestore(collection, backupName)
+	} else if collectionRestoreStatus.InProgress {
+		var successful bool
+		var asyncStatus string
+		// Check the state of the restore, when it is in progress, and update the state accordingly
+		finished, successful, asyncStatus, err = util.CheckRestoreForCollection(ctx, solrCloud, collection.TargetCollection(), restore.Name, logger)
+		if err != nil {
+			return false, err
+		}
+		collectionRestoreStatus.Finished = finished
+		if finished {
+			collectionRestoreStatus.InProgress = false
+			if collectionRestoreStatus.Successful == nil {
+				collectionRestoreStatus.Successful = &successful
+				if !successful {
+					r.Recorder.Eventf(restore, corev1.EventTypeWarning, util.EventReasonCollectionRestoreFailed, "Restore of collection %s failed with async status %q", collection.TargetCollection(), asyncStatus)
+				}
+			}
+			collectionRestoreStatus.AsyncRestoreStatus = ""
+			if collectionRestoreStatus.FinishTime == nil {
+				collectionRestoreStatus.FinishTime = &now
+			}
+
+			err = util.DeleteAsyncInfoForRestore(ctx, solrCloud, collection.TargetCollection(), restore.Name, logger)
+		} else {
+			collectionRestoreStatus.AsyncRestoreStatus = asyncStatus
+		}
+	}
+
+	if restoreIndex < 0 {
+		restore.Status.CollectionRestoreStatuses = append(restore.Status.CollectionRestoreStatuses, collectionRestoreStatus)
+	} else {
+		restore.Status.CollectionRestoreStatuses[restoreIndex] = collectionRestoreStatus
+	}
+
+	return collectionRestoreStatus.Finished, err
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SolrRestoreReconciler) SetupWithManager(mgr ctrl.Manager) (err error) {
+	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&solrv1beta1.SolrRestore{})
+
+	ctrlBuilder, err = r.indexAndWatchForSolrClouds(mgr, ctrlBuilder)
+	if err != nil {
+		return err
+	}
+
+	return ctrlBuilder.Complete(r)
+}
+
+func (r *SolrRestoreReconciler) indexAndWatchForSolrClouds(mgr ctrl.Manager, ctrlBuilder *builder.Builder) (*builder.Builder, error) {
+	solrCloudField := ".spec.solrCloud"
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &solrv1beta1.SolrRestore{}, solrCloudField, func(rawObj client.Object) []string {
+		// grab the SolrRestore object, extract the used SolrCloud...
+		return []string{rawObj.(*solrv1beta1.SolrRestore).Spec.SolrCloud}
+	}); err != nil {
+		return ctrlBuilder, err
+	}
+
+	return ctrlBuilder.Watches(
+		&solrv1beta1.SolrCloud{},
+		handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			solrCloud := obj.(*solrv1beta1.SolrCloud)
+			foundRestores := &solrv1beta1.SolrRestoreList{}
+			listOps := &client.ListOptions{
+				FieldSelector: fields.OneTermEqualSelector(solrCloudField, obj.GetName()),
+				Namespace:     obj.GetNamespace(),
+			}
+			err := r.List(ctx, foundRestores, listOps)
+			if err != nil {
+				// if no restores found, just no-op this
+				return []reconcile.Request{}
+			}
+
+			requests := make([]reconcile.Request, 0)
+			for _, item := range foundRestores.Items {
+				// Only queue the request if the Cloud is ready, and the restore has not already finished.
+				cloudIsReady := solrCloud.Status.BackupRestoreReady
+				if item.Spec.RepositoryName != "" {
+					cloudIsReady = solrCloud.Status.BackupRepositoriesAvailable[item.Spec.RepositoryName]
+				}
+				if cloudIsReady && !item.Status.Finished {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: types.NamespacedName{
+							Name:      item.GetName(),
+							Namespace: item.GetNamespace(),
+						},
+					})
+				}
+			}
+			return requests
+		}),
+		builder.WithPredicates(predicate.GenerationChangedPredicate{})), nil
+}
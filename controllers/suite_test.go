@@ -106,18 +106,39 @@ var _ = BeforeSuite(func(ctx context.Context) {
 	// Start up Reconcilers
 	By("starting the reconcilers")
 	Expect((&SolrCloudReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: k8sManager.GetScheme(),
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("solrcloud-controller"),
 	}).SetupWithManager(k8sManager)).To(Succeed())
 
 	Expect((&SolrPrometheusExporterReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: k8sManager.GetScheme(),
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("solrprometheusexporter-controller"),
 	}).SetupWithManager(k8sManager)).To(Succeed())
 
 	Expect((&SolrBackupReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: k8sManager.GetScheme(),
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("solrbackup-controller"),
+	}).SetupWithManager(k8sManager)).To(Succeed())
+
+	Expect((&SolrRestoreReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("solrrestore-controller"),
+	}).SetupWithManager(k8sManager)).To(Succeed())
+
+	Expect((&SolrCollectionReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("solrcollection-controller"),
+	}).SetupWithManager(k8sManager)).To(Succeed())
+
+	Expect((&SolrConfigSetReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("solrconfigset-controller"),
 	}).SetupWithManager(k8sManager)).To(Succeed())
 
 	go func() {
@@ -26,11 +26,14 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
 	"net/url"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -84,7 +87,9 @@ func GenerateQueryParamsForBackup(backupRepository *solr.SolrBackupRepository, b
 	queryParams.Add("location", BackupLocationPath(backupRepository, backup.Spec.Location))
 	queryParams.Add("repository", backupRepository.Name)
 
-	if backup.Spec.Recurrence.IsEnabled() {
+	// With a retention, the backup points are deleted by the Solr Operator instead
+	// A retention without any rules is ignored, since it would not keep any backup points
+	if backup.Spec.Recurrence.IsEnabled() && !backup.Spec.Recurrence.HasRetention() {
 		queryParams.Add("maxNumBackupPoints", strconv.Itoa(backup.Spec.Recurrence.MaxSaved))
 	}
 
@@ -142,6 +147,172 @@ func DeleteAsyncInfoForBackup(ctx context.Context, cloud *solr.SolrCloud, collec
 	return err
 }
 
+func GenerateQueryParamsForListBackup(backupRepository *solr.SolrBackupRepository, backup *solr.SolrBackup, collection string) url.Values {
+	queryParams := url.Values{}
+	queryParams.Add("action", "LISTBACKUP")
+	queryParams.Add("name", FullCollectionBackupName(collection, backup.Name))
+	queryParams.Add("location", BackupLocationPath(backupRepository, backup.Spec.Location))
+	queryParams.Add("repository", backupRepository.Name)
+	return queryParams
+}
+
+// ListBackupPointsForCollection lists the backup points of the collection's backup that exist in the backup repository
+func ListBackupPointsForCollection(ctx context.Context, cloud *solr.SolrCloud, backupRepository *solr.SolrBackupRepository, backup *solr.SolrBackup, collection string, logger logr.Logger) (catalog solr.CollectionBackupCatalog, err error) {
+	logger.Info("Calling to list collection backup points", "solrCloud", cloud.Name, "collection", collection)
+	resp := &solr_api.SolrBackupListResponse{}
+	err = solr_api.CallCollectionsApi(ctx, cloud, GenerateQueryParamsForListBackup(backupRepository, backup, collection), resp)
+	if err == nil {
+		_, err = solr_api.CheckForCollectionsApiError("LISTBACKUP", resp.ResponseHeader, resp.Error)
+	}
+	if err != nil {
+		logger.Error(err, "Error listing collection backup points", "solrCloud", cloud.Name, "collection", collection)
+		return catalog, err
+	}
+
+	catalog.Collection = collection
+	catalog.BackupName = FullCollectionBackupName(collection, backup.Name)
+	catalog.BackupPoints = BackupPointsFromListResponse(resp)
+	return catalog, nil
+}
+
+// BackupPointsFromListResponse converts the backup points listed by Solr into the form stored in the SolrBackup status
+func BackupPointsFromListResponse(resp *solr_api.SolrBackupListResponse) (backupPoints []solr.BackupPoint) {
+	for _, listed := range resp.Backups {
+		backupPoint := solr.BackupPoint{
+			BackupId:       listed.BackupId,
+			IndexFileCount: listed.IndexFileCount,
+			SolrVersion:    listed.IndexVersion,
+			IndexSize:      resource.NewQuantity(int64(listed.IndexSizeMB*1024*1024), resource.BinarySI),
+		}
+		if startTime, parseErr := time.Parse(time.RFC3339Nano, listed.StartTime); parseErr == nil {
+			convTime := metav1.NewTime(startTime)
+			backupPoint.StartTime = &convTime
+		}
+		backupPoints = append(backupPoints, backupPoint)
+	}
+	sort.SliceStable(backupPoints, func(i, j int) bool {
+		return backupPoints[i].BackupId < backupPoints[j].BackupId
+	})
+	return backupPoints
+}
+
+// BackupCollectionsInStatus returns every collection that has been backed up, by the current backup or any backup in the history
+func BackupCollectionsInStatus(backupStatus *solr.SolrBackupStatus) (collections []string) {
+	found := map[string]bool{}
+	for _, individualStatus := range append([]solr.IndividualSolrBackupStatus{backupStatus.IndividualSolrBackupStatus}, backupStatus.History...) {
+		for _, collectionStatus := range individualStatus.CollectionBackupStatuses {
+			if !found[collectionStatus.Collection] {
+				found[collectionStatus.Collection] = true
+				collections = append(collections, collectionStatus.Collection)
+			}
+		}
+	}
+	return collections
+}
+
+func GenerateQueryParamsForDeleteBackup(backupRepository *solr.SolrBackupRepository, backup *solr.SolrBackup, collection string) url.Values {
+	queryParams := url.Values{}
+	queryParams.Add("action", "DELETEBACKUP")
+	queryParams.Add("name", FullCollectionBackupName(collection, backup.Name))
+	queryParams.Add("location", BackupLocationPath(backupRepository, backup.Spec.Location))
+	queryParams.Add("repository", backupRepository.Name)
+	return queryParams
+}
+
+// DeleteBackupPointsForCollection deletes the given backup points of the collection's backup from the backup repository,
+// and then purges any files in the repository that are no longer used by the remaining backup points.
+func DeleteBackupPointsForCollection(ctx context.Context, cloud *solr.SolrCloud, backupRepository *solr.SolrBackupRepository, backup *solr.SolrBackup, collection string, backupIds []int, logger logr.Logger) (err error) {
+	for _, backupId := range backupIds {
+		logger.Info("Calling to delete collection backup point", "solrCloud", cloud.Name, "collection", collection, "backupId", backupId)
+		queryParams := GenerateQueryParamsForDeleteBackup(backupRepository, backup, collection)
+		queryParams.Add("backupId", strconv.Itoa(backupId))
+		if err = callDeleteBackup(ctx, cloud, queryParams); err != nil {
+			logger.Error(err, "Error deleting collection backup point", "solrCloud", cloud.Name, "collection", collection, "backupId", backupId)
+			return err
+		}
+	}
+
+	logger.Info("Calling to purge unused files of collection backup", "solrCloud", cloud.Name, "collection", collection)
+	queryParams := GenerateQueryParamsForDeleteBackup(backupRepository, backup, collection)
+	queryParams.Add("purgeUnused", "true")
+	if err = callDeleteBackup(ctx, cloud, queryParams); err != nil {
+		logger.Error(err, "Error purging unused files of collection backup", "solrCloud", cloud.Name, "collection", collection)
+	}
+	return err
+}
+
+func callDeleteBackup(ctx context.Context, cloud *solr.SolrCloud, queryParams url.Values) (err error) {
+	resp := &solr_api.SolrAsyncResponse{}
+	if err = solr_api.CallCollectionsApi(ctx, cloud, queryParams, resp); err == nil {
+		_, err = solr_api.CheckForCollectionsApiError("DELETEBACKUP", resp.ResponseHeader, resp.Error)
+	}
+	return err
+}
+
+// BackupPointsToDelete returns the IDs of the backup points that are not kept by the retention.
+// Backup points without a start time are always kept, since their age is unknown.
+// Nothing is deleted for a retention without any rules.
+func BackupPointsToDelete(retention *solr.BackupRetention, backupPoints []solr.BackupPoint, now time.Time) (backupIds []int) {
+	if retention == nil || retention.IsEmpty() {
+		return nil
+	}
+	var dated []solr.BackupPoint
+	for _, backupPoint := range backupPoints {
+		if backupPoint.StartTime != nil {
+			dated = append(dated, backupPoint)
+		}
+	}
+	if len(dated) == 0 {
+		return nil
+	}
+	// Newest first, so that the first backup point seen in each period is the one kept for it
+	sort.SliceStable(dated, func(i, j int) bool {
+		return dated[i].StartTime.After(dated[j].StartTime.Time)
+	})
+
+	now = now.UTC()
+	tiers := []struct {
+		keep int32
+		// The number of periods between the period of the backup point and the current period
+		periodsAgo func(t time.Time) int
+	}{
+		{retention.KeepDaily, func(t time.Time) int { return int(startOfDay(now).Sub(startOfDay(t)).Hours()) / 24 }},
+		{retention.KeepWeekly, func(t time.Time) int { return int(startOfWeek(now).Sub(startOfWeek(t)).Hours()) / (24 * 7) }},
+		{retention.KeepMonthly, func(t time.Time) int { return (now.Year()-t.Year())*12 + int(now.Month()) - int(t.Month()) }},
+	}
+
+	kept := map[int]bool{dated[0].BackupId: true}
+	for _, tier := range tiers {
+		seenPeriods := map[int]bool{}
+		for _, backupPoint := range dated {
+			period := tier.periodsAgo(backupPoint.StartTime.UTC())
+			if period >= 0 && period < int(tier.keep) && !seenPeriods[period] {
+				seenPeriods[period] = true
+				kept[backupPoint.BackupId] = true
+			}
+		}
+	}
+	for _, backupPoint := range dated {
+		if retention.KeepWithinHours > 0 && now.Sub(backupPoint.StartTime.Time) < time.Duration(retention.KeepWithinHours)*time.Hour {
+			kept[backupPoint.BackupId] = true
+		}
+		if !kept[backupPoint.BackupId] {
+			backupIds = append(backupIds, backupPoint.BackupId)
+		}
+	}
+	sort.Ints(backupIds)
+	return backupIds
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// startOfWeek returns the start of the ISO week, which begins on Monday
+func startOfWeek(t time.Time) time.Time {
+	return startOfDay(t).AddDate(0, 0, -(int(t.Weekday())+6)%7)
+}
+
 func EnsureDirectoryForBackup(solrCloud *solr.SolrCloud, backupRepository *solr.SolrBackupRepository, backup *solr.SolrBackup, config *rest.Config) (err error) {
 	// Directory creation only required/possible for volume (i.e. local) backups
 	if IsRepoVolume(backupRepository) {
@@ -19,10 +19,12 @@ package util
 
 import (
 	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"testing"
+	"time"
 )
 
 func TestSolrBackupApiParamsForVolumeRepositoryBackup(t *testing.T) {
@@ -413,3 +415,154 @@ func TestRepositoryLookupFailsIfNoNameProvidedAndMultipleRepositoriesDefined(t *
 
 	assert.Nil(t, found, "Expected GetBackupRepositoryByName to report no match")
 }
+
+func TestSolrBackupApiParamsForListBackup(t *testing.T) {
+	gcsRepository := &solr.SolrBackupRepository{
+		Name: "gcs-repository-1",
+		GCS: &solr.GcsRepository{
+			Bucket: "some-gcs-bucket",
+			GcsCredentialSecret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "some-secret-name"},
+				Key:                  "some-secret-key",
+			},
+			BaseLocation: "/some/gcs/path",
+		},
+	}
+	backupConfig := solr.SolrBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-backup-name",
+		},
+		Spec: solr.SolrBackupSpec{
+			SolrCloud:      "solrcloudcluster",
+			RepositoryName: "gcs-repository-1",
+			Location:       "/another/gcs/path",
+		},
+	}
+
+	queryParams := GenerateQueryParamsForListBackup(gcsRepository, &backupConfig, "col2")
+
+	assert.Equalf(t, "LISTBACKUP", queryParams.Get("action"), "Wrong %s for Collections API Call", "action")
+	assert.Equalf(t, "some-backup-name-col2", queryParams.Get("name"), "Wrong %s for Collections API Call", "backup name")
+	assert.Equalf(t, "/another/gcs/path", queryParams.Get("location"), "Wrong %s for Collections API Call", "backup location")
+	assert.Equalf(t, "gcs-repository-1", queryParams.Get("repository"), "Wrong %s for Collections API Call", "repository")
+	assert.Emptyf(t, queryParams.Get("async"), "Wrong %s for Collections API Call", "async id")
+}
+
+func TestBackupPointsFromListResponse(t *testing.T) {
+	resp := &solr_api.SolrBackupListResponse{
+		Collection: "col1",
+		Backups: []solr_api.SolrBackupListInstance{
+			{BackupId: 1, StartTime: "2024-02-09T03:19:52.085653Z", IndexFileCount: 12, IndexSizeMB: 1.5, IndexVersion: "9.4.1"},
+			{BackupId: 0, StartTime: "not a time", IndexFileCount: 10, IndexSizeMB: 1, IndexVersion: "9.4.0"},
+		},
+	}
+
+	backupPoints := BackupPointsFromListResponse(resp)
+	if !assert.Len(t, backupPoints, 2, "Wrong number of backup points") {
+		return
+	}
+	assert.Equal(t, 0, backupPoints[0].BackupId, "The backup points should be sorted from oldest to newest")
+	assert.Nil(t, backupPoints[0].StartTime, "An unparseable start time should be left empty")
+	assert.Equal(t, "1Mi", backupPoints[0].IndexSize.String(), "Wrong index size")
+
+	assert.Equal(t, 1, backupPoints[1].BackupId, "Wrong backupId")
+	if assert.NotNil(t, backupPoints[1].StartTime, "The start time should be parsed") {
+		assert.Equal(t, time.Date(2024, 2, 9, 3, 19, 52, 85653000, time.UTC), backupPoints[1].StartTime.UTC(), "Wrong start time")
+	}
+	assert.Equal(t, int64(1572864), backupPoints[1].IndexSize.Value(), "Wrong index size")
+	assert.Equal(t, 12, backupPoints[1].IndexFileCount, "Wrong index file count")
+	assert.Equal(t, "9.4.1", backupPoints[1].SolrVersion, "Wrong Solr version")
+}
+
+func TestBackupCollectionsInStatus(t *testing.T) {
+	backupStatus := &solr.SolrBackupStatus{
+		IndividualSolrBackupStatus: solr.IndividualSolrBackupStatus{
+			CollectionBackupStatuses: []solr.CollectionBackupStatus{{Collection: "col1"}, {Collection: "col2"}},
+		},
+		History: []solr.IndividualSolrBackupStatus{
+			{CollectionBackupStatuses: []solr.CollectionBackupStatus{{Collection: "col2"}, {Collection: "col3"}}},
+		},
+	}
+
+	assert.Equal(t, []string{"col1", "col2", "col3"}, BackupCollectionsInStatus(backupStatus), "Collections in the current backup and the history should be listed once each")
+	assert.Empty(t, BackupCollectionsInStatus(&solr.SolrBackupStatus{}), "There should be no collections before a backup is taken")
+}
+
+func TestSolrBackupApiParamsForRecurringBackupWithRetention(t *testing.T) {
+	volumeRepository := &solr.SolrBackupRepository{
+		Name: "some-volume-repository",
+		Volume: &solr.VolumeRepository{
+			Source: corev1.VolumeSource{}, // Actual volume info doesn't matter here
+		},
+	}
+	backupConfig := solr.SolrBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-backup-name",
+		},
+		Spec: solr.SolrBackupSpec{
+			SolrCloud:      "solrcloudcluster",
+			RepositoryName: "some-volume-repository",
+			Recurrence: &solr.BackupRecurrence{
+				Schedule: "@daily",
+				MaxSaved: 5,
+			},
+		},
+	}
+
+	queryParams := GenerateQueryParamsForBackup(volumeRepository, &backupConfig, "col1")
+	assert.Equalf(t, "5", queryParams.Get("maxNumBackupPoints"), "Wrong %s for Collections API Call", "maxNumBackupPoints")
+
+	backupConfig.Spec.Recurrence.Retention = &solr.BackupRetention{KeepDaily: 14}
+	queryParams = GenerateQueryParamsForBackup(volumeRepository, &backupConfig, "col1")
+	assert.Falsef(t, queryParams.Has("maxNumBackupPoints"), "The %s should not be passed when a retention is used", "maxNumBackupPoints")
+
+	// A retention without any rules, which is only rejected when the webhooks are enabled, must not delete every backup point
+	backupConfig.Spec.Recurrence.Retention = &solr.BackupRetention{}
+	queryParams = GenerateQueryParamsForBackup(volumeRepository, &backupConfig, "col1")
+	assert.Equalf(t, "5", queryParams.Get("maxNumBackupPoints"), "The %s should be used when the retention has no rules", "maxNumBackupPoints")
+
+	queryParams = GenerateQueryParamsForDeleteBackup(volumeRepository, &backupConfig, "col1")
+	assert.Equalf(t, "DELETEBACKUP", queryParams.Get("action"), "Wrong %s for Collections API Call", "action")
+	assert.Equalf(t, "some-backup-name-col1", queryParams.Get("name"), "Wrong %s for Collections API Call", "backup name")
+	assert.Equalf(t, "/var/solr/data/backup-restore/some-volume-repository/backups", queryParams.Get("location"), "Wrong %s for Collections API Call", "backup location")
+	assert.Equalf(t, "some-volume-repository", queryParams.Get("repository"), "Wrong %s for Collections API Call", "repository")
+}
+
+func TestBackupPointsToDelete(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	backupPoint := func(backupId int, startTime time.Time) solr.BackupPoint {
+		convTime := metav1.NewTime(startTime)
+		return solr.BackupPoint{BackupId: backupId, StartTime: &convTime}
+	}
+	// A backup point every 12 hours, for 120 days
+	var backupPoints []solr.BackupPoint
+	for i := 0; i < 240; i++ {
+		backupPoints = append(backupPoints, backupPoint(240-i, now.Add(-time.Duration(i)*12*time.Hour)))
+	}
+	keptIds := func(retention *solr.BackupRetention) (kept []int) {
+		deleted := map[int]bool{}
+		for _, backupId := range BackupPointsToDelete(retention, backupPoints, now) {
+			deleted[backupId] = true
+		}
+		for _, point := range backupPoints {
+			if !deleted[point.BackupId] {
+				kept = append(kept, point.BackupId)
+			}
+		}
+		return kept
+	}
+
+	assert.Equal(t, []int{240, 239, 238, 237}, keptIds(&solr.BackupRetention{KeepWithinHours: 48}), "Only the backup points within 48 hours should be kept")
+	assert.Equal(t, []int{240, 238, 236}, keptIds(&solr.BackupRetention{KeepDaily: 3}), "The newest backup point of each of the last 3 days should be kept")
+	assert.Equal(t, []int{240, 234, 220}, keptIds(&solr.BackupRetention{KeepWeekly: 3}), "The newest backup point of each of the last 3 ISO weeks should be kept")
+	assert.Equal(t, []int{240, 210, 150}, keptIds(&solr.BackupRetention{KeepMonthly: 3}), "The newest backup point of each of the last 3 months should be kept")
+	assert.Equal(t, []int{240, 238, 234, 220, 206}, keptIds(&solr.BackupRetention{KeepDaily: 2, KeepWeekly: 4}), "The tiers should be combined")
+	assert.Equal(t, []int{240}, keptIds(&solr.BackupRetention{KeepWithinHours: 1}), "The newest backup point should always be kept")
+	assert.Empty(t, BackupPointsToDelete(&solr.BackupRetention{}, backupPoints, now), "No backup points should be deleted for a retention without rules")
+	assert.Empty(t, BackupPointsToDelete(nil, backupPoints, now), "No backup points should be deleted without a retention")
+
+	backupPoints = append(backupPoints, solr.BackupPoint{BackupId: 0})
+	assert.NotContains(t, BackupPointsToDelete(&solr.BackupRetention{KeepDaily: 1}, backupPoints, now), 0, "Backup points without a start time should never be deleted")
+	assert.Empty(t, BackupPointsToDelete(&solr.BackupRetention{KeepDaily: 1}, nil, now), "There should be nothing to delete without backup points")
+}
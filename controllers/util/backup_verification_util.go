@@ -0,0 +1,264 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"github.com/go-logr/logr"
+	"net/url"
+	"time"
+)
+
+// VerificationCollectionName returns the name of the temporary collection that the collection's backup is restored into for verification
+func VerificationCollectionName(collection string, backupName string) string {
+	return fmt.Sprintf("%s-verify-%s", backupName, collection)
+}
+
+// NewCollectionBackupVerification returns the status of the verification of a collection's backup, before the verification has started
+func NewCollectionBackupVerification(backup *solr.SolrBackup, collection string) *solr.CollectionBackupVerification {
+	verificationCloud := backup.Spec.SolrCloud
+	if backup.Spec.Verify != nil && backup.Spec.Verify.SolrCloud != "" {
+		verificationCloud = backup.Spec.Verify.SolrCloud
+	}
+	return &solr.CollectionBackupVerification{
+		SolrCloud:         verificationCloud,
+		ScratchCollection: VerificationCollectionName(collection, backup.Name),
+	}
+}
+
+func AsyncIdForCollectionVerification(collection string, backupName string) string {
+	return fmt.Sprintf("%s-verify-restore-%s", backupName, collection)
+}
+
+// CollectionBackupsToVerify returns the indexes of the successful collection backups whose verification has not finished.
+// Verifications that have not yet started are only included if verification is enabled,
+// so that verifications that are in progress are still cleaned up after verification is disabled.
+func CollectionBackupsToVerify(backupStatus *solr.IndividualSolrBackupStatus, verificationEnabled bool) (indexes []int) {
+	if !backupStatus.Finished {
+		return nil
+	}
+	for i, collectionStatus := range backupStatus.CollectionBackupStatuses {
+		if collectionStatus.Successful == nil || !*collectionStatus.Successful {
+			continue
+		}
+		if (collectionStatus.Verification == nil && verificationEnabled) || (collectionStatus.Verification != nil && !collectionStatus.Verification.Finished) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// UpdateStatusOfBackupVerification sets whether the backup was verified successfully, once the verification of every collection backup has finished.
+// Nothing is set if no collection backup was verified.
+func UpdateStatusOfBackupVerification(backupStatus *solr.IndividualSolrBackupStatus) (allFinished bool) {
+	allFinished = true
+	allSuccessful := true
+	anyVerified := false
+	for _, collectionStatus := range backupStatus.CollectionBackupStatuses {
+		if collectionStatus.Verification == nil {
+			continue
+		}
+		anyVerified = true
+		allFinished = allFinished && collectionStatus.Verification.Finished
+		allSuccessful = allSuccessful && collectionStatus.Verification.Successful != nil && *collectionStatus.Verification.Successful
+	}
+	if anyVerified && allFinished && backupStatus.Verified == nil {
+		backupStatus.Verified = &allSuccessful
+	}
+	return allFinished
+}
+
+// BackupVerificationTimedOut returns whether the verification of the backup should have finished by now.
+func BackupVerificationTimedOut(backupStatus *solr.IndividualSolrBackupStatus, verify *solr.BackupVerification, now time.Time) bool {
+	return backupStatus.FinishTime != nil && now.After(backupStatus.FinishTime.Add(verify.Timeout()))
+}
+
+func GenerateQueryParamsForVerification(backupRepository *solr.SolrBackupRepository, backup *solr.SolrBackup, collection string) url.Values {
+	queryParams := url.Values{}
+	queryParams.Add("action", "RESTORE")
+	queryParams.Add("collection", VerificationCollectionName(collection, backup.Name))
+	queryParams.Add("name", FullCollectionBackupName(collection, backup.Name))
+	queryParams.Add("async", AsyncIdForCollectionVerification(collection, backup.Name))
+	queryParams.Add("location", BackupLocationPath(backupRepository, backup.Spec.Location))
+	queryParams.Add("repository", backupRepository.Name)
+
+	return queryParams
+}
+
+// StartVerificationForCollection restores the latest backup point of the collection into its temporary verification collection.
+// A temporary collection left over from a previous verification is deleted first.
+func StartVerificationForCollection(ctx context.Context, cloud *solr.SolrCloud, backupRepository *solr.SolrBackupRepository, backup *solr.SolrBackup, collection string, logger logr.Logger) (err error) {
+	if err = DeleteVerificationCollection(ctx, cloud, collection, backup.Name, logger); err != nil {
+		return err
+	}
+	queryParams := GenerateQueryParamsForVerification(backupRepository, backup, collection)
+	resp := &solr_api.SolrAsyncResponse{}
+
+	logger.Info("Calling to start collection backup verification", "solrCloud", cloud.Name, "collection", collection, "restoreAs", VerificationCollectionName(collection, backup.Name))
+	err = solr_api.CallCollectionsApi(ctx, cloud, queryParams, resp)
+	if _, apiErr := solr_api.CheckForCollectionsApiError("RESTORE", resp.ResponseHeader, resp.Error); apiErr != nil {
+		err = apiErr
+	}
+	if err != nil {
+		logger.Error(err, "Error starting collection backup verification", "solrCloud", cloud.Name, "collection", collection)
+	}
+	return err
+}
+
+func CheckVerificationForCollection(ctx context.Context, cloud *solr.SolrCloud, collection string, backupName string, logger logr.Logger) (finished bool, success bool, asyncStatus string, err error) {
+	logger.Info("Calling to check on collection backup verification", "solrCloud", cloud.Name, "collection", collection)
+
+	var message string
+	asyncStatus, message, err = solr_api.CheckAsyncRequest(ctx, cloud, AsyncIdForCollectionVerification(collection, backupName))
+
+	if err == nil {
+		if asyncStatus == "completed" {
+			finished = true
+			success = true
+		}
+		if asyncStatus == "failed" {
+			finished = true
+			success = false
+		}
+	} else {
+		logger.Error(err, "Error checking on collection backup verification", "solrCloud", cloud.Name, "collection", collection, "message", message)
+	}
+
+	return finished, success, asyncStatus, err
+}
+
+func DeleteAsyncInfoForVerification(ctx context.Context, cloud *solr.SolrCloud, collection string, backupName string, logger logr.Logger) (err error) {
+	logger.Info("Calling to delete async info for backup verification.", "solrCloud", cloud.Name, "collection", collection)
+	_, err = solr_api.DeleteAsyncRequest(ctx, cloud, AsyncIdForCollectionVerification(collection, backupName))
+
+	if err != nil {
+		logger.Error(err, "Error deleting async data for collection backup verification", "solrCloud", cloud.Name, "collection", collection)
+	}
+
+	return err
+}
+
+// DeleteVerificationCollection deletes the temporary collection that the collection's backup was restored into, if it exists
+func DeleteVerificationCollection(ctx context.Context, cloud *solr.SolrCloud, collection string, backupName string, logger logr.Logger) (err error) {
+	verificationCollection := VerificationCollectionName(collection, backupName)
+	collections, err := ListAllSolrCollections(ctx, cloud, logger)
+	if err != nil {
+		return err
+	}
+	exists := false
+	for _, existingCollection := range collections {
+		exists = exists || existingCollection == verificationCollection
+	}
+	if !exists {
+		return nil
+	}
+	queryParams := url.Values{}
+	queryParams.Add("action", "DELETE")
+	queryParams.Add("name", verificationCollection)
+
+	logger.Info("Deleting temporary collection for backup verification", "solrCloud", cloud.Name, "collection", verificationCollection)
+	return callCollectionModificationApi(ctx, cloud, "DELETE", queryParams)
+}
+
+// verificationCount is a count of the documents matching a query in a collection, that is compared for the verification of a backup
+type verificationCount struct {
+	ctx        context.Context
+	cloud      *solr.SolrCloud
+	collection string
+	query      string
+	numFound   **int64
+}
+
+// CountSourceDocumentsForVerification counts the documents of the source collection that the verification of its backup is compared with,
+// and records the counts in the verification status.
+// This is done when the collection's backup finishes, so that documents indexed between the backup and its verification do not fail the verification.
+func CountSourceDocumentsForVerification(ctx context.Context, cloud *solr.SolrCloud, verify *solr.BackupVerification, collection string, verification *solr.CollectionBackupVerification) (err error) {
+	counts := []verificationCount{{ctx, cloud, collection, "*:*", &verification.SourceNumDocs}}
+	if verify.Query != "" {
+		counts = append(counts, verificationCount{ctx, cloud, collection, verify.Query, &verification.SourceQueryNumFound})
+	}
+	for _, count := range counts {
+		numFound, countErr := solr_api.CountDocuments(count.ctx, count.cloud, count.collection, count.query)
+		if countErr != nil {
+			return countErr
+		}
+		*count.numFound = &numFound
+	}
+	return nil
+}
+
+// CompareVerificationCollection counts the documents of the collection that the backup was restored into, and records the counts and the result in the verification status.
+// The source collection is only counted here if it could not be counted when its backup finished.
+// Errors returned by Solr for the queries, such as the source collection no longer existing, fail the verification instead of being returned.
+func CompareVerificationCollection(sourceCtx context.Context, sourceCloud *solr.SolrCloud, verificationCtx context.Context, verificationCloud *solr.SolrCloud, verify *solr.BackupVerification, collection string, verification *solr.CollectionBackupVerification) (err error) {
+	var counts []verificationCount
+	if verification.SourceNumDocs == nil {
+		counts = append(counts, verificationCount{sourceCtx, sourceCloud, collection, "*:*", &verification.SourceNumDocs})
+	}
+	counts = append(counts, verificationCount{verificationCtx, verificationCloud, verification.ScratchCollection, "*:*", &verification.RestoredNumDocs})
+	if verify.Query != "" {
+		if verification.SourceQueryNumFound == nil {
+			counts = append(counts, verificationCount{sourceCtx, sourceCloud, collection, verify.Query, &verification.SourceQueryNumFound})
+		}
+		counts = append(counts, verificationCount{verificationCtx, verificationCloud, verification.ScratchCollection, verify.Query, &verification.RestoredQueryNumFound})
+	}
+	for _, count := range counts {
+		numFound, countErr := solr_api.CountDocuments(count.ctx, count.cloud, count.collection, count.query)
+		var solrErr solr_api.SolrErrorResponse
+		if goerrors.As(countErr, &solrErr) {
+			successful := false
+			verification.Successful = &successful
+			verification.Message = fmt.Sprintf("could not query collection %s: %s", count.collection, solrErr.Message)
+			return nil
+		} else if countErr != nil {
+			return countErr
+		}
+		*count.numFound = &numFound
+	}
+	successful, message := EvaluateBackupVerification(verify, verification)
+	verification.Successful = &successful
+	verification.Message = message
+	return nil
+}
+
+// EvaluateBackupVerification compares the document counts of the restored collection with those of the source collection.
+// The message describes why the verification failed, and is empty if it was successful.
+func EvaluateBackupVerification(verify *solr.BackupVerification, verification *solr.CollectionBackupVerification) (successful bool, message string) {
+	if verification.SourceNumDocs == nil || verification.RestoredNumDocs == nil || (verify.Query != "" && (verification.SourceQueryNumFound == nil || verification.RestoredQueryNumFound == nil)) {
+		return false, "the documents of the source and restored collections have not been counted"
+	}
+	if !countsMatch(verification.SourceNumDocs, verification.RestoredNumDocs, verify.DocCountTolerancePercent) {
+		return false, fmt.Sprintf("the restored collection has %d documents, but the source collection has %d", *verification.RestoredNumDocs, *verification.SourceNumDocs)
+	}
+	if verify.Query != "" && !countsMatch(verification.SourceQueryNumFound, verification.RestoredQueryNumFound, verify.DocCountTolerancePercent) {
+		return false, fmt.Sprintf("the query %q matches %d documents in the restored collection, but %d in the source collection", verify.Query, *verification.RestoredQueryNumFound, *verification.SourceQueryNumFound)
+	}
+	return true, ""
+}
+
+func countsMatch(source *int64, restored *int64, tolerancePercent int32) bool {
+	difference := *source - *restored
+	if difference < 0 {
+		difference = -difference
+	}
+	return difference*100 <= *source*int64(tolerancePercent)
+}
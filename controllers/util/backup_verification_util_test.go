@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestSolrBackupApiParamsForVerification(t *testing.T) {
+	s3Repository := &solr.SolrBackupRepository{
+		Name: "some-s3-repository",
+		S3: &solr.S3Repository{
+			Bucket: "some-bucket",
+			Region: "us-west-2",
+		},
+	}
+	backupConfig := solr.SolrBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-backup-name",
+		},
+		Spec: solr.SolrBackupSpec{
+			SolrCloud: "solrcloudcluster",
+			Location:  "/some/location",
+			Verify:    &solr.BackupVerification{},
+		},
+	}
+
+	queryParams := GenerateQueryParamsForVerification(s3Repository, &backupConfig, "col2")
+
+	assert.Equalf(t, "RESTORE", queryParams.Get("action"), "Wrong %s for Collections API Call", "action")
+	assert.Equalf(t, "some-backup-name-verify-col2", queryParams.Get("collection"), "Wrong %s for Collections API Call", "collection name")
+	assert.Equalf(t, "some-backup-name-col2", queryParams.Get("name"), "Wrong %s for Collections API Call", "backup name")
+	assert.Equalf(t, "some-backup-name-verify-restore-col2", queryParams.Get("async"), "Wrong %s for Collections API Call", "async id")
+	assert.Equalf(t, "/some/location", queryParams.Get("location"), "Wrong %s for Collections API Call", "backup location")
+	assert.Equalf(t, "some-s3-repository", queryParams.Get("repository"), "Wrong %s for Collections API Call", "repository")
+}
+
+func TestCollectionBackupsToVerify(t *testing.T) {
+	successful := true
+	failed := false
+	backupStatus := &solr.IndividualSolrBackupStatus{
+		CollectionBackupStatuses: []solr.CollectionBackupStatus{
+			{Collection: "col1", Finished: true, Successful: &successful},
+			{Collection: "col2", Finished: true, Successful: &failed},
+			{Collection: "col3", Finished: true, Successful: &successful, Verification: &solr.CollectionBackupVerification{InProgress: true}},
+			{Collection: "col4", Finished: true, Successful: &successful, Verification: &solr.CollectionBackupVerification{Finished: true, Successful: &successful}},
+		},
+	}
+	assert.Empty(t, CollectionBackupsToVerify(backupStatus, true), "An unfinished backup should not be verified")
+
+	backupStatus.Finished = true
+	assert.Equal(t, []int{0, 2}, CollectionBackupsToVerify(backupStatus, true), "Only successful collection backups with unfinished verifications should be verified")
+	assert.Equal(t, []int{2}, CollectionBackupsToVerify(backupStatus, false), "Only verifications in progress should be continued when verification is disabled")
+}
+
+func TestUpdateStatusOfBackupVerification(t *testing.T) {
+	successful := true
+	failed := false
+	backupStatus := &solr.IndividualSolrBackupStatus{
+		Finished: true,
+		CollectionBackupStatuses: []solr.CollectionBackupStatus{
+			{Collection: "col1", Finished: true, Successful: &failed},
+		},
+	}
+	assert.True(t, UpdateStatusOfBackupVerification(backupStatus), "A backup without verifications has no verification to wait for")
+	assert.Nil(t, backupStatus.Verified, "A backup without verifications should not have a verification result")
+
+	backupStatus.CollectionBackupStatuses = append(backupStatus.CollectionBackupStatuses,
+		solr.CollectionBackupStatus{Collection: "col2", Finished: true, Successful: &successful, Verification: &solr.CollectionBackupVerification{Finished: true, Successful: &successful}},
+		solr.CollectionBackupStatus{Collection: "col3", Finished: true, Successful: &successful, Verification: &solr.CollectionBackupVerification{InProgress: true}},
+	)
+	assert.False(t, UpdateStatusOfBackupVerification(backupStatus), "A backup with verifications in progress is not verified")
+	assert.Nil(t, backupStatus.Verified, "An unfinished verification should not have a result")
+
+	backupStatus.CollectionBackupStatuses[2].Verification = &solr.CollectionBackupVerification{Finished: true, Successful: &failed}
+	assert.True(t, UpdateStatusOfBackupVerification(backupStatus), "A backup with all verifications finished is verified")
+	if assert.NotNil(t, backupStatus.Verified, "A finished verification should have a result") {
+		assert.False(t, *backupStatus.Verified, "A backup with a failed verification should not be verified successfully")
+	}
+}
+
+func TestBackupVerificationTimedOut(t *testing.T) {
+	now := time.Now()
+	backupStatus := &solr.IndividualSolrBackupStatus{}
+	assert.False(t, BackupVerificationTimedOut(backupStatus, &solr.BackupVerification{TimeoutMinutes: 60}, now), "An unfinished backup cannot have a verification that timed out")
+
+	finishTime := metav1.NewTime(now.Add(-90 * time.Minute))
+	backupStatus.FinishTime = &finishTime
+	assert.True(t, BackupVerificationTimedOut(backupStatus, &solr.BackupVerification{TimeoutMinutes: 60}, now), "The verification should time out after the configured timeout")
+	assert.False(t, BackupVerificationTimedOut(backupStatus, &solr.BackupVerification{TimeoutMinutes: 120}, now), "The verification should not time out before the configured timeout")
+	assert.False(t, BackupVerificationTimedOut(backupStatus, nil, now), "The default timeout should be used once verification is disabled")
+}
+
+func TestEvaluateBackupVerification(t *testing.T) {
+	count := func(numDocs int64) *int64 { return &numDocs }
+	verify := &solr.BackupVerification{}
+	verification := &solr.CollectionBackupVerification{SourceNumDocs: count(1000), RestoredNumDocs: count(1000)}
+
+	successful, message := EvaluateBackupVerification(verify, verification)
+	assert.True(t, successful, "Matching document counts should be verified")
+	assert.Empty(t, message, "A successful verification should have no message")
+
+	verification.RestoredNumDocs = count(990)
+	successful, message = EvaluateBackupVerification(verify, verification)
+	assert.False(t, successful, "Different document counts should fail the verification without a tolerance")
+	assert.Equal(t, "the restored collection has 990 documents, but the source collection has 1000", message, "Wrong message for different document counts")
+
+	verify.DocCountTolerancePercent = 1
+	successful, _ = EvaluateBackupVerification(verify, verification)
+	assert.True(t, successful, "A difference within the tolerance should be verified")
+	verification.RestoredNumDocs = count(1011)
+	successful, _ = EvaluateBackupVerification(verify, verification)
+	assert.False(t, successful, "A difference beyond the tolerance should fail the verification")
+
+	verification.RestoredNumDocs = count(1000)
+	verify.Query = "type:product"
+	successful, message = EvaluateBackupVerification(verify, verification)
+	assert.False(t, successful, "A verification with an uncounted query should fail")
+	assert.NotEmpty(t, message, "A failed verification should have a message")
+
+	verification.SourceQueryNumFound = count(100)
+	verification.RestoredQueryNumFound = count(0)
+	successful, message = EvaluateBackupVerification(verify, verification)
+	assert.False(t, successful, "Different query counts should fail the verification")
+	assert.Equal(t, "the query \"type:product\" matches 0 documents in the restored collection, but 100 in the source collection", message, "Wrong message for different query counts")
+
+	verification.RestoredQueryNumFound = count(100)
+	successful, _ = EvaluateBackupVerification(verify, verification)
+	assert.True(t, successful, "Matching document and query counts should be verified")
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,6 +34,8 @@ import (
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 var (
@@ -153,6 +156,17 @@ func IsPVCOrphan(pvcName string, replicas int32) bool {
 	return int32(ordinal) >= replicas
 }
 
+// IsPVCForStatefulSet determines whether the given name represents a PVC that was created from the given volumeClaimTemplate of the StatefulSet.
+// The name of the StatefulSet cannot be used as a prefix by itself, since the StatefulSets of node pools share a prefix with the default StatefulSet.
+func IsPVCForStatefulSet(pvcName string, volumeClaimTemplateName string, statefulSetName string) bool {
+	ordinal, hasPrefix := strings.CutPrefix(pvcName, volumeClaimTemplateName+"-"+statefulSetName+"-")
+	if !hasPrefix {
+		return false
+	}
+	_, err := strconv.Atoi(ordinal)
+	return err == nil
+}
+
 // customizeProbe builds the probe logic used for pod liveness, readiness, startup checks
 func customizeProbe(initialProbe *corev1.Probe, customProbe corev1.Probe) *corev1.Probe {
 	if customProbe.InitialDelaySeconds != 0 {
@@ -205,6 +219,34 @@ func CopyServiceFields(from, to *corev1.Service, logger logr.Logger) bool {
 
 	// Don't copy the entire Spec, because we can't overwrite the clusterIp field
 
+	// An empty type is defaulted to ClusterIP by Kubernetes
+	fromType := from.Spec.Type
+	if fromType == "" {
+		fromType = corev1.ServiceTypeClusterIP
+	}
+	if to.Spec.Type != fromType && !(to.Spec.Type == "" && fromType == corev1.ServiceTypeClusterIP) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.Type", "from", to.Spec.Type, "to", fromType)
+		to.Spec.Type = fromType
+	}
+
+	if !DeepEqualWithNils(to.Spec.LoadBalancerSourceRanges, from.Spec.LoadBalancerSourceRanges) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.LoadBalancerSourceRanges", "from", to.Spec.LoadBalancerSourceRanges, "to", from.Spec.LoadBalancerSourceRanges)
+	}
+	to.Spec.LoadBalancerSourceRanges = from.Spec.LoadBalancerSourceRanges
+
+	// Kubernetes allocates nodePorts for NodePort and LoadBalancer Services, keep them unless a specific nodePort is requested
+	if to.Spec.Type == corev1.ServiceTypeNodePort || to.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		for i := range from.Spec.Ports {
+			for _, toPort := range to.Spec.Ports {
+				if from.Spec.Ports[i].NodePort == 0 && from.Spec.Ports[i].Name == toPort.Name {
+					from.Spec.Ports[i].NodePort = toPort.NodePort
+				}
+			}
+		}
+	}
+
 	if !DeepEqualWithNils(to.Spec.Selector, from.Spec.Selector) {
 		requireUpdate = true
 		logger.Info("Update required because field changed", "field", "Spec.Selector", "from", to.Spec.Selector, "to", from.Spec.Selector)
@@ -734,6 +776,64 @@ func CopyPodDisruptionBudgetFields(from, to *policyv1.PodDisruptionBudget, logge
 	return requireUpdate
 }
 
+// CopyHorizontalPodAutoscalerFields copies the owned fields from one HorizontalPodAutoscaler to another
+func CopyHorizontalPodAutoscalerFields(from, to *autoscalingv2.HorizontalPodAutoscaler, logger logr.Logger) bool {
+	logger = logger.WithValues("kind", "HorizontalPodAutoscaler")
+	requireUpdate := CopyLabelsAndAnnotations(&from.ObjectMeta, &to.ObjectMeta, logger)
+
+	if !DeepEqualWithNils(to.Spec.ScaleTargetRef, from.Spec.ScaleTargetRef) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.ScaleTargetRef", "from", to.Spec.ScaleTargetRef, "to", from.Spec.ScaleTargetRef)
+		to.Spec.ScaleTargetRef = from.Spec.ScaleTargetRef
+	}
+	if !DeepEqualWithNils(to.Spec.MinReplicas, from.Spec.MinReplicas) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.MinReplicas", "from", to.Spec.MinReplicas, "to", from.Spec.MinReplicas)
+		to.Spec.MinReplicas = from.Spec.MinReplicas
+	}
+	if to.Spec.MaxReplicas != from.Spec.MaxReplicas {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.MaxReplicas", "from", to.Spec.MaxReplicas, "to", from.Spec.MaxReplicas)
+		to.Spec.MaxReplicas = from.Spec.MaxReplicas
+	}
+	if !DeepEqualWithNils(to.Spec.Metrics, from.Spec.Metrics) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.Metrics", "from", to.Spec.Metrics, "to", from.Spec.Metrics)
+		to.Spec.Metrics = from.Spec.Metrics
+	}
+	if !DeepEqualWithNils(to.Spec.Behavior, from.Spec.Behavior) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.Behavior", "from", to.Spec.Behavior, "to", from.Spec.Behavior)
+		to.Spec.Behavior = from.Spec.Behavior
+	}
+
+	return requireUpdate
+}
+
+// CopyNetworkPolicyFields copies the owned fields from one NetworkPolicy to another
+func CopyNetworkPolicyFields(from, to *netv1.NetworkPolicy, logger logr.Logger) bool {
+	logger = logger.WithValues("kind", "NetworkPolicy")
+	requireUpdate := CopyLabelsAndAnnotations(&from.ObjectMeta, &to.ObjectMeta, logger)
+
+	if !DeepEqualWithNils(to.Spec.PodSelector, from.Spec.PodSelector) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.PodSelector", "from", to.Spec.PodSelector, "to", from.Spec.PodSelector)
+		to.Spec.PodSelector = from.Spec.PodSelector
+	}
+	if !DeepEqualWithNils(to.Spec.Ingress, from.Spec.Ingress) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.Ingress", "from", to.Spec.Ingress, "to", from.Spec.Ingress)
+		to.Spec.Ingress = from.Spec.Ingress
+	}
+	if !DeepEqualWithNils(to.Spec.PolicyTypes, from.Spec.PolicyTypes) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.PolicyTypes", "from", to.Spec.PolicyTypes, "to", from.Spec.PolicyTypes)
+		to.Spec.PolicyTypes = from.Spec.PolicyTypes
+	}
+
+	return requireUpdate
+}
+
 // OvertakeControllerRef makes sure that the controlled object has the owner as the controller ref.
 // If the object has a different controller, then that ref will be downgraded to an "owner" and the new controller ref will be added
 func OvertakeControllerRef(owner metav1.Object, controlled metav1.Object, scheme *runtime.Scheme) (needsUpdate bool, err error) {
@@ -747,3 +847,51 @@ func OvertakeControllerRef(owner metav1.Object, controlled metav1.Object, scheme
 	}
 	return needsUpdate, err
 }
+
+// CopyHTTPRouteFields copies the owned fields from one HTTPRoute to another
+func CopyHTTPRouteFields(from, to *gatewayv1.HTTPRoute, logger logr.Logger) bool {
+	logger = logger.WithValues("kind", "HTTPRoute")
+	requireUpdate := CopyLabelsAndAnnotations(&from.ObjectMeta, &to.ObjectMeta, logger)
+
+	if !DeepEqualWithNils(to.Spec.ParentRefs, from.Spec.ParentRefs) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.ParentRefs", "from", to.Spec.ParentRefs, "to", from.Spec.ParentRefs)
+		to.Spec.ParentRefs = from.Spec.ParentRefs
+	}
+	if !DeepEqualWithNils(to.Spec.Hostnames, from.Spec.Hostnames) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.Hostnames", "from", to.Spec.Hostnames, "to", from.Spec.Hostnames)
+		to.Spec.Hostnames = from.Spec.Hostnames
+	}
+	if !DeepEqualWithNils(to.Spec.Rules, from.Spec.Rules) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.Rules", "from", to.Spec.Rules, "to", from.Spec.Rules)
+		to.Spec.Rules = from.Spec.Rules
+	}
+
+	return requireUpdate
+}
+
+// CopyTLSRouteFields copies the owned fields from one TLSRoute to another
+func CopyTLSRouteFields(from, to *gatewayv1alpha2.TLSRoute, logger logr.Logger) bool {
+	logger = logger.WithValues("kind", "TLSRoute")
+	requireUpdate := CopyLabelsAndAnnotations(&from.ObjectMeta, &to.ObjectMeta, logger)
+
+	if !DeepEqualWithNils(to.Spec.ParentRefs, from.Spec.ParentRefs) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.ParentRefs", "from", to.Spec.ParentRefs, "to", from.Spec.ParentRefs)
+		to.Spec.ParentRefs = from.Spec.ParentRefs
+	}
+	if !DeepEqualWithNils(to.Spec.Hostnames, from.Spec.Hostnames) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.Hostnames", "from", to.Spec.Hostnames, "to", from.Spec.Hostnames)
+		to.Spec.Hostnames = from.Spec.Hostnames
+	}
+	if !DeepEqualWithNils(to.Spec.Rules, from.Spec.Rules) {
+		requireUpdate = true
+		logger.Info("Update required because field changed", "field", "Spec.Rules", "from", to.Spec.Rules, "to", from.Spec.Rules)
+		to.Spec.Rules = from.Spec.Rules
+	}
+
+	return requireUpdate
+}
@@ -93,3 +93,10 @@ func TestCopyResources(t *testing.T) {
 	assert.Len(t, baseResources.Requests, 0, "Incorrect length for resource requests")
 	assert.Nil(t, baseResources.Requests, "Resource requests should be nil")
 }
+
+func TestIsPVCForStatefulSet(t *testing.T) {
+	assert.True(t, IsPVCForStatefulSet("data-foo-solrcloud-0", "data", "foo-solrcloud"), "PVC should belong to the StatefulSet")
+	assert.True(t, IsPVCForStatefulSet("data-foo-solrcloud-pull-12", "data", "foo-solrcloud-pull"), "PVC should belong to the node pool StatefulSet")
+	assert.False(t, IsPVCForStatefulSet("data-foo-solrcloud-pull-0", "data", "foo-solrcloud"), "PVC of a node pool should not belong to the default StatefulSet")
+	assert.False(t, IsPVCForStatefulSet("other-foo-solrcloud-0", "data", "foo-solrcloud"), "PVC from a different volumeClaimTemplate should not belong to the StatefulSet")
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+// Reasons for the Kubernetes Events created by the Solr Operator
+const (
+	// Cluster Operations
+	EventReasonClusterOpStarted         = "ClusterOpStarted"
+	EventReasonClusterOpCompleted       = "ClusterOpCompleted"
+	EventReasonClusterOpQueuedForRetry  = "ClusterOpQueuedForRetry"
+	EventReasonClusterOpRetried         = "ClusterOpRetried"
+	EventReasonClusterOpNotSupported    = "ClusterOpNotSupported"
+	EventReasonClusterOpInvalidMetadata = "ClusterOpInvalidMetadata"
+
+	// Solr Pods and Replicas
+	EventReasonEvictingReplicas         = "EvictingReplicas"
+	EventReasonReplicasEvicted          = "ReplicasEvicted"
+	EventReasonEvictReplicasFailed      = "EvictReplicasFailed"
+	EventReasonBalancingReplicas        = "BalancingReplicas"
+	EventReasonReplicasBalanced         = "ReplicasBalanced"
+	EventReasonBalanceReplicasFailed    = "BalanceReplicasFailed"
+	EventReasonDeletingPodForUpdate     = "DeletingPodForUpdate"
+	EventReasonDeletePodFailed          = "DeletePodFailed"
+	EventReasonPodReadinessFailed       = "PodReadinessConditionFailed"
+	EventReasonScaledStatefulSet        = "ScaledStatefulSet"
+	EventReasonScheduledRestart         = "ScheduledRestart"
+	EventReasonDeletedNodePool          = "DeletedNodePool"
+	EventReasonEvictionRefused          = "EvictionRefused"
+	EventReasonExpandingDataVolumes     = "ExpandingDataVolumes"
+	EventReasonExpandDataVolumesFailed  = "ExpandDataVolumesFailed"
+	EventReasonRecreatingStatefulSet    = "RecreatingStatefulSet"
+	EventReasonMigratingStorage         = "MigratingStorage"
+	EventReasonStorageMigrationBlocked  = "StorageMigrationBlocked"
+	EventReasonSnapshottingDataVolume   = "SnapshottingDataVolume"
+	EventReasonSnapshotDataVolumeFailed = "SnapshotDataVolumeFailed"
+	EventReasonRestoringDataVolume      = "RestoringDataVolume"
+	EventReasonWaitingForNodeServices   = "WaitingForNodeServices"
+	EventReasonInvalidConfiguration     = "InvalidConfiguration"
+	EventReasonZookeeperReconcileFailed = "ZookeeperReconcileFailed"
+
+	// Collections
+	EventReasonCollectionMissing               = "CollectionMissing"
+	EventReasonCollectionCreationOptionChanged = "CollectionCreationOptionChanged"
+
+	// Security
+	EventReasonSecurityBootstrapFailed  = "SecurityBootstrapFailed"
+	EventReasonSecurityUpdated          = "SecurityUpdated"
+	EventReasonSecurityReconcileFailed  = "SecurityReconcileFailed"
+	EventReasonCredentialsRotated       = "CredentialsRotated"
+	EventReasonCredentialRotationFailed = "CredentialRotationFailed"
+
+	// Backups
+	EventReasonBackupStarted          = "BackupStarted"
+	EventReasonBackupFinished         = "BackupFinished"
+	EventReasonBackupFailed           = "BackupFailed"
+	EventReasonBackupError            = "BackupError"
+	EventReasonCollectionBackupFailed = "CollectionBackupFailed"
+	EventReasonBackupPointsDeleted    = "BackupPointsDeleted"
+	EventReasonBackupDataDeleted      = "BackupDataDeleted"
+	EventReasonBackupDeletionFailed   = "BackupDeletionFailed"
+	EventReasonBackupVerified         = "BackupVerified"
+	EventReasonBackupVerifyFailed     = "BackupVerificationFailed"
+
+	// Restores
+	EventReasonRestoreStarted          = "RestoreStarted"
+	EventReasonRestoreFinished         = "RestoreFinished"
+	EventReasonRestoreFailed           = "RestoreFailed"
+	EventReasonRestoreError            = "RestoreError"
+	EventReasonCollectionRestoreFailed = "CollectionRestoreFailed"
+
+	// ConfigSets
+	EventReasonConfigSetUploadFailed  = "ConfigSetUploadFailed"
+	EventReasonCollectionReloadFailed = "CollectionReloadFailed"
+)
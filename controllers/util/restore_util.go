@@ -0,0 +1,163 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"fmt"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"github.com/go-logr/logr"
+	"net/url"
+)
+
+func AsyncIdForCollectionRestore(collection string, restoreName string) string {
+	return fmt.Sprintf("%s-restore-%s", restoreName, collection)
+}
+
+// CollectionBackupNameForRestore returns the name of the collection's backup in the repository.
+// By default, this is the same name that the Solr Operator would give the collection backup for a SolrBackup with the given name.
+func CollectionBackupNameForRestore(collection *solr.RestoreCollection, backupName string) string {
+	if collection.BackupName != "" {
+		return collection.BackupName
+	}
+	return FullCollectionBackupName(collection.Name, backupName)
+}
+
+// CollectionsToRestore determines the list of collections to restore.
+// If the restore does not list the collections explicitly, all collections successfully backed up by the latest successful backup
+// of the SolrBackup are used. For recurring backups, this may be a backup from the history, while the next backup is in progress.
+func CollectionsToRestore(restore *solr.SolrRestore, backup *solr.SolrBackup) (collections []solr.RestoreCollection, err error) {
+	if len(restore.Spec.Collections) > 0 {
+		return restore.Spec.Collections, nil
+	}
+	if backup == nil {
+		return nil, fmt.Errorf("the collections to restore must be listed when restoring without a SolrBackup reference")
+	}
+	latestBackup := latestSuccessfulBackup(&backup.Status)
+	if latestBackup == nil {
+		return nil, fmt.Errorf("cannot restore from SolrBackup %s, none of its backups have finished successfully", backup.Name)
+	}
+	for _, collectionBackupStatus := range latestBackup.CollectionBackupStatuses {
+		if collectionBackupStatus.Successful != nil && *collectionBackupStatus.Successful {
+			collections = append(collections, solr.RestoreCollection{Name: collectionBackupStatus.Collection})
+		}
+	}
+	return collections, nil
+}
+
+// latestSuccessfulBackup returns the most recent backup that finished successfully, either the current one or one from the history
+func latestSuccessfulBackup(backupStatus *solr.SolrBackupStatus) *solr.IndividualSolrBackupStatus {
+	isSuccessful := func(status *solr.IndividualSolrBackupStatus) bool {
+		return status.Finished && status.Successful != nil && *status.Successful
+	}
+	if isSuccessful(&backupStatus.IndividualSolrBackupStatus) {
+		return &backupStatus.IndividualSolrBackupStatus
+	}
+	// The history is sorted from most recent to oldest
+	for i := range backupStatus.History {
+		if isSuccessful(&backupStatus.History[i]) {
+			return &backupStatus.History[i]
+		}
+	}
+	return nil
+}
+
+func UpdateStatusOfCollectionRestores(restoreStatus *solr.SolrRestoreStatus) (allFinished bool) {
+	// Check if all collection restores have been completed, this is updated in the loop
+	allFinished = len(restoreStatus.CollectionRestoreStatuses) > 0
+
+	allSuccessful := len(restoreStatus.CollectionRestoreStatuses) > 0
+
+	for _, collectionStatus := range restoreStatus.CollectionRestoreStatuses {
+		allFinished = allFinished && collectionStatus.Finished
+		allSuccessful = allSuccessful && (collectionStatus.Successful != nil && *collectionStatus.Successful)
+	}
+
+	restoreStatus.Finished = allFinished
+	if allFinished && restoreStatus.Successful == nil {
+		restoreStatus.Successful = &allSuccessful
+	}
+	return
+}
+
+func GenerateQueryParamsForRestore(backupRepository *solr.SolrBackupRepository, restore *solr.SolrRestore, location string, backupName string, collection *solr.RestoreCollection) url.Values {
+	queryParams := url.Values{}
+	queryParams.Add("action", "RESTORE")
+	queryParams.Add("collection", collection.TargetCollection())
+	queryParams.Add("name", CollectionBackupNameForRestore(collection, backupName))
+	queryParams.Add("async", AsyncIdForCollectionRestore(collection.TargetCollection(), restore.Name))
+	queryParams.Add("location", BackupLocationPath(backupRepository, location))
+	queryParams.Add("repository", backupRepository.Name)
+
+	return queryParams
+}
+
+func StartRestoreForCollection(ctx context.Context, cloud *solr.SolrCloud, backupRepository *solr.SolrBackupRepository, restore *solr.SolrRestore, location string, backupName string, collection *solr.RestoreCollection, logger logr.Logger) (success bool, err error) {
+	queryParams := GenerateQueryParamsForRestore(backupRepository, restore, location, backupName, collection)
+	resp := &solr_api.SolrAsyncResponse{}
+
+	logger.Info("Calling to start collection restore", "solrCloud", cloud.Name, "collection", collection.Name, "restoreAs", collection.TargetCollection())
+	err = solr_api.CallCollectionsApi(ctx, cloud, queryParams, resp)
+	if _, apiErr := solr_api.CheckForCollectionsApiError("RESTORE", resp.ResponseHeader, resp.Error); apiErr != nil {
+		err = apiErr
+	}
+
+	if err == nil {
+		if resp.ResponseHeader.Status == 0 {
+			success = true
+		}
+	} else {
+		logger.Error(err, "Error starting collection restore", "solrCloud", cloud.Name, "collection", collection.Name, "restoreAs", collection.TargetCollection())
+	}
+
+	return success, err
+}
+
+func CheckRestoreForCollection(ctx context.Context, cloud *solr.SolrCloud, collection string, restoreName string, logger logr.Logger) (finished bool, success bool, asyncStatus string, err error) {
+	logger.Info("Calling to check on collection restore", "solrCloud", cloud.Name, "collection", collection)
+
+	var message string
+	asyncStatus, message, err = solr_api.CheckAsyncRequest(ctx, cloud, AsyncIdForCollectionRestore(collection, restoreName))
+
+	if err == nil {
+		if asyncStatus == "completed" {
+			finished = true
+			success = true
+		}
+		if asyncStatus == "failed" {
+			finished = true
+			success = false
+		}
+	} else {
+		logger.Error(err, "Error checking on collection restore", "solrCloud", cloud.Name, "collection", collection, "message", message)
+	}
+
+	return finished, success, asyncStatus, err
+}
+
+func DeleteAsyncInfoForRestore(ctx context.Context, cloud *solr.SolrCloud, collection string, restoreName string, logger logr.Logger) (err error) {
+	logger.Info("Calling to delete async info for restore command.", "solrCloud", cloud.Name, "collection", collection)
+	_, err = solr_api.DeleteAsyncRequest(ctx, cloud, AsyncIdForCollectionRestore(collection, restoreName))
+
+	if err != nil {
+		logger.Error(err, "Error deleting async data for collection restore", "solrCloud", cloud.Name, "collection", collection)
+	}
+
+	return err
+}
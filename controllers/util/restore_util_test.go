@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestSolrRestoreApiParamsForVolumeRepository(t *testing.T) {
+	volumeRepository := &solr.SolrBackupRepository{
+		Name: "some-volume-repository",
+		Volume: &solr.VolumeRepository{
+			Source:    corev1.VolumeSource{}, // Actual volume info doesn't matter here
+			Directory: "/somedirectory",
+		},
+	}
+	restoreConfig := solr.SolrRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-restore-name",
+		},
+		Spec: solr.SolrRestoreSpec{
+			SolrCloud:  "solrcloudcluster",
+			SolrBackup: "some-backup-name",
+		},
+	}
+
+	queryParams := GenerateQueryParamsForRestore(volumeRepository, &restoreConfig, "", "some-backup-name", &solr.RestoreCollection{Name: "col2"})
+
+	assert.Equalf(t, "RESTORE", queryParams.Get("action"), "Wrong %s for Collections API Call", "action")
+	assert.Equalf(t, "col2", queryParams.Get("collection"), "Wrong %s for Collections API Call", "collection name")
+	assert.Equalf(t, "some-backup-name-col2", queryParams.Get("name"), "Wrong %s for Collections API Call", "backup name")
+	assert.Equalf(t, "some-restore-name-restore-col2", queryParams.Get("async"), "Wrong %s for Collections API Call", "async id")
+	assert.Equalf(t, "/var/solr/data/backup-restore/some-volume-repository/backups", queryParams.Get("location"), "Wrong %s for Collections API Call", "backup location")
+	assert.Equalf(t, "some-volume-repository", queryParams.Get("repository"), "Wrong %s for Collections API Call", "repository")
+}
+
+func TestSolrRestoreApiParamsWithRenamedCollection(t *testing.T) {
+	s3Repository := &solr.SolrBackupRepository{
+		Name: "some-s3-repository",
+		S3: &solr.S3Repository{
+			Bucket: "some-bucket",
+			Region: "us-west-2",
+		},
+	}
+	restoreConfig := solr.SolrRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-restore-name",
+		},
+		Spec: solr.SolrRestoreSpec{
+			SolrCloud:  "solrcloudcluster",
+			BackupName: "some-backup-name",
+			Location:   "/another/location",
+		},
+	}
+
+	queryParams := GenerateQueryParamsForRestore(s3Repository, &restoreConfig, "/another/location", "some-backup-name", &solr.RestoreCollection{Name: "col2", RestoreAs: "col3"})
+
+	assert.Equalf(t, "RESTORE", queryParams.Get("action"), "Wrong %s for Collections API Call", "action")
+	assert.Equalf(t, "col3", queryParams.Get("collection"), "Wrong %s for Collections API Call", "collection name")
+	assert.Equalf(t, "some-backup-name-col2", queryParams.Get("name"), "Wrong %s for Collections API Call", "backup name")
+	assert.Equalf(t, "some-restore-name-restore-col3", queryParams.Get("async"), "Wrong %s for Collections API Call", "async id")
+	assert.Equalf(t, "/another/location", queryParams.Get("location"), "Wrong %s for Collections API Call", "backup location")
+	assert.Equalf(t, "some-s3-repository", queryParams.Get("repository"), "Wrong %s for Collections API Call", "repository")
+
+	// A custom backup name overrides the default naming of collection backups
+	queryParams = GenerateQueryParamsForRestore(s3Repository, &restoreConfig, "/another/location", "some-backup-name", &solr.RestoreCollection{Name: "col2", RestoreAs: "col3", BackupName: "custom-backup"})
+	assert.Equalf(t, "custom-backup", queryParams.Get("name"), "Wrong %s for Collections API Call", "backup name")
+}
+
+func TestCollectionsToRestore(t *testing.T) {
+	successful := true
+	failed := false
+	restore := &solr.SolrRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore"},
+		Spec: solr.SolrRestoreSpec{
+			SolrCloud:  "solrcloudcluster",
+			SolrBackup: "backup",
+		},
+	}
+	backup := &solr.SolrBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup"},
+		Status: solr.SolrBackupStatus{
+			IndividualSolrBackupStatus: solr.IndividualSolrBackupStatus{
+				Finished:   true,
+				Successful: &successful,
+				CollectionBackupStatuses: []solr.CollectionBackupStatus{
+					{Collection: "col1", Finished: true, Successful: &successful},
+					{Collection: "col2", Finished: true, Successful: &failed},
+					{Collection: "col3", Finished: true, Successful: &successful},
+				},
+			},
+		},
+	}
+
+	collections, err := CollectionsToRestore(restore, backup)
+	assert.NoError(t, err, "Collections to restore should be found from the SolrBackup status")
+	assert.EqualValues(t, []solr.RestoreCollection{{Name: "col1"}, {Name: "col3"}}, collections, "Only successfully backed up collections should be restored")
+
+	restore.Spec.Collections = []solr.RestoreCollection{{Name: "col2", RestoreAs: "col4"}}
+	collections, err = CollectionsToRestore(restore, backup)
+	assert.NoError(t, err, "Listed collections should always be usable")
+	assert.EqualValues(t, restore.Spec.Collections, collections, "Listed collections should be restored, regardless of the SolrBackup")
+
+	restore.Spec.Collections = nil
+	_, err = CollectionsToRestore(restore, nil)
+	assert.Error(t, err, "Collections must be listed when there is no SolrBackup reference")
+
+	backup.Status.History = []solr.IndividualSolrBackupStatus{
+		{
+			Finished:   true,
+			Successful: &failed,
+			CollectionBackupStatuses: []solr.CollectionBackupStatus{
+				{Collection: "col1", Finished: true, Successful: &failed},
+			},
+		},
+		backup.Status.IndividualSolrBackupStatus,
+	}
+	backup.Status.IndividualSolrBackupStatus = solr.IndividualSolrBackupStatus{
+		CollectionBackupStatuses: []solr.CollectionBackupStatus{
+			{Collection: "col1", InProgress: true},
+		},
+	}
+	collections, err = CollectionsToRestore(restore, backup)
+	assert.NoError(t, err, "Collections should be taken from the history while the next recurring backup is in progress")
+	assert.EqualValues(t, []solr.RestoreCollection{{Name: "col1"}, {Name: "col3"}}, collections, "The collections of the latest successful backup in the history should be restored")
+
+	backup.Status.History = backup.Status.History[:1]
+	_, err = CollectionsToRestore(restore, backup)
+	assert.Error(t, err, "Collections cannot be taken from a SolrBackup without a successful backup")
+}
+
+func TestUpdateStatusOfCollectionRestores(t *testing.T) {
+	successful := true
+	failed := false
+	restoreStatus := &solr.SolrRestoreStatus{}
+	assert.False(t, UpdateStatusOfCollectionRestores(restoreStatus), "A restore with no collection statuses is not finished")
+
+	restoreStatus.CollectionRestoreStatuses = []solr.CollectionRestoreStatus{
+		{Collection: "col1", Finished: true, Successful: &successful},
+		{Collection: "col2", InProgress: true},
+	}
+	assert.False(t, UpdateStatusOfCollectionRestores(restoreStatus), "A restore with in-progress collections is not finished")
+	assert.Nil(t, restoreStatus.Successful, "An unfinished restore should not have a result")
+
+	restoreStatus.CollectionRestoreStatuses[1] = solr.CollectionRestoreStatus{Collection: "col2", Finished: true, Successful: &failed}
+	assert.True(t, UpdateStatusOfCollectionRestores(restoreStatus), "A restore with all collections finished is finished")
+	assert.True(t, restoreStatus.Finished, "The restore status should be marked as finished")
+	if assert.NotNil(t, restoreStatus.Successful, "A finished restore should have a result") {
+		assert.False(t, *restoreStatus.Successful, "A restore with a failed collection should not be successful")
+	}
+}
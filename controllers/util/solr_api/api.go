@@ -18,6 +18,7 @@
 package solr_api
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
@@ -31,6 +32,7 @@ import (
 
 const (
 	HTTP_HEADERS_CONTEXT_KEY = "HTTP_HEADERS"
+	HTTP_CLIENT_CONTEXT_KEY  = "HTTP_CLIENT"
 )
 
 // Used to call a Solr pod over https when using a self-signed cert
@@ -47,6 +49,18 @@ func SetMTLSHttpClient(client *http.Client) {
 	mTLSHttpClient = client
 }
 
+// httpClientFromContext returns the HTTP client passed through the Context, used when a SolrCloud has its own client certificate for the operator.
+// Otherwise the client shared by all SolrClouds is returned.
+func httpClientFromContext(ctx context.Context) *http.Client {
+	if client, hasClient := ctx.Value(HTTP_CLIENT_CONTEXT_KEY).(*http.Client); hasClient && client != nil {
+		return client
+	}
+	if mTLSHttpClient != nil {
+		return mTLSHttpClient
+	}
+	return noVerifyTLSHttpClient
+}
+
 type SolrAsyncResponse struct {
 	ResponseHeader SolrResponseHeader `json:"responseHeader"`
 
@@ -142,20 +156,30 @@ func DeleteAsyncRequest(ctx context.Context, cloud *solr.SolrCloud, asyncId stri
 }
 
 func CallCollectionsApi(ctx context.Context, cloud *solr.SolrCloud, urlParams url.Values, response interface{}) (err error) {
+	return callSolrAdminApi(ctx, cloud, http.MethodGet, "/solr/admin/collections", urlParams, nil, response)
+}
+
+// callSolrAdminApi calls a V1 admin API of the SolrCloud and decodes the JSON response.
+// If a body is given, it is sent as the raw bytes of the request.
+func callSolrAdminApi(ctx context.Context, cloud *solr.SolrCloud, urlMethod string, urlPath string, urlParams url.Values, body []byte, response interface{}) (err error) {
 	cloudUrl := solr.InternalURLForCloud(cloud)
 
-	client := noVerifyTLSHttpClient
-	if mTLSHttpClient != nil {
-		client = mTLSHttpClient
-	}
+	client := httpClientFromContext(ctx)
 
 	urlParams.Set("wt", "json")
 
-	cloudUrl = cloudUrl + "/solr/admin/collections?" + urlParams.Encode()
+	cloudUrl = cloudUrl + urlPath + "?" + urlParams.Encode()
 
 	resp := &http.Response{}
 
-	req, err := http.NewRequest("GET", cloudUrl, nil)
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, urlMethod, cloudUrl, reqBody); err != nil {
+		return err
+	}
 
 	// Any custom HTTP headers passed through the Context
 	if httpHeaders, hasHeaders := ctx.Value(HTTP_HEADERS_CONTEXT_KEY).(map[string]string); hasHeaders {
@@ -163,6 +187,9 @@ func CallCollectionsApi(ctx context.Context, cloud *solr.SolrCloud, urlParams ur
 			req.Header.Add(key, header)
 		}
 	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
 
 	if resp, err = client.Do(req); err != nil {
 		return err
@@ -172,7 +199,9 @@ func CallCollectionsApi(ctx context.Context, cloud *solr.SolrCloud, urlParams ur
 
 	if err == nil && resp.StatusCode >= 400 {
 		b, _ := io.ReadAll(resp.Body)
-		err = errors.NewServiceUnavailable(fmt.Sprintf("Recieved bad response code of %d from solr with response: %s", resp.StatusCode, string(b)))
+		err = errors.NewServiceUnavailable(fmt.Sprintf("Received bad response code of %d from solr with response: %s", resp.StatusCode, string(b)))
+		// try to read the response, just in case Solr returned an error that we can read
+		json.NewDecoder(bytes.NewReader(b)).Decode(&response)
 	}
 
 	if err == nil {
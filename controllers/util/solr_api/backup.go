@@ -24,6 +24,9 @@ type SolrBackupListResponse struct {
 
 	// +optional
 	Backups []SolrBackupListInstance `json:"backups,omitempty"`
+
+	// +optional
+	Error *SolrErrorResponse `json:"error,omitempty"`
 }
 
 type SolrBackupListInstance struct {
@@ -31,4 +34,14 @@ type SolrBackupListInstance struct {
 
 	// +optional
 	BackupId int `json:"backupId,omitempty"`
+
+	// +optional
+	IndexFileCount int `json:"indexFileCount,omitempty"`
+
+	// +optional
+	IndexSizeMB float64 `json:"indexSizeMB,omitempty"`
+
+	// The version of Solr that took the backup
+	// +optional
+	IndexVersion string `json:"indexVersion,omitempty"`
 }
@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package solr_api
+
+import (
+	"context"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"net/http"
+	"net/url"
+)
+
+type SolrConfigSetsResponse struct {
+	ResponseHeader SolrResponseHeader `json:"responseHeader"`
+
+	// +optional
+	Error *SolrErrorResponse `json:"error,omitempty"`
+}
+
+type SolrConfigSetsListResponse struct {
+	ResponseHeader SolrResponseHeader `json:"responseHeader"`
+
+	// +optional
+	ConfigSets []string `json:"configSets,omitempty"`
+
+	// +optional
+	Error *SolrErrorResponse `json:"error,omitempty"`
+}
+
+// ListConfigSets returns the names of all configsets that exist in Solr
+func ListConfigSets(ctx context.Context, cloud *solr.SolrCloud) (configSets []string, err error) {
+	queryParams := url.Values{}
+	queryParams.Set("action", "LIST")
+
+	resp := &SolrConfigSetsListResponse{}
+	err = CallConfigSetsApi(ctx, cloud, http.MethodGet, queryParams, nil, resp)
+	if _, apiErr := CheckForCollectionsApiError("LIST", resp.ResponseHeader, resp.Error); apiErr != nil {
+		err = apiErr
+	}
+	return resp.ConfigSets, err
+}
+
+// UploadConfigSet uploads a zipped configset to Solr, replacing the existing configset with the same name
+func UploadConfigSet(ctx context.Context, cloud *solr.SolrCloud, configSetName string, zippedConfigSet []byte) (err error) {
+	queryParams := url.Values{}
+	queryParams.Set("action", "UPLOAD")
+	queryParams.Set("name", configSetName)
+	queryParams.Set("overwrite", "true")
+	queryParams.Set("cleanup", "true")
+
+	resp := &SolrConfigSetsResponse{}
+	err = CallConfigSetsApi(ctx, cloud, http.MethodPost, queryParams, zippedConfigSet, resp)
+	if _, apiErr := CheckForCollectionsApiError("UPLOAD", resp.ResponseHeader, resp.Error); apiErr != nil {
+		err = apiErr
+	}
+	return err
+}
+
+func CallConfigSetsApi(ctx context.Context, cloud *solr.SolrCloud, urlMethod string, urlParams url.Values, body []byte, response interface{}) (err error) {
+	return callSolrAdminApi(ctx, cloud, urlMethod, "/solr/admin/configs", urlParams, body, response)
+}
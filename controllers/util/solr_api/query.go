@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package solr_api
+
+import (
+	"context"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"net/http"
+	"net/url"
+)
+
+type SolrQueryResponse struct {
+	ResponseHeader SolrResponseHeader `json:"responseHeader"`
+
+	// +optional
+	Response SolrQueryResult `json:"response,omitempty"`
+
+	// +optional
+	Error *SolrErrorResponse `json:"error,omitempty"`
+}
+
+type SolrQueryResult struct {
+	NumFound int64 `json:"numFound"`
+}
+
+// CountDocuments returns the number of documents in the collection that match the query
+func CountDocuments(ctx context.Context, cloud *solr.SolrCloud, collection string, query string) (numFound int64, err error) {
+	response := &SolrQueryResponse{}
+	queryParams := url.Values{}
+	queryParams.Set("q", query)
+	queryParams.Set("rows", "0")
+	queryParams.Set("wt", "json")
+	err = CallCollectionsApiV2(ctx, cloud, http.MethodGet, "/solr/"+url.PathEscape(collection)+"/select", queryParams, nil, response)
+	if response.Error != nil {
+		// Prefer the error returned by Solr, such as the collection not existing, over the HTTP status error
+		err = *response.Error
+	}
+	return response.Response.NumFound, err
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package solr_api
+
+import (
+	"context"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"net/http"
+)
+
+const (
+	SecurityAuthenticationPath = "/solr/admin/authentication"
+	SecurityAuthorizationPath  = "/solr/admin/authorization"
+)
+
+type SolrSecurityConfigResponse struct {
+	ResponseHeader SolrResponseHeader `json:"responseHeader"`
+
+	// +optional
+	Authentication *SolrAuthenticationConfig `json:"authentication,omitempty"`
+
+	// +optional
+	Authorization *SolrAuthorizationConfig `json:"authorization,omitempty"`
+
+	// +optional
+	ErrorMessages []interface{} `json:"errorMessages,omitempty"`
+}
+
+type SolrAuthenticationConfig struct {
+	Class string `json:"class"`
+
+	// The password hash and salt of each user of the BasicAuthPlugin
+	// +optional
+	Credentials map[string]string `json:"credentials,omitempty"`
+}
+
+type SolrAuthorizationConfig struct {
+	Class string `json:"class"`
+
+	// The roles of each user, either a single role or a list of roles
+	// +optional
+	UserRole map[string]interface{} `json:"user-role,omitempty"`
+
+	// +optional
+	Permissions []map[string]interface{} `json:"permissions,omitempty"`
+}
+
+// GetSecurityConfig returns the live authentication and authorization config of the SolrCloud, from the given Security API path
+func GetSecurityConfig(ctx context.Context, cloud *solr.SolrCloud, path string) (*SolrSecurityConfigResponse, error) {
+	response := &SolrSecurityConfigResponse{}
+	err := CallCollectionsApiV2(ctx, cloud, http.MethodGet, path, nil, nil, response)
+	return response, err
+}
+
+// EditSecurityConfig sends a single command, such as "set-user" or "set-permission", to the given Security API path
+func EditSecurityConfig(ctx context.Context, cloud *solr.SolrCloud, path string, command map[string]interface{}) error {
+	response := &SolrSecurityConfigResponse{}
+	return CallCollectionsApiV2(ctx, cloud, http.MethodPost, path, nil, command, response)
+}
@@ -39,10 +39,7 @@ type SolrRebalanceRequest struct {
 }
 
 func CallCollectionsApiV2(ctx context.Context, cloud *solr.SolrCloud, urlMethod string, urlPath string, urlParams url.Values, body interface{}, response interface{}) (err error) {
-	client := noVerifyTLSHttpClient
-	if mTLSHttpClient != nil {
-		client = mTLSHttpClient
-	}
+	client := httpClientFromContext(ctx)
 
 	cloudUrl := solr.InternalURLForCloud(cloud)
 	if !strings.HasPrefix(urlPath, "/") {
@@ -56,12 +53,14 @@ func CallCollectionsApiV2(ctx context.Context, cloud *solr.SolrCloud, urlMethod
 
 	resp := &http.Response{}
 
-	var b *bytes.Buffer
+	// A nil *bytes.Buffer cannot be used as the request body, so only set it when there is a body to send
+	var b io.Reader
 	if body != nil {
-		b = new(bytes.Buffer)
-		if err = json.NewEncoder(b).Encode(body); err != nil {
+		buf := new(bytes.Buffer)
+		if err = json.NewEncoder(buf).Encode(body); err != nil {
 			return
 		}
+		b = buf
 	}
 	var req *http.Request
 	if req, err = http.NewRequestWithContext(ctx, urlMethod, cloudUrl, b); err != nil {
@@ -85,7 +84,7 @@ func CallCollectionsApiV2(ctx context.Context, cloud *solr.SolrCloud, urlMethod
 
 	if err == nil && resp.StatusCode >= 400 {
 		b, _ := io.ReadAll(resp.Body)
-		err = errors.NewServiceUnavailable(fmt.Sprintf("Recieved bad response code of %d from solr with response: %s", resp.StatusCode, string(b)))
+		err = errors.NewServiceUnavailable(fmt.Sprintf("Received bad response code of %d from solr with response: %s", resp.StatusCode, string(b)))
 		// try to read the response, just in case Solr returned an error that we can read
 		json.NewDecoder(bytes.NewReader(b)).Decode(&response)
 	}
@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// The maximum periodSeconds allowed by Kubernetes for HPA scaling policies
+	maxAutoscalerPolicyPeriodSeconds = int32(1800)
+)
+
+// GenerateHorizontalPodAutoscaler returns a new HorizontalPodAutoscaler that targets the scale subresource of the given SolrCloud.
+// The HPA only changes spec.replicas of the SolrCloud, the Solr Operator is responsible for safely adding and removing Solr Nodes.
+// Nil is returned if the SolrCloud does not have an autoscaler.
+func GenerateHorizontalPodAutoscaler(cloud *solr.SolrCloud) *autoscalingv2.HorizontalPodAutoscaler {
+	opts := cloud.Spec.Scaling.Autoscaler
+	if opts == nil {
+		return nil
+	}
+
+	var metrics []autoscalingv2.MetricSpec
+	if opts.Metrics.CPUUtilizationPercentage != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: opts.Metrics.CPUUtilizationPercentage,
+				},
+			},
+		})
+	}
+	for _, podMetric := range []*solr.SolrAutoscalerPodMetric{opts.Metrics.QueryRate, opts.Metrics.DiskUsage} {
+		if podMetric == nil {
+			continue
+		}
+		targetValue := podMetric.TargetAverageValue.DeepCopy()
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name: podMetric.MetricName,
+				},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &targetValue,
+				},
+			},
+		})
+	}
+
+	// Solr Nodes are removed one at a time, since the replicas of each Solr Node need to be moved before it can be deleted.
+	scaleDownPeriod := maxAutoscalerPolicyPeriodSeconds
+	if opts.ScaleDownCooldownSeconds != nil && *opts.ScaleDownCooldownSeconds < scaleDownPeriod {
+		scaleDownPeriod = *opts.ScaleDownCooldownSeconds
+	}
+	if scaleDownPeriod < 1 {
+		scaleDownPeriod = 1
+	}
+	// All fields are explicitly set, including the Kubernetes defaults for scaling up, so that the HPA is not constantly updated
+	scaleUpCooldown := int32(0)
+	if opts.ScaleUpCooldownSeconds != nil {
+		scaleUpCooldown = *opts.ScaleUpCooldownSeconds
+	}
+	selectPolicy := autoscalingv2.MaxChangePolicySelect
+	behavior := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: &scaleUpCooldown,
+			SelectPolicy:               &selectPolicy,
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{
+					Type:          autoscalingv2.PercentScalingPolicy,
+					Value:         100,
+					PeriodSeconds: 15,
+				},
+				{
+					Type:          autoscalingv2.PodsScalingPolicy,
+					Value:         4,
+					PeriodSeconds: 15,
+				},
+			},
+		},
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: opts.ScaleDownCooldownSeconds,
+			SelectPolicy:               &selectPolicy,
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{
+					Type:          autoscalingv2.PodsScalingPolicy,
+					Value:         1,
+					PeriodSeconds: scaleDownPeriod,
+				},
+			},
+		},
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cloud.StatefulSetName(),
+			Namespace: cloud.Namespace,
+			Labels:    cloud.SharedLabels(),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: solr.GroupVersion.String(),
+				Kind:       "SolrCloud",
+				Name:       cloud.Name,
+			},
+			MinReplicas: opts.MinReplicas,
+			MaxReplicas: opts.MaxReplicas,
+			Metrics:     metrics,
+			Behavior:    behavior,
+		},
+	}
+}
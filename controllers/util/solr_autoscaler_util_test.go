@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestGenerateHorizontalPodAutoscaler(t *testing.T) {
+	cpu := int32(70)
+	scaleDownCooldown := int32(3600)
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solr.SolrCloudSpec{
+			Scaling: solr.SolrScalingOptions{
+				Autoscaler: &solr.SolrAutoscalerOptions{
+					MaxReplicas: 10,
+					Metrics: solr.SolrAutoscalerMetrics{
+						CPUUtilizationPercentage: &cpu,
+						DiskUsage:                &solr.SolrAutoscalerPodMetric{TargetAverageValue: resource.MustParse("50Gi")},
+					},
+					ScaleDownCooldownSeconds: &scaleDownCooldown,
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+
+	hpa := GenerateHorizontalPodAutoscaler(solrCloud)
+	assert.Equal(t, solrCloud.StatefulSetName(), hpa.Name, "Wrong HPA name")
+	assert.Equal(t, autoscalingv2.CrossVersionObjectReference{APIVersion: "solr.apache.org/v1beta1", Kind: "SolrCloud", Name: "foo"}, hpa.Spec.ScaleTargetRef, "HPA should target the SolrCloud")
+	assert.Equal(t, solr.DefaultAutoscalerMinReplicas, *hpa.Spec.MinReplicas, "Wrong HPA minReplicas")
+	assert.EqualValues(t, 10, hpa.Spec.MaxReplicas, "Wrong HPA maxReplicas")
+
+	if assert.Len(t, hpa.Spec.Metrics, 2, "Wrong number of HPA metrics") {
+		assert.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name, "First metric should be CPU")
+		assert.Equal(t, cpu, *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization, "Wrong CPU utilization target")
+		assert.Equal(t, solr.DefaultAutoscalerDiskUsageMetricName, hpa.Spec.Metrics[1].Pods.Metric.Name, "Second metric should be the default disk usage metric")
+		assert.Equal(t, "50Gi", hpa.Spec.Metrics[1].Pods.Target.AverageValue.String(), "Wrong disk usage target")
+	}
+
+	assert.EqualValues(t, 0, *hpa.Spec.Behavior.ScaleUp.StabilizationWindowSeconds, "Scale up cooldown should default to 0")
+	assert.Equal(t, scaleDownCooldown, *hpa.Spec.Behavior.ScaleDown.StabilizationWindowSeconds, "Wrong scale down cooldown")
+	if assert.Len(t, hpa.Spec.Behavior.ScaleDown.Policies, 1, "Wrong number of scale down policies") {
+		assert.EqualValues(t, 1, hpa.Spec.Behavior.ScaleDown.Policies[0].Value, "Only 1 Solr Node should be removed at a time")
+		assert.EqualValues(t, 1800, hpa.Spec.Behavior.ScaleDown.Policies[0].PeriodSeconds, "Scale down period should be capped at the Kubernetes maximum")
+	}
+}
+
+func TestGenerateHorizontalPodAutoscalerWithoutAutoscaler(t *testing.T) {
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+
+	assert.Nil(t, solrCloud.Spec.Scaling.Autoscaler, "The autoscaler should not be enabled by default")
+	assert.Nil(t, GenerateHorizontalPodAutoscaler(solrCloud), "No HPA should be generated for a SolrCloud without an autoscaler")
+}
@@ -30,6 +30,7 @@ const (
 
 	GCSCredentialSecretKey = "service-account-key.json"
 	S3CredentialFileName   = "credentials"
+	HdfsKeytabFileName     = "hdfs.keytab"
 
 	SolrBackupRepositoriesAnnotation = "solr.apache.org/backupRepositories"
 )
@@ -61,6 +62,10 @@ func S3RepoSecretMountPath(repo *solrv1beta1.SolrBackupRepository) string {
 	return fmt.Sprintf("%s/%s/%s", BaseBackupRestorePath, repo.Name, "s3credential")
 }
 
+func HdfsRepoConfigMountPath(repo *solrv1beta1.SolrBackupRepository) string {
+	return fmt.Sprintf("%s/%s/%s", BaseBackupRestorePath, repo.Name, "hdfsconfig")
+}
+
 func VolumeRepoVolumeMountPath(repo *solrv1beta1.SolrBackupRepository) string {
 	return fmt.Sprintf("%s/%s", BaseBackupRestorePath, repo.Name)
 }
@@ -100,6 +105,36 @@ func RepoVolumeSourceAndMount(repo *solrv1beta1.SolrBackupRepository, solrCloudN
 			MountPath: S3RepoSecretMountPath(repo),
 			ReadOnly:  true,
 		}
+	} else if repo.HDFS != nil && (repo.HDFS.HadoopConfigMap != "" || repo.HDFS.Kerberos != nil) {
+		// The Hadoop configuration files and the Kerberos keytab are projected into the same directory
+		projections := make([]corev1.VolumeProjection, 0, 2)
+		if repo.HDFS.HadoopConfigMap != "" {
+			projections = append(projections, corev1.VolumeProjection{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: repo.HDFS.HadoopConfigMap},
+					Optional:             &f,
+				},
+			})
+		}
+		if repo.HDFS.Kerberos != nil {
+			projections = append(projections, corev1.VolumeProjection{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: repo.HDFS.Kerberos.KeytabSecret.LocalObjectReference,
+					Items:                []corev1.KeyToPath{{Key: repo.HDFS.Kerberos.KeytabSecret.Key, Path: HdfsKeytabFileName}},
+					Optional:             &f,
+				},
+			})
+		}
+		source = &corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources:     projections,
+				DefaultMode: &SecretReadOnlyPermissions,
+			},
+		}
+		mount = &corev1.VolumeMount{
+			MountPath: HdfsRepoConfigMountPath(repo),
+			ReadOnly:  true,
+		}
 	}
 	if mount != nil {
 		mount.Name = RepoVolumeName(repo)
@@ -112,6 +147,10 @@ func RepoSolrModules(repo *solrv1beta1.SolrBackupRepository) (libs []string) {
 		libs = []string{"gcs-repository"}
 	} else if repo.S3 != nil {
 		libs = []string{"s3-repository"}
+	} else if repo.Azure != nil {
+		libs = []string{"azure-blob-repository"}
+	} else if repo.HDFS != nil {
+		libs = []string{"hdfs"}
 	}
 	return
 }
@@ -150,6 +189,36 @@ func RepoXML(repo *solrv1beta1.SolrBackupRepository) (xml string) {
     <str name="s3.region">%s</str>
     %s
 </repository>`, repo.Name, repo.S3.Bucket, repo.S3.Region, strings.Join(s3Extras, `
+    `))
+	} else if repo.Azure != nil {
+		azureExtras := make([]string, 0)
+		if repo.Azure.Endpoint != "" {
+			azureExtras = append(azureExtras, fmt.Sprintf("<str name=\"azure.blob.endpoint\">%s</str>", repo.Azure.Endpoint))
+		}
+		xml = fmt.Sprintf(`
+<repository name="%s" class="org.apache.solr.azureblob.AzureBlobBackupRepository">
+    <str name="azure.blob.container.name">%s</str>
+    <str name="azure.blob.account.name">%s</str>
+    %s
+</repository>`, repo.Name, repo.Azure.Container, repo.Azure.AccountName, strings.Join(azureExtras, `
+    `))
+	} else if repo.HDFS != nil {
+		hdfsExtras := make([]string, 0)
+		if repo.HDFS.HadoopConfigMap != "" {
+			hdfsExtras = append(hdfsExtras, fmt.Sprintf("<str name=\"solr.hdfs.confdir\">%s</str>", HdfsRepoConfigMountPath(repo)))
+		}
+		if repo.HDFS.Kerberos != nil {
+			hdfsExtras = append(hdfsExtras,
+				"<bool name=\"solr.hdfs.security.kerberos.enabled\">true</bool>",
+				fmt.Sprintf("<str name=\"solr.hdfs.security.kerberos.keytabfile\">%s/%s</str>", HdfsRepoConfigMountPath(repo), HdfsKeytabFileName),
+				fmt.Sprintf("<str name=\"solr.hdfs.security.kerberos.principal\">%s</str>", repo.HDFS.Kerberos.Principal),
+			)
+		}
+		xml = fmt.Sprintf(`
+<repository name="%s" class="org.apache.solr.hdfs.backup.HdfsBackupRepository">
+    <str name="solr.hdfs.home">%s</str>
+    %s
+</repository>`, repo.Name, HdfsRepoHome(repo), strings.Join(hdfsExtras, `
     `))
 	}
 	return
@@ -184,6 +253,21 @@ func RepoEnvVars(repo *solrv1beta1.SolrBackupRepository) (envVars []corev1.EnvVa
 			})
 		}
 	}
+	if repo.Azure != nil && repo.Azure.Credentials != nil {
+		// Env Var names match those used by the Azure CLI: https://learn.microsoft.com/en-us/cli/azure/storage
+		if repo.Azure.Credentials.AccountKeySecret != nil {
+			envVars = append(envVars, corev1.EnvVar{
+				Name:      "AZURE_STORAGE_KEY",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: repo.Azure.Credentials.AccountKeySecret},
+			})
+		}
+		if repo.Azure.Credentials.SasTokenSecret != nil {
+			envVars = append(envVars, corev1.EnvVar{
+				Name:      "AZURE_STORAGE_SAS_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: repo.Azure.Credentials.SasTokenSecret},
+			})
+		}
+	}
 	return envVars
 }
 
@@ -208,6 +292,15 @@ func GenerateBackupRepositoriesForSolrXml(backupRepos []solrv1beta1.SolrBackupRe
 	return
 }
 
+// HdfsRepoHome returns the full URI of the HDFS path that the repository stores its data in.
+func HdfsRepoHome(repo *solrv1beta1.SolrBackupRepository) string {
+	basePath := repo.HDFS.BasePath
+	if !strings.HasPrefix(basePath, "/") {
+		basePath = "/" + basePath
+	}
+	return repo.HDFS.NameNodeUri + basePath
+}
+
 func BackupLocationPath(repo *solrv1beta1.SolrBackupRepository, backupLocation string) string {
 	if repo.Volume != nil {
 		if backupLocation == "" {
@@ -228,6 +321,21 @@ func BackupLocationPath(repo *solrv1beta1.SolrBackupRepository, backupLocation s
 		} else {
 			return "/"
 		}
+	} else if repo.Azure != nil {
+		if backupLocation != "" {
+			return backupLocation
+		} else if repo.Azure.BaseLocation != "" {
+			return repo.Azure.BaseLocation
+		} else {
+			return "/"
+		}
+	} else if repo.HDFS != nil {
+		// Relative locations are resolved against the solr.hdfs.home of the repository
+		if backupLocation != "" {
+			return backupLocation
+		} else {
+			return HdfsRepoHome(repo)
+		}
 	}
 	return backupLocation
 }
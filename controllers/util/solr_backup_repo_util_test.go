@@ -136,6 +136,165 @@ func TestS3RepoSolrModules(t *testing.T) {
 	assert.EqualValues(t, []string{"s3-repository"}, RepoSolrModules(repo), "S3 Repos require the s3-repository solr module")
 }
 
+func TestAzureRepoXML(t *testing.T) {
+	repo := &solr.SolrBackupRepository{
+		Name: "repo1",
+		Azure: &solr.AzureRepository{
+			Container:   "some-container-name1",
+			AccountName: "someaccount",
+		},
+	}
+	assert.EqualValuesf(t, `
+<repository name="repo1" class="org.apache.solr.azureblob.AzureBlobBackupRepository">
+    <str name="azure.blob.container.name">some-container-name1</str>
+    <str name="azure.blob.account.name">someaccount</str>
+    
+</repository>`, RepoXML(repo), "Wrong SolrXML entry for the Azure Repo")
+
+	// Test with an Azurite endpoint
+	repo.Azure.Endpoint = "http://azurite:10000/devstoreaccount1"
+	assert.EqualValuesf(t, `
+<repository name="repo1" class="org.apache.solr.azureblob.AzureBlobBackupRepository">
+    <str name="azure.blob.container.name">some-container-name1</str>
+    <str name="azure.blob.account.name">someaccount</str>
+    <str name="azure.blob.endpoint">http://azurite:10000/devstoreaccount1</str>
+</repository>`, RepoXML(repo), "Wrong SolrXML entry for the Azure Repo with an endpoint set")
+}
+
+func TestAzureRepoEnvVars(t *testing.T) {
+	repo := &solr.SolrBackupRepository{
+		Name: "repo1",
+		Azure: &solr.AzureRepository{
+			Container:   "some-container-name1",
+			AccountName: "someaccount",
+		},
+	}
+	assert.Empty(t, RepoEnvVars(repo), "Azure Repos without credentials should have no env vars")
+
+	accountKey := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "azure-secret"}, Key: "account-key"}
+	repo.Azure.Credentials = &solr.AzureCredentials{AccountKeySecret: accountKey}
+	assert.EqualValues(t, []corev1.EnvVar{{Name: "AZURE_STORAGE_KEY", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: accountKey}}}, RepoEnvVars(repo), "Wrong env vars for an Azure account key")
+
+	sasToken := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "azure-secret"}, Key: "sas-token"}
+	repo.Azure.Credentials = &solr.AzureCredentials{SasTokenSecret: sasToken}
+	assert.EqualValues(t, []corev1.EnvVar{{Name: "AZURE_STORAGE_SAS_TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: sasToken}}}, RepoEnvVars(repo), "Wrong env vars for an Azure SAS token")
+
+	source, mount := RepoVolumeSourceAndMount(repo, "cloud")
+	assert.Nil(t, source, "Azure Repos should not need a volume, since the credentials are passed through env vars")
+	assert.Nil(t, mount, "Azure Repos should not need a volume mount, since the credentials are passed through env vars")
+}
+
+func TestAzureRepoSolrModules(t *testing.T) {
+	repo := &solr.SolrBackupRepository{
+		Name: "repo1",
+		Azure: &solr.AzureRepository{
+			Container:   "some-container-name1",
+			AccountName: "someaccount",
+		},
+	}
+	assert.EqualValues(t, []string{"azure-blob-repository"}, RepoSolrModules(repo), "Azure Repos require the azure-blob-repository solr module")
+	assert.Empty(t, AdditionalRepoLibs(repo), "Azure Repos require no additional libraries for Solr")
+}
+
+func TestAzureRepoBackupLocation(t *testing.T) {
+	repo := &solr.SolrBackupRepository{
+		Name: "repo1",
+		Azure: &solr.AzureRepository{
+			Container:   "some-container-name1",
+			AccountName: "someaccount",
+		},
+	}
+	assert.Equal(t, "/", BackupLocationPath(repo, ""), "Wrong default backup location for the Azure Repo")
+	repo.Azure.BaseLocation = "/base"
+	assert.Equal(t, "/base", BackupLocationPath(repo, ""), "The base location should be used when no backup location is given")
+	assert.Equal(t, "/backups", BackupLocationPath(repo, "/backups"), "The backup location should override the base location")
+}
+
+func TestHdfsRepoXML(t *testing.T) {
+	repo := &solr.SolrBackupRepository{
+		Name: "repo1",
+		HDFS: &solr.HdfsRepository{
+			NameNodeUri: "hdfs://namenode:8020",
+		},
+	}
+	assert.EqualValuesf(t, `
+<repository name="repo1" class="org.apache.solr.hdfs.backup.HdfsBackupRepository">
+    <str name="solr.hdfs.home">hdfs://namenode:8020/</str>
+    
+</repository>`, RepoXML(repo), "Wrong SolrXML entry for the HDFS Repo")
+
+	// Test with a Hadoop config and Kerberos
+	repo.HDFS.BasePath = "solr/backups"
+	repo.HDFS.HadoopConfigMap = "hadoop-conf"
+	repo.HDFS.Kerberos = &solr.HdfsKerberosOptions{
+		Principal:    "solr/_HOST@EXAMPLE.COM",
+		KeytabSecret: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "solr-keytab"}, Key: "keytab"},
+	}
+	assert.EqualValuesf(t, `
+<repository name="repo1" class="org.apache.solr.hdfs.backup.HdfsBackupRepository">
+    <str name="solr.hdfs.home">hdfs://namenode:8020/solr/backups</str>
+    <str name="solr.hdfs.confdir">/var/solr/data/backup-restore/repo1/hdfsconfig</str>
+    <bool name="solr.hdfs.security.kerberos.enabled">true</bool>
+    <str name="solr.hdfs.security.kerberos.keytabfile">/var/solr/data/backup-restore/repo1/hdfsconfig/hdfs.keytab</str>
+    <str name="solr.hdfs.security.kerberos.principal">solr/_HOST@EXAMPLE.COM</str>
+</repository>`, RepoXML(repo), "Wrong SolrXML entry for the HDFS Repo with a Hadoop config and Kerberos")
+}
+
+func TestHdfsRepoVolumeSourceAndMount(t *testing.T) {
+	repo := &solr.SolrBackupRepository{
+		Name: "repo1",
+		HDFS: &solr.HdfsRepository{
+			NameNodeUri: "hdfs://namenode:8020",
+		},
+	}
+	source, mount := RepoVolumeSourceAndMount(repo, "cloud")
+	assert.Nil(t, source, "HDFS Repos without a Hadoop config or Kerberos should not need a volume")
+	assert.Nil(t, mount, "HDFS Repos without a Hadoop config or Kerberos should not need a volume mount")
+
+	repo.HDFS.HadoopConfigMap = "hadoop-conf"
+	repo.HDFS.Kerberos = &solr.HdfsKerberosOptions{
+		Principal:    "solr@EXAMPLE.COM",
+		KeytabSecret: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "solr-keytab"}, Key: "keytab"},
+	}
+	source, mount = RepoVolumeSourceAndMount(repo, "cloud")
+	if assert.NotNil(t, source, "HDFS Repos with a Hadoop config should have a volume") && assert.NotNil(t, source.Projected, "The HDFS volume should be a projected volume") {
+		if assert.Len(t, source.Projected.Sources, 2, "The Hadoop config and the keytab should both be projected") {
+			assert.Equal(t, "hadoop-conf", source.Projected.Sources[0].ConfigMap.Name, "Wrong ConfigMap projected for the Hadoop config")
+			assert.Equal(t, "solr-keytab", source.Projected.Sources[1].Secret.Name, "Wrong Secret projected for the Kerberos keytab")
+			assert.EqualValues(t, []corev1.KeyToPath{{Key: "keytab", Path: HdfsKeytabFileName}}, source.Projected.Sources[1].Secret.Items, "Wrong keytab item projected")
+		}
+	}
+	if assert.NotNil(t, mount, "HDFS Repos with a Hadoop config should have a volume mount") {
+		assert.Equal(t, RepoVolumeName(repo), mount.Name, "Wrong volume mount name")
+		assert.Equal(t, "/var/solr/data/backup-restore/repo1/hdfsconfig", mount.MountPath, "Wrong volume mount path")
+		assert.True(t, mount.ReadOnly, "The HDFS config volume should be mounted read-only")
+	}
+	assert.False(t, IsRepoVolume(repo), "HDFS Repos are not volume repos")
+}
+
+func TestHdfsRepoSolrModules(t *testing.T) {
+	repo := &solr.SolrBackupRepository{
+		Name: "repo1",
+		HDFS: &solr.HdfsRepository{
+			NameNodeUri: "hdfs://namenode:8020",
+		},
+	}
+	assert.EqualValues(t, []string{"hdfs"}, RepoSolrModules(repo), "HDFS Repos require the hdfs solr module")
+	assert.Empty(t, AdditionalRepoLibs(repo), "HDFS Repos require no additional libraries for Solr")
+}
+
+func TestHdfsRepoBackupLocation(t *testing.T) {
+	repo := &solr.SolrBackupRepository{
+		Name: "repo1",
+		HDFS: &solr.HdfsRepository{
+			NameNodeUri: "hdfs://namenode:8020",
+			BasePath:    "/solr/backups",
+		},
+	}
+	assert.Equal(t, "hdfs://namenode:8020/solr/backups", BackupLocationPath(repo, ""), "The repository home should be used when no backup location is given")
+	assert.Equal(t, "nightly", BackupLocationPath(repo, "nightly"), "The backup location should override the repository home")
+}
+
 func TestVolumeRepoXML(t *testing.T) {
 	repo := &solr.SolrBackupRepository{
 		Name: "volumerepository2",
@@ -0,0 +1,183 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sync"
+)
+
+// The HTTP clients using the operatorClientCert of each SolrCloud are kept between reconciles, so that connections are re-used.
+var operatorHttpClients sync.Map
+
+type cachedHttpClient struct {
+	fingerprint string
+	client      *http.Client
+}
+
+// Reconcile the security.json for Solr secured with cert auth.
+// Bootstraps an initial security.json config with the CertAuthPlugin, if not supplied by the user.
+func reconcileForCertAuth(ctx context.Context, client *client.Client, instance *solr.SolrCloud) (*SecurityConfig, error) {
+	sec := instance.Spec.SolrSecurity
+	security := &SecurityConfig{SolrSecurity: sec}
+
+	if sec.BootstrapSecurityJson != nil {
+		securityJson, err := loadSecretKeyValue(ctx, client, sec.BootstrapSecurityJson, instance.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		security.SecurityJson = securityJson
+		security.SecurityJsonSrc = &corev1.EnvVarSource{SecretKeyRef: sec.BootstrapSecurityJson}
+		return security, nil
+	}
+	if sec.OperatorClientCert == "" {
+		return nil, fmt.Errorf("invalid cert auth config, the 'operatorClientCert' is required unless you provide your own 'security.json'")
+	}
+
+	err := reconcileGeneratedSecurityJson(ctx, client, instance, security, func() ([]byte, error) {
+		certSecret, err := operatorClientCertSecret(ctx, client, instance)
+		if err != nil {
+			return nil, err
+		}
+		operatorCert, err := x509Certificate(certSecret)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(GenerateCertSecurityConfig(instance, operatorCert.Subject.String()))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return security, nil
+}
+
+// GenerateCertSecurityConfig generates the security.json for the CertAuthPlugin, with the same permissions as the security.json bootstrapped for basic auth.
+// The operatorPrincipal is the subject of the operatorClientCert, which is given the role that the Solr Operator needs.
+func GenerateCertSecurityConfig(solrCloud *solr.SolrCloud, operatorPrincipal string) map[string]interface{} {
+	userRoles := map[string][]string{}
+	if certOptions := solrCloud.Spec.SolrSecurity.Cert; certOptions != nil {
+		for user, roles := range certOptions.UserRoles {
+			userRoles[user] = roles
+		}
+	}
+	userRoles[operatorPrincipal] = append(append([]string{}, userRoles[operatorPrincipal]...), solrOperatorRole)
+
+	return map[string]interface{}{
+		"authentication": map[string]interface{}{
+			"class": "solr.CertAuthPlugin",
+		},
+		"authorization": map[string]interface{}{
+			"class":       "solr.RuleBasedAuthorizationPlugin",
+			"user-role":   userRoles,
+			"permissions": bootstrapPermissions(solrCloud),
+		},
+	}
+}
+
+// operatorHttpClient returns the HTTP client that presents the operatorClientCert of the SolrCloud.
+// The client is re-used across reconciles, until the Secret changes.
+func operatorHttpClient(ctx context.Context, client *client.Client, instance *solr.SolrCloud) (*http.Client, error) {
+	certSecret, err := operatorClientCertSecret(ctx, client, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := fmt.Sprintf("%x", sha256.Sum256(append(append(certSecret.Data[TLSCertKey], certSecret.Data[corev1.TLSPrivateKeyKey]...), certSecret.Data[TLSCACertKey]...)))
+	key := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+	if cached, isCached := operatorHttpClients.Load(key); isCached && cached.(*cachedHttpClient).fingerprint == fingerprint {
+		return cached.(*cachedHttpClient).client, nil
+	}
+
+	httpClient, err := newClientCertHttpClient(certSecret)
+	if err != nil {
+		return nil, err
+	}
+	if replaced, wasCached := operatorHttpClients.Swap(key, &cachedHttpClient{fingerprint: fingerprint, client: httpClient}); wasCached {
+		// Close the connections that were opened with the old certificate, since the replaced client is no longer used
+		replaced.(*cachedHttpClient).client.CloseIdleConnections()
+	}
+	return httpClient, nil
+}
+
+// ForgetOperatorHttpClient closes and removes the cached HTTP client of a SolrCloud, once the SolrCloud has been deleted.
+func ForgetOperatorHttpClient(solrCloud types.NamespacedName) {
+	if cached, wasCached := operatorHttpClients.LoadAndDelete(solrCloud); wasCached {
+		cached.(*cachedHttpClient).client.CloseIdleConnections()
+	}
+}
+
+// newClientCertHttpClient builds an HTTP client that presents the client certificate in the Secret.
+// The certificates of the Solr pods are only verified if the Secret contains a CA certificate.
+func newClientCertHttpClient(certSecret *corev1.Secret) (*http.Client, error) {
+	clientCert, err := tls.X509KeyPair(certSecret.Data[TLSCertKey], certSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate in the operatorClientCert secret %s: %w", certSecret.Name, err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates:       []tls.Certificate{clientCert},
+		InsecureSkipVerify: true,
+	}
+	if caCert, hasCA := certSecret.Data[TLSCACertKey]; hasCA && len(caCert) > 0 {
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("invalid %s in the operatorClientCert secret %s", TLSCACertKey, certSecret.Name)
+		}
+		tlsConfig.RootCAs = caCertPool
+		tlsConfig.InsecureSkipVerify = false
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
+
+func operatorClientCertSecret(ctx context.Context, client *client.Client, instance *solr.SolrCloud) (*corev1.Secret, error) {
+	reader := *client
+	certSecret := &corev1.Secret{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: instance.Spec.SolrSecurity.OperatorClientCert, Namespace: instance.Namespace}, certSecret); err != nil {
+		return nil, err
+	}
+	if err := validateCredentialsSecretData(certSecret, solr.Cert, TLSCertKey, corev1.TLSPrivateKeyKey); err != nil {
+		return nil, err
+	}
+	return certSecret, nil
+}
+
+// x509Certificate parses the client certificate in the Secret, to find the user that Solr will see the operator as
+func x509Certificate(certSecret *corev1.Secret) (*x509.Certificate, error) {
+	clientCert, err := tls.X509KeyPair(certSecret.Data[TLSCertKey], certSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate in the operatorClientCert secret %s: %w", certSecret.Name, err)
+	}
+	return x509.ParseCertificate(clientCert.Certificate[0])
+}
+
+func contextWithHttpClient(ctx context.Context, httpClient *http.Client) context.Context {
+	return context.WithValue(ctx, solr_api.HTTP_CLIENT_CONTEXT_KEY, httpClient)
+}
@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"math/big"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+func TestClientCertHttpClient(t *testing.T) {
+	certPem, keyPem, err := generateTestClientCert("k8s-oper")
+	if !assert.NoError(t, err, "Could not generate a client certificate") {
+		return
+	}
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "operator-cert", Namespace: "default"},
+		Data: map[string][]byte{
+			TLSCertKey:              certPem,
+			corev1.TLSPrivateKeyKey: keyPem,
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+
+	operatorCert, err := x509Certificate(certSecret)
+	if assert.NoError(t, err, "The client certificate should be parsed") {
+		assert.Equal(t, "CN=k8s-oper,O=Solr Operator", operatorCert.Subject.String(), "Wrong subject, which Solr uses as the user")
+	}
+
+	httpClient, err := newClientCertHttpClient(certSecret)
+	if assert.NoError(t, err, "The HTTP client should be built") {
+		tlsConfig := httpClient.Transport.(*http.Transport).TLSClientConfig
+		assert.Len(t, tlsConfig.Certificates, 1, "The client certificate should be presented")
+		assert.True(t, tlsConfig.InsecureSkipVerify, "The Solr pods cannot be verified without a CA certificate")
+	}
+
+	certSecret.Data[TLSCACertKey] = certPem
+	httpClient, err = newClientCertHttpClient(certSecret)
+	if assert.NoError(t, err, "The HTTP client should be built with a CA certificate") {
+		tlsConfig := httpClient.Transport.(*http.Transport).TLSClientConfig
+		assert.False(t, tlsConfig.InsecureSkipVerify, "The Solr pods should be verified when a CA certificate is provided")
+		assert.NotNil(t, tlsConfig.RootCAs, "The CA certificate should be trusted")
+	}
+
+	certSecret.Data[TLSCACertKey] = []byte("not a cert")
+	_, err = newClientCertHttpClient(certSecret)
+	assert.Error(t, err, "An invalid CA certificate should be rejected")
+
+	certSecret.Data[corev1.TLSPrivateKeyKey] = []byte("not a key")
+	_, err = newClientCertHttpClient(certSecret)
+	assert.Error(t, err, "An invalid client key should be rejected")
+}
+
+func TestOperatorHttpClientCache(t *testing.T) {
+	certPem, keyPem, err := generateTestClientCert("k8s-oper")
+	if !assert.NoError(t, err, "Could not generate a client certificate") {
+		return
+	}
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "operator-cert", Namespace: "default"},
+		Data: map[string][]byte{
+			TLSCertKey:              certPem,
+			corev1.TLSPrivateKeyKey: keyPem,
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solr.SolrCloudSpec{
+			SolrSecurity: &solr.SolrSecurityOptions{AuthenticationType: solr.Cert, OperatorClientCert: "operator-cert"},
+		},
+	}
+	var k8sClient client.Client = fake.NewClientBuilder().WithObjects(certSecret).Build()
+	cloudKey := types.NamespacedName{Name: solrCloud.Name, Namespace: solrCloud.Namespace}
+	defer ForgetOperatorHttpClient(cloudKey)
+
+	firstClient, err := operatorHttpClient(context.Background(), &k8sClient, solrCloud)
+	if !assert.NoError(t, err, "The HTTP client should be built") {
+		return
+	}
+	cachedClient, err := operatorHttpClient(context.Background(), &k8sClient, solrCloud)
+	if assert.NoError(t, err, "The HTTP client should be returned from the cache") {
+		assert.Same(t, firstClient, cachedClient, "The HTTP client should be re-used while the Secret is unchanged")
+	}
+
+	certPem, keyPem, err = generateTestClientCert("k8s-oper")
+	if !assert.NoError(t, err, "Could not generate a client certificate") {
+		return
+	}
+	certSecret.Data = map[string][]byte{TLSCertKey: certPem, corev1.TLSPrivateKeyKey: keyPem}
+	if !assert.NoError(t, k8sClient.Update(context.Background(), certSecret), "Could not update the Secret") {
+		return
+	}
+	rotatedClient, err := operatorHttpClient(context.Background(), &k8sClient, solrCloud)
+	if assert.NoError(t, err, "The HTTP client should be rebuilt") {
+		assert.NotSame(t, firstClient, rotatedClient, "The HTTP client should be rebuilt once the Secret changes")
+	}
+
+	ForgetOperatorHttpClient(cloudKey)
+	_, isCached := operatorHttpClients.Load(cloudKey)
+	assert.False(t, isCached, "The HTTP client of a deleted SolrCloud should be removed from the cache")
+}
+
+func TestGenerateCertSecurityConfig(t *testing.T) {
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solr.SolrCloudSpec{
+			SolrSecurity: &solr.SolrSecurityOptions{
+				AuthenticationType: solr.Cert,
+				OperatorClientCert: "operator-cert",
+				Cert: &solr.SolrCertAuthOptions{
+					UserRoles: map[string][]string{"CN=alice,O=Example": {"admin"}},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+
+	config := GenerateCertSecurityConfig(solrCloud, "CN=k8s-oper,O=Solr Operator")
+	assert.Equal(t, map[string]interface{}{"class": "solr.CertAuthPlugin"}, config["authentication"], "Wrong authentication plugin")
+	authorization := config["authorization"].(map[string]interface{})
+	assert.Equal(t, "solr.RuleBasedAuthorizationPlugin", authorization["class"], "Wrong authorization plugin")
+	assert.Equal(t, map[string][]string{"CN=alice,O=Example": {"admin"}, "CN=k8s-oper,O=Solr Operator": {"k8s"}}, authorization["user-role"], "The operator's certificate subject should be given the k8s role")
+	permissions := authorization["permissions"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "k8s-probe-0", "role": nil, "collection": nil, "path": "/admin/info/system"}, permissions[0], "The probe endpoints should be open")
+
+	// The user-provided roles of the operator's subject are kept
+	solrCloud.Spec.SolrSecurity.Cert.UserRoles = map[string][]string{"CN=k8s-oper,O=Solr Operator": {"admin"}}
+	config = GenerateCertSecurityConfig(solrCloud, "CN=k8s-oper,O=Solr Operator")
+	assert.Equal(t, map[string][]string{"CN=k8s-oper,O=Solr Operator": {"admin", "k8s"}}, config["authorization"].(map[string]interface{})["user-role"], "The k8s role should be added to the operator's existing roles")
+}
+
+func generateTestClientCert(commonName string) (certPem []byte, keyPem []byte, err error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Solr Operator"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), nil
+}
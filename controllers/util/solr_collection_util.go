@@ -0,0 +1,333 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"fmt"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"github.com/go-logr/logr"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ShardReplicaAddition defines the number of replicas of each type that need to be added to a shard
+type ShardReplicaAddition struct {
+	Shard string
+	Nrt   int
+	Tlog  int
+	Pull  int
+}
+
+// ShardReplicaDeletion defines a replica that needs to be removed from a shard
+type ShardReplicaDeletion struct {
+	Shard   string
+	Replica string
+}
+
+// CollectionReplicaChanges holds the changes that need to be made to the shards and replicas of a collection,
+// so that it matches the SolrCollection spec
+type CollectionReplicaChanges struct {
+	ShardsToCreate   []string
+	ReplicasToAdd    []ShardReplicaAddition
+	ReplicasToDelete []ShardReplicaDeletion
+}
+
+// IsEmpty returns whether there are no changes to make to the collection
+func (changes CollectionReplicaChanges) IsEmpty() bool {
+	return len(changes.ShardsToCreate) == 0 && len(changes.ReplicasToAdd) == 0 && len(changes.ReplicasToDelete) == 0
+}
+
+func GenerateQueryParamsForCollectionCreate(collection *solr.SolrCollection) url.Values {
+	nrt, tlog, pull := collection.Spec.DesiredReplicasPerShard()
+
+	queryParams := url.Values{}
+	queryParams.Add("action", "CREATE")
+	queryParams.Add("name", collection.Spec.CollectionName)
+	if collection.Spec.ConfigSet != "" {
+		queryParams.Add("collection.configName", collection.Spec.ConfigSet)
+	}
+	if router := collection.Spec.Router; router != nil {
+		queryParams.Add("router.name", string(router.Name))
+		if router.Field != "" {
+			queryParams.Add("router.field", router.Field)
+		}
+		if router.Name == solr.ImplicitRouter {
+			queryParams.Add("shards", strings.Join(router.Shards, ","))
+		}
+	}
+	if collection.Spec.NumShards != nil && (collection.Spec.Router == nil || collection.Spec.Router.Name != solr.ImplicitRouter) {
+		queryParams.Add("numShards", strconv.Itoa(int(*collection.Spec.NumShards)))
+	}
+	queryParams.Add("nrtReplicas", strconv.Itoa(nrt))
+	queryParams.Add("tlogReplicas", strconv.Itoa(tlog))
+	queryParams.Add("pullReplicas", strconv.Itoa(pull))
+	queryParams.Add("waitForFinalState", "true")
+
+	return queryParams
+}
+
+// ChangedCollectionCreationOptions returns the names of the options in the SolrCollection spec that differ from
+// the options that the collection was created with. These options cannot be changed once the collection exists.
+func ChangedCollectionCreationOptions(collection *solr.SolrCollection) (changed []string) {
+	created := collection.Status.CreationOptions
+	if created == nil {
+		return nil
+	}
+	desired := collection.Spec.CreationOptions()
+	if created.NumShards != nil && desired.NumShards != nil && *created.NumShards != *desired.NumShards {
+		changed = append(changed, "numShards")
+	}
+	if created.RouterName != "" && created.RouterName != desired.RouterName {
+		changed = append(changed, "router.name")
+	}
+	if created.RouterField != desired.RouterField {
+		changed = append(changed, "router.field")
+	}
+	return changed
+}
+
+// DetermineCollectionReplicaChanges compares the current state of a collection in Solr against the SolrCollection spec.
+// Replicas that are not active, or are not leaders, are preferred when choosing replicas to delete.
+// The last active NRT or TLOG replica of a shard is never deleted, since PULL replicas cannot become leaders.
+// Shards that are not active (e.g. the parents of split shards) are ignored.
+func DetermineCollectionReplicaChanges(collection *solr.SolrCollection, collectionState *solr_api.SolrCollectionStatus, liveNodes []string) (changes CollectionReplicaChanges) {
+	nrt, tlog, pull := collection.Spec.DesiredReplicasPerShard()
+	desiredReplicas := map[solr_api.SolrReplicaType]int{
+		solr_api.NRT:  nrt,
+		solr_api.TLOG: tlog,
+		solr_api.PULL: pull,
+	}
+	liveNodeSet := make(map[string]bool, len(liveNodes))
+	for _, node := range liveNodes {
+		liveNodeSet[node] = true
+	}
+
+	if collection.Spec.Router != nil && collection.Spec.Router.Name == solr.ImplicitRouter {
+		for _, shard := range collection.Spec.Router.Shards {
+			if _, hasShard := collectionState.Shards[shard]; !hasShard {
+				changes.ShardsToCreate = append(changes.ShardsToCreate, shard)
+			}
+		}
+	}
+
+	shardNames := make([]string, 0, len(collectionState.Shards))
+	for shard := range collectionState.Shards {
+		shardNames = append(shardNames, shard)
+	}
+	sort.Strings(shardNames)
+
+	for _, shard := range shardNames {
+		shardState := collectionState.Shards[shard]
+		if shardState.State != "" && shardState.State != solr_api.ShardActive {
+			continue
+		}
+		replicasByType := map[solr_api.SolrReplicaType][]string{}
+		activeLeaderEligibleReplicas := 0
+		for replica, replicaState := range shardState.Replicas {
+			replicaType := replicaState.Type
+			if replicaType == "" {
+				replicaType = solr_api.NRT
+			}
+			replicasByType[replicaType] = append(replicasByType[replicaType], replica)
+			if replicaType != solr_api.PULL && isReplicaActive(replicaState, liveNodeSet) {
+				activeLeaderEligibleReplicas += 1
+			}
+		}
+
+		addition := ShardReplicaAddition{Shard: shard}
+		for _, replicaType := range []solr_api.SolrReplicaType{solr_api.NRT, solr_api.TLOG, solr_api.PULL} {
+			replicas := replicasByType[replicaType]
+			diff := desiredReplicas[replicaType] - len(replicas)
+			if diff > 0 {
+				switch replicaType {
+				case solr_api.NRT:
+					addition.Nrt = diff
+				case solr_api.TLOG:
+					addition.Tlog = diff
+				case solr_api.PULL:
+					addition.Pull = diff
+				}
+			} else if diff < 0 {
+				// Delete the replicas that are least useful first: non-active, then non-leader, then by name for consistency
+				sort.Slice(replicas, func(i, j int) bool {
+					ri, rj := shardState.Replicas[replicas[i]], shardState.Replicas[replicas[j]]
+					riActive := isReplicaActive(ri, liveNodeSet)
+					rjActive := isReplicaActive(rj, liveNodeSet)
+					if riActive != rjActive {
+						return !riActive
+					}
+					if ri.Leader != rj.Leader {
+						return !ri.Leader
+					}
+					return replicas[i] > replicas[j]
+				})
+				for _, replica := range replicas[:-diff] {
+					if replicaType != solr_api.PULL && isReplicaActive(shardState.Replicas[replica], liveNodeSet) {
+						if activeLeaderEligibleReplicas <= 1 {
+							continue
+						}
+						activeLeaderEligibleReplicas -= 1
+					}
+					changes.ReplicasToDelete = append(changes.ReplicasToDelete, ShardReplicaDeletion{Shard: shard, Replica: replica})
+				}
+			}
+		}
+		if addition.Nrt+addition.Tlog+addition.Pull > 0 {
+			changes.ReplicasToAdd = append(changes.ReplicasToAdd, addition)
+		}
+	}
+
+	return changes
+}
+
+// GenerateCollectionStatus builds the status of a SolrCollection from the state of the collection in Solr.
+func GenerateCollectionStatus(collectionName string, collectionState *solr_api.SolrCollectionStatus, liveNodes []string) (status solr.SolrCollectionStatus) {
+	liveNodeSet := make(map[string]bool, len(liveNodes))
+	for _, node := range liveNodes {
+		liveNodeSet[node] = true
+	}
+
+	status.CollectionName = collectionName
+	status.Created = true
+	status.ConfigSet = collectionState.ConfigName
+	status.Health = solr.CollectionHealthy
+
+	for shard, shardState := range collectionState.Shards {
+		shardStatus := solr.SolrCollectionShardStatus{
+			Name:  shard,
+			State: string(shardState.State),
+		}
+		// Only active shards are used to serve requests, so only they determine the health of the collection
+		countsTowardsHealth := shardState.State == "" || shardState.State == solr_api.ShardActive
+		hasActiveLeader := false
+		for replica, replicaState := range shardState.Replicas {
+			active := isReplicaActive(replicaState, liveNodeSet)
+			replicaStatus := solr.SolrCollectionReplicaStatus{
+				Name:     replica,
+				Core:     replicaState.Core,
+				NodeName: replicaState.NodeName,
+				Type:     string(replicaState.Type),
+				State:    string(replicaState.State),
+				Leader:   replicaState.Leader,
+			}
+			if !liveNodeSet[replicaState.NodeName] {
+				replicaStatus.State = string(solr_api.ReplicaDown)
+			}
+			if replicaState.Leader {
+				shardStatus.Leader = replica
+				hasActiveLeader = active
+			}
+			if countsTowardsHealth {
+				status.TotalReplicas += 1
+				if active {
+					status.ActiveReplicas += 1
+				} else if status.Health == solr.CollectionHealthy {
+					status.Health = solr.CollectionDegraded
+				}
+			}
+			shardStatus.Replicas = append(shardStatus.Replicas, replicaStatus)
+		}
+		if countsTowardsHealth && !hasActiveLeader {
+			status.Health = solr.CollectionDown
+		}
+		sort.Slice(shardStatus.Replicas, func(i, j int) bool {
+			return shardStatus.Replicas[i].Name < shardStatus.Replicas[j].Name
+		})
+		status.Shards = append(status.Shards, shardStatus)
+	}
+	sort.Slice(status.Shards, func(i, j int) bool {
+		return status.Shards[i].Name < status.Shards[j].Name
+	})
+	status.ReplicasReady = fmt.Sprintf("%d/%d", status.ActiveReplicas, status.TotalReplicas)
+
+	return status
+}
+
+func isReplicaActive(replicaState solr_api.SolrReplicaStatus, liveNodes map[string]bool) bool {
+	return replicaState.State == solr_api.ReplicaActive && liveNodes[replicaState.NodeName]
+}
+
+// GetSolrClusterStatus fetches the state of all collections, and the live nodes, of the SolrCloud
+func GetSolrClusterStatus(ctx context.Context, cloud *solr.SolrCloud) (clusterStatus solr_api.SolrClusterStatus, err error) {
+	clusterResp := &solr_api.SolrClusterStatusResponse{}
+	queryParams := url.Values{}
+	queryParams.Add("action", "CLUSTERSTATUS")
+	err = solr_api.CallCollectionsApi(ctx, cloud, queryParams, clusterResp)
+	if _, apiErr := solr_api.CheckForCollectionsApiError("CLUSTERSTATUS", clusterResp.ResponseHeader, clusterResp.Error); apiErr != nil {
+		err = apiErr
+	}
+	return clusterResp.ClusterStatus, err
+}
+
+func CreateCollection(ctx context.Context, cloud *solr.SolrCloud, collection *solr.SolrCollection, logger logr.Logger) (err error) {
+	logger.Info("Creating Solr collection", "solrCloud", cloud.Name, "collection", collection.Spec.CollectionName)
+	return callCollectionModificationApi(ctx, cloud, "CREATE", GenerateQueryParamsForCollectionCreate(collection))
+}
+
+func CreateShardForCollection(ctx context.Context, cloud *solr.SolrCloud, collection *solr.SolrCollection, shard string, logger logr.Logger) (err error) {
+	nrt, tlog, pull := collection.Spec.DesiredReplicasPerShard()
+	queryParams := url.Values{}
+	queryParams.Add("action", "CREATESHARD")
+	queryParams.Add("collection", collection.Spec.CollectionName)
+	queryParams.Add("shard", shard)
+	queryParams.Add("nrtReplicas", strconv.Itoa(nrt))
+	queryParams.Add("tlogReplicas", strconv.Itoa(tlog))
+	queryParams.Add("pullReplicas", strconv.Itoa(pull))
+	queryParams.Add("waitForFinalState", "true")
+
+	logger.Info("Creating shard for Solr collection", "solrCloud", cloud.Name, "collection", collection.Spec.CollectionName, "shard", shard)
+	return callCollectionModificationApi(ctx, cloud, "CREATESHARD", queryParams)
+}
+
+func AddReplicasToShard(ctx context.Context, cloud *solr.SolrCloud, collectionName string, addition ShardReplicaAddition, logger logr.Logger) (err error) {
+	queryParams := url.Values{}
+	queryParams.Add("action", "ADDREPLICA")
+	queryParams.Add("collection", collectionName)
+	queryParams.Add("shard", addition.Shard)
+	queryParams.Add("nrtReplicas", strconv.Itoa(addition.Nrt))
+	queryParams.Add("tlogReplicas", strconv.Itoa(addition.Tlog))
+	queryParams.Add("pullReplicas", strconv.Itoa(addition.Pull))
+	queryParams.Add("waitForFinalState", "true")
+
+	logger.Info("Adding replicas to Solr collection", "solrCloud", cloud.Name, "collection", collectionName, "shard", addition.Shard, "nrt", addition.Nrt, "tlog", addition.Tlog, "pull", addition.Pull)
+	return callCollectionModificationApi(ctx, cloud, "ADDREPLICA", queryParams)
+}
+
+func DeleteReplicaFromShard(ctx context.Context, cloud *solr.SolrCloud, collectionName string, deletion ShardReplicaDeletion, logger logr.Logger) (err error) {
+	queryParams := url.Values{}
+	queryParams.Add("action", "DELETEREPLICA")
+	queryParams.Add("collection", collectionName)
+	queryParams.Add("shard", deletion.Shard)
+	queryParams.Add("replica", deletion.Replica)
+
+	logger.Info("Deleting replica from Solr collection", "solrCloud", cloud.Name, "collection", collectionName, "shard", deletion.Shard, "replica", deletion.Replica)
+	return callCollectionModificationApi(ctx, cloud, "DELETEREPLICA", queryParams)
+}
+
+func callCollectionModificationApi(ctx context.Context, cloud *solr.SolrCloud, action string, queryParams url.Values) (err error) {
+	resp := &solr_api.SolrAsyncResponse{}
+	err = solr_api.CallCollectionsApi(ctx, cloud, queryParams, resp)
+	if _, apiErr := solr_api.CheckForCollectionsApiError(action, resp.ResponseHeader, resp.Error); apiErr != nil {
+		err = apiErr
+	}
+	return err
+}
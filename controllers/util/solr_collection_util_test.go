@@ -0,0 +1,269 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"testing"
+)
+
+func TestCollectionCreateApiParams(t *testing.T) {
+	collection := &solr.SolrCollection{
+		ObjectMeta: metav1.ObjectMeta{Name: "col1"},
+		Spec: solr.SolrCollectionSpec{
+			SolrCloud:    "solrcloudcluster",
+			ConfigSet:    "some-config",
+			NumShards:    pointer.Int32(3),
+			TlogReplicas: pointer.Int32(2),
+			PullReplicas: pointer.Int32(1),
+		},
+	}
+	collection.WithDefaults()
+
+	queryParams := GenerateQueryParamsForCollectionCreate(collection)
+
+	assert.Equalf(t, "CREATE", queryParams.Get("action"), "Wrong %s for Collections API Call", "action")
+	assert.Equalf(t, "col1", queryParams.Get("name"), "Wrong %s for Collections API Call", "collection name")
+	assert.Equalf(t, "some-config", queryParams.Get("collection.configName"), "Wrong %s for Collections API Call", "configset")
+	assert.Equalf(t, "compositeId", queryParams.Get("router.name"), "Wrong %s for Collections API Call", "router name")
+	assert.Equalf(t, "3", queryParams.Get("numShards"), "Wrong %s for Collections API Call", "numShards")
+	assert.Falsef(t, queryParams.Has("shards"), "The %s should not be set for the compositeId router", "shards")
+	assert.Equalf(t, "0", queryParams.Get("nrtReplicas"), "Wrong %s for Collections API Call, NRT replicas default to 0 when TLOG replicas are used", "nrtReplicas")
+	assert.Equalf(t, "2", queryParams.Get("tlogReplicas"), "Wrong %s for Collections API Call", "tlogReplicas")
+	assert.Equalf(t, "1", queryParams.Get("pullReplicas"), "Wrong %s for Collections API Call", "pullReplicas")
+}
+
+func TestCollectionCreateApiParamsForImplicitRouter(t *testing.T) {
+	collection := &solr.SolrCollection{
+		ObjectMeta: metav1.ObjectMeta{Name: "col1"},
+		Spec: solr.SolrCollectionSpec{
+			SolrCloud:         "solrcloudcluster",
+			CollectionName:    "some_collection",
+			ReplicationFactor: pointer.Int32(2),
+			Router: &solr.SolrCollectionRouterOptions{
+				Name:   solr.ImplicitRouter,
+				Field:  "shard_s",
+				Shards: []string{"a", "b"},
+			},
+		},
+	}
+	collection.WithDefaults()
+
+	queryParams := GenerateQueryParamsForCollectionCreate(collection)
+
+	assert.Equalf(t, "some_collection", queryParams.Get("name"), "Wrong %s for Collections API Call", "collection name")
+	assert.Falsef(t, queryParams.Has("collection.configName"), "The %s should not be set when not provided", "configset")
+	assert.Equalf(t, "implicit", queryParams.Get("router.name"), "Wrong %s for Collections API Call", "router name")
+	assert.Equalf(t, "shard_s", queryParams.Get("router.field"), "Wrong %s for Collections API Call", "router field")
+	assert.Equalf(t, "a,b", queryParams.Get("shards"), "Wrong %s for Collections API Call", "shards")
+	assert.Falsef(t, queryParams.Has("numShards"), "The %s should not be set for the implicit router", "numShards")
+	assert.Equalf(t, "2", queryParams.Get("nrtReplicas"), "Wrong %s for Collections API Call, should use the replicationFactor", "nrtReplicas")
+	assert.Equalf(t, "0", queryParams.Get("tlogReplicas"), "Wrong %s for Collections API Call", "tlogReplicas")
+}
+
+func TestChangedCollectionCreationOptions(t *testing.T) {
+	collection := &solr.SolrCollection{
+		ObjectMeta: metav1.ObjectMeta{Name: "col1"},
+		Spec: solr.SolrCollectionSpec{
+			SolrCloud: "solrcloudcluster",
+			NumShards: pointer.Int32(3),
+		},
+	}
+	collection.WithDefaults()
+
+	assert.Empty(t, ChangedCollectionCreationOptions(collection), "Nothing can have changed before the collection is created")
+
+	collection.Status.CreationOptions = collection.Spec.CreationOptions()
+	assert.Empty(t, ChangedCollectionCreationOptions(collection), "Nothing should have changed right after the collection is created")
+
+	collection.Spec.ReplicationFactor = pointer.Int32(2)
+	assert.Empty(t, ChangedCollectionCreationOptions(collection), "The replicas of a collection can be changed after it is created")
+
+	collection.Spec.NumShards = pointer.Int32(4)
+	collection.Spec.Router.Field = "shard_s"
+	assert.Equal(t, []string{"numShards", "router.field"}, ChangedCollectionCreationOptions(collection), "The numShards and router field cannot be changed after the collection is created")
+
+	collection.Spec.NumShards = nil
+	collection.Spec.Router = &solr.SolrCollectionRouterOptions{Name: solr.ImplicitRouter, Shards: []string{"a"}}
+	assert.Equal(t, []string{"router.name"}, ChangedCollectionCreationOptions(collection), "The router cannot be changed after the collection is created")
+
+	collection.Status.CreationOptions = collection.Spec.CreationOptions()
+	collection.Spec.Router.Shards = append(collection.Spec.Router.Shards, "b")
+	assert.Empty(t, ChangedCollectionCreationOptions(collection), "Shards can be added to a collection using the implicit router")
+}
+
+func TestDetermineCollectionReplicaChanges(t *testing.T) {
+	collection := &solr.SolrCollection{
+		ObjectMeta: metav1.ObjectMeta{Name: "col1"},
+		Spec: solr.SolrCollectionSpec{
+			SolrCloud:    "solrcloudcluster",
+			NrtReplicas:  pointer.Int32(2),
+			PullReplicas: pointer.Int32(1),
+		},
+	}
+	collection.WithDefaults()
+	liveNodes := []string{"node1", "node2", "node3"}
+
+	collectionState := &solr_api.SolrCollectionStatus{
+		Shards: map[string]solr_api.SolrShardStatus{
+			"shard1": {
+				State: solr_api.ShardActive,
+				Replicas: map[string]solr_api.SolrReplicaStatus{
+					"core_node1": {State: solr_api.ReplicaActive, NodeName: "node1", Type: solr_api.NRT, Leader: true},
+					"core_node2": {State: solr_api.ReplicaActive, NodeName: "node2", Type: solr_api.NRT},
+					"core_node3": {State: solr_api.ReplicaActive, NodeName: "node3", Type: solr_api.PULL},
+				},
+			},
+			"shard2": {
+				State: solr_api.ShardActive,
+				Replicas: map[string]solr_api.SolrReplicaStatus{
+					"core_node4": {State: solr_api.ReplicaActive, NodeName: "node1", Type: solr_api.NRT, Leader: true},
+				},
+			},
+			"shard3": {
+				State: solr_api.ShardActive,
+				Replicas: map[string]solr_api.SolrReplicaStatus{
+					"core_node5": {State: solr_api.ReplicaActive, NodeName: "node1", Type: solr_api.NRT},
+					"core_node6": {State: solr_api.ReplicaActive, NodeName: "node2", Type: solr_api.NRT, Leader: true},
+					"core_node7": {State: solr_api.ReplicaDown, NodeName: "node3", Type: solr_api.NRT},
+					"core_node8": {State: solr_api.ReplicaActive, NodeName: "node3", Type: solr_api.PULL},
+					"core_node9": {State: solr_api.ReplicaActive, NodeName: "node4", Type: solr_api.PULL},
+				},
+			},
+			// Inactive shards, such as the parents of split shards, should be ignored
+			"shard4": {
+				State: "inactive",
+				Replicas: map[string]solr_api.SolrReplicaStatus{
+					"core_node10": {State: solr_api.ReplicaActive, NodeName: "node1", Type: solr_api.NRT, Leader: true},
+				},
+			},
+		},
+	}
+
+	changes := DetermineCollectionReplicaChanges(collection, collectionState, liveNodes)
+
+	assert.Empty(t, changes.ShardsToCreate, "No shards should be created for the compositeId router")
+	assert.EqualValues(t, []ShardReplicaAddition{{Shard: "shard2", Nrt: 1, Pull: 1}}, changes.ReplicasToAdd, "Wrong replicas to add")
+	assert.EqualValues(t, []ShardReplicaDeletion{{Shard: "shard3", Replica: "core_node7"}, {Shard: "shard3", Replica: "core_node9"}}, changes.ReplicasToDelete, "Wrong replicas to delete, non-active replicas should be deleted first")
+	assert.False(t, changes.IsEmpty(), "Changes should not be empty")
+
+	// Implicit router shards that do not exist yet should be created
+	collection.Spec.Router = &solr.SolrCollectionRouterOptions{Name: solr.ImplicitRouter, Shards: []string{"shard1", "shard5"}}
+	changes = DetermineCollectionReplicaChanges(collection, collectionState, liveNodes)
+	assert.EqualValues(t, []string{"shard5"}, changes.ShardsToCreate, "Wrong shards to create for the implicit router")
+}
+
+func TestDetermineCollectionReplicaChangesKeepsLeaderEligibleReplicas(t *testing.T) {
+	collection := &solr.SolrCollection{
+		ObjectMeta: metav1.ObjectMeta{Name: "col1"},
+		Spec: solr.SolrCollectionSpec{
+			SolrCloud:    "solrcloudcluster",
+			NrtReplicas:  pointer.Int32(0),
+			PullReplicas: pointer.Int32(2),
+		},
+	}
+	collection.WithDefaults()
+	assert.False(t, collection.Spec.HasLeaderEligibleReplicas(), "A PULL-only collection cannot have shard leaders")
+	liveNodes := []string{"node1", "node2", "node3"}
+
+	collectionState := &solr_api.SolrCollectionStatus{
+		Shards: map[string]solr_api.SolrShardStatus{
+			"shard1": {
+				State: solr_api.ShardActive,
+				Replicas: map[string]solr_api.SolrReplicaStatus{
+					"core_node1": {State: solr_api.ReplicaActive, NodeName: "node1", Type: solr_api.NRT, Leader: true},
+					"core_node2": {State: solr_api.ReplicaActive, NodeName: "node2", Type: solr_api.NRT},
+					"core_node3": {State: solr_api.ReplicaDown, NodeName: "node3", Type: solr_api.NRT},
+				},
+			},
+			"shard2": {
+				State: solr_api.ShardActive,
+				Replicas: map[string]solr_api.SolrReplicaStatus{
+					"core_node4": {State: solr_api.ReplicaActive, NodeName: "node1", Type: solr_api.NRT, Leader: true},
+					"core_node5": {State: solr_api.ReplicaActive, NodeName: "node2", Type: solr_api.PULL},
+					"core_node6": {State: solr_api.ReplicaActive, NodeName: "node3", Type: solr_api.PULL},
+				},
+			},
+		},
+	}
+
+	changes := DetermineCollectionReplicaChanges(collection, collectionState, liveNodes)
+	assert.EqualValues(t, []ShardReplicaAddition{{Shard: "shard1", Pull: 2}}, changes.ReplicasToAdd, "Wrong replicas to add")
+	assert.EqualValues(t, []ShardReplicaDeletion{{Shard: "shard1", Replica: "core_node3"}, {Shard: "shard1", Replica: "core_node2"}}, changes.ReplicasToDelete, "The last active NRT replica of each shard, the leader, must never be deleted")
+
+	// The last active NRT replica can be deleted once a TLOG replica is active, which can become the leader instead
+	collection.Spec.TlogReplicas = pointer.Int32(1)
+	assert.True(t, collection.Spec.HasLeaderEligibleReplicas(), "A collection with TLOG replicas can have shard leaders")
+	shard2 := collectionState.Shards["shard2"]
+	shard2.Replicas["core_node7"] = solr_api.SolrReplicaStatus{State: solr_api.ReplicaActive, NodeName: "node2", Type: solr_api.TLOG}
+	changes = DetermineCollectionReplicaChanges(collection, collectionState, liveNodes)
+	assert.Contains(t, changes.ReplicasToDelete, ShardReplicaDeletion{Shard: "shard2", Replica: "core_node4"}, "The NRT leader should be deleted once an active TLOG replica exists")
+	assert.NotContains(t, changes.ReplicasToDelete, ShardReplicaDeletion{Shard: "shard1", Replica: "core_node1"}, "The NRT leader should not be deleted before a TLOG replica is active")
+}
+
+func TestGenerateCollectionStatus(t *testing.T) {
+	liveNodes := []string{"node1", "node2"}
+	collectionState := &solr_api.SolrCollectionStatus{
+		ConfigName: "some-config",
+		Shards: map[string]solr_api.SolrShardStatus{
+			"shard2": {
+				State: solr_api.ShardActive,
+				Replicas: map[string]solr_api.SolrReplicaStatus{
+					"core_node3": {State: solr_api.ReplicaActive, NodeName: "node1", Core: "col1_shard2_replica_n3", Type: solr_api.NRT, Leader: true},
+					"core_node4": {State: solr_api.ReplicaRecovering, NodeName: "node2", Core: "col1_shard2_replica_n4", Type: solr_api.NRT},
+				},
+			},
+			"shard1": {
+				State: solr_api.ShardActive,
+				Replicas: map[string]solr_api.SolrReplicaStatus{
+					"core_node1": {State: solr_api.ReplicaActive, NodeName: "node1", Core: "col1_shard1_replica_n1", Type: solr_api.NRT, Leader: true},
+					"core_node2": {State: solr_api.ReplicaActive, NodeName: "node2", Core: "col1_shard1_replica_n2", Type: solr_api.NRT},
+				},
+			},
+		},
+	}
+
+	status := GenerateCollectionStatus("col1", collectionState, liveNodes)
+	assert.Equal(t, "col1", status.CollectionName, "Wrong collection name")
+	assert.True(t, status.Created, "The collection should be marked as created")
+	assert.Equal(t, "some-config", status.ConfigSet, "Wrong configset")
+	assert.Equal(t, solr.CollectionDegraded, status.Health, "A collection with a recovering replica should be degraded")
+	assert.Equal(t, 3, status.ActiveReplicas, "Wrong number of active replicas")
+	assert.Equal(t, 4, status.TotalReplicas, "Wrong number of total replicas")
+	assert.Equal(t, "3/4", status.ReplicasReady, "Wrong replicas ready summary")
+	if assert.Len(t, status.Shards, 2, "Wrong number of shards") {
+		assert.Equal(t, "shard1", status.Shards[0].Name, "Shards should be sorted by name")
+		assert.Equal(t, "core_node1", status.Shards[0].Leader, "Wrong shard leader")
+		assert.Equal(t, "core_node1", status.Shards[0].Replicas[0].Name, "Replicas should be sorted by name")
+		assert.Equal(t, "col1_shard1_replica_n1", status.Shards[0].Replicas[0].Core, "Wrong replica core")
+	}
+
+	// A leader on a node that is no longer live means the shard is down
+	status = GenerateCollectionStatus("col1", collectionState, []string{"node2"})
+	assert.Equal(t, solr.CollectionDown, status.Health, "A collection with a shard without a live leader should be down")
+	assert.Equal(t, "1/4", status.ReplicasReady, "Wrong replicas ready summary")
+	assert.Equal(t, string(solr_api.ReplicaDown), status.Shards[0].Replicas[0].State, "Replicas on non-live nodes should be shown as down")
+
+	collectionState.Shards["shard2"].Replicas["core_node4"] = solr_api.SolrReplicaStatus{State: solr_api.ReplicaActive, NodeName: "node2", Type: solr_api.NRT}
+	status = GenerateCollectionStatus("col1", collectionState, liveNodes)
+	assert.Equal(t, solr.CollectionHealthy, status.Health, "A collection with all replicas active should be healthy")
+}
@@ -0,0 +1,145 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ConfigSetFilesFromConfigMaps collects the files of a configset from the ConfigMaps it references.
+// The ConfigMaps are passed in by name, and must all be present.
+func ConfigSetFilesFromConfigMaps(configSet *solr.SolrConfigSet, configMaps map[string]*corev1.ConfigMap) (files map[string][]byte, err error) {
+	files = map[string][]byte{}
+	for _, source := range configSet.Spec.ConfigMaps {
+		configMap, found := configMaps[source.Name]
+		if !found {
+			return nil, fmt.Errorf("configMap %s for configset %s was not found", source.Name, configSet.Name)
+		}
+		dir := strings.Trim(path.Clean("/"+source.Path), "/")
+		addFile := func(key string, content []byte) error {
+			filePath := path.Join(dir, key)
+			if _, exists := files[filePath]; exists {
+				return fmt.Errorf("file %s for configset %s is provided by more than one configMap", filePath, configSet.Name)
+			}
+			files[filePath] = content
+			return nil
+		}
+		for key, content := range configMap.Data {
+			if err = addFile(key, []byte(content)); err != nil {
+				return nil, err
+			}
+		}
+		for key, content := range configMap.BinaryData {
+			if err = addFile(key, content); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return files, nil
+}
+
+// ZipConfigSetFiles creates a zip of the given configset files.
+// The zip is deterministic, so that the same files always produce the same zip, and therefore the same content hash.
+func ZipConfigSetFiles(files map[string][]byte) ([]byte, error) {
+	filePaths := make([]string, 0, len(files))
+	for filePath := range files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	for _, filePath := range filePaths {
+		// Use a header without a modification time, so that the zip only depends on the file contents
+		fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{Name: filePath, Method: zip.Deflate})
+		if err != nil {
+			return nil, err
+		}
+		if _, err = fileWriter.Write(files[filePath]); err != nil {
+			return nil, err
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ConfigSetContentHash returns the hash used to determine whether a configset needs to be re-uploaded
+func ConfigSetContentHash(zippedConfigSet []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(zippedConfigSet))
+}
+
+// CollectionsUsingConfigSet returns the sorted list of collections that use the given configset
+func CollectionsUsingConfigSet(clusterStatus solr_api.SolrClusterStatus, configSetName string) (collections []string) {
+	for collection, collectionState := range clusterStatus.Collections {
+		if collectionState.ConfigName == configSetName {
+			collections = append(collections, collection)
+		}
+	}
+	sort.Strings(collections)
+	return collections
+}
+
+func UploadConfigSet(ctx context.Context, cloud *solr.SolrCloud, configSetName string, zippedConfigSet []byte, logger logr.Logger) (err error) {
+	logger.Info("Uploading configset", "solrCloud", cloud.Name, "configSet", configSetName)
+	err = solr_api.UploadConfigSet(ctx, cloud, configSetName, zippedConfigSet)
+	if err != nil {
+		logger.Error(err, "Error uploading configset", "solrCloud", cloud.Name, "configSet", configSetName)
+	}
+	return err
+}
+
+// ConfigSetExists returns whether a configset with the given name exists in Solr
+func ConfigSetExists(ctx context.Context, cloud *solr.SolrCloud, configSetName string) (exists bool, err error) {
+	configSets, err := solr_api.ListConfigSets(ctx, cloud)
+	if err != nil {
+		return false, err
+	}
+	for _, configSet := range configSets {
+		if configSet == configSetName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func ReloadCollection(ctx context.Context, cloud *solr.SolrCloud, collection string, logger logr.Logger) (err error) {
+	queryParams := url.Values{}
+	queryParams.Add("action", "RELOAD")
+	queryParams.Add("name", collection)
+
+	logger.Info("Reloading collection", "solrCloud", cloud.Name, "collection", collection)
+	err = callCollectionModificationApi(ctx, cloud, "RELOAD", queryParams)
+	if err != nil {
+		logger.Error(err, "Error reloading collection", "solrCloud", cloud.Name, "collection", collection)
+	}
+	return err
+}
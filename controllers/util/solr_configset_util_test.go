@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestConfigSetFilesFromConfigMaps(t *testing.T) {
+	configSet := &solr.SolrConfigSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "conf"},
+		Spec: solr.SolrConfigSetSpec{
+			SolrCloud: "solrcloudcluster",
+			ConfigMaps: []solr.ConfigSetConfigMapSource{
+				{Name: "root"},
+				{Name: "lang", Path: "/lang/"},
+			},
+		},
+	}
+	configMaps := map[string]*corev1.ConfigMap{
+		"root": {
+			Data:       map[string]string{"solrconfig.xml": "<config/>", "managed-schema.xml": "<schema/>"},
+			BinaryData: map[string][]byte{"synonyms.bin": {0, 1}},
+		},
+		"lang": {
+			Data: map[string]string{"stopwords_en.txt": "a"},
+		},
+	}
+
+	files, err := ConfigSetFilesFromConfigMaps(configSet, configMaps)
+	if !assert.NoError(t, err, "Unexpected error collecting configset files") {
+		return
+	}
+	assert.EqualValues(t, map[string][]byte{
+		"solrconfig.xml":        []byte("<config/>"),
+		"managed-schema.xml":    []byte("<schema/>"),
+		"synonyms.bin":          {0, 1},
+		"lang/stopwords_en.txt": []byte("a"),
+	}, files, "Wrong configset files")
+
+	// The same file cannot be provided by multiple ConfigMaps
+	configSet.Spec.ConfigMaps[1].Path = ""
+	configMaps["lang"].Data["solrconfig.xml"] = "<other/>"
+	_, err = ConfigSetFilesFromConfigMaps(configSet, configMaps)
+	assert.Error(t, err, "Files provided by multiple configMaps should result in an error")
+
+	delete(configMaps, "lang")
+	_, err = ConfigSetFilesFromConfigMaps(configSet, configMaps)
+	assert.Error(t, err, "A missing configMap should result in an error")
+}
+
+func TestZipConfigSetFiles(t *testing.T) {
+	files := map[string][]byte{
+		"solrconfig.xml":        []byte("<config/>"),
+		"lang/stopwords_en.txt": []byte("a"),
+	}
+
+	zipped, err := ZipConfigSetFiles(files)
+	if !assert.NoError(t, err, "Unexpected error zipping configset files") {
+		return
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(zipped), int64(len(zipped)))
+	if !assert.NoError(t, err, "Unable to read configset zip") {
+		return
+	}
+	if assert.Len(t, zipReader.File, 2, "Wrong number of files in the configset zip") {
+		assert.Equal(t, "lang/stopwords_en.txt", zipReader.File[0].Name, "Files in the configset zip should be sorted")
+		assert.Equal(t, "solrconfig.xml", zipReader.File[1].Name, "Files in the configset zip should be sorted")
+	}
+
+	zippedAgain, err := ZipConfigSetFiles(files)
+	if !assert.NoError(t, err, "Unexpected error zipping configset files") {
+		return
+	}
+	assert.Equal(t, ConfigSetContentHash(zipped), ConfigSetContentHash(zippedAgain), "The same files should always result in the same content hash")
+
+	files["solrconfig.xml"] = []byte("<config></config>")
+	zippedChanged, err := ZipConfigSetFiles(files)
+	if !assert.NoError(t, err, "Unexpected error zipping configset files") {
+		return
+	}
+	assert.NotEqual(t, ConfigSetContentHash(zipped), ConfigSetContentHash(zippedChanged), "Changed files should result in a different content hash")
+}
+
+func TestCollectionsUsingConfigSet(t *testing.T) {
+	clusterStatus := solr_api.SolrClusterStatus{
+		Collections: map[string]solr_api.SolrCollectionStatus{
+			"col3": {ConfigName: "conf"},
+			"col1": {ConfigName: "conf"},
+			"col2": {ConfigName: "other"},
+		},
+	}
+
+	assert.EqualValues(t, []string{"col1", "col3"}, CollectionsUsingConfigSet(clusterStatus, "conf"), "Wrong collections using the configset")
+	assert.Empty(t, CollectionsUsingConfigSet(clusterStatus, "missing"), "No collections should use a configset that is not referenced")
+}
@@ -0,0 +1,224 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"net/url"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
+)
+
+const (
+	SolrNextCredentialRotationAnnotation = "solr.apache.org/nextCredentialRotation"
+
+	// The new password is stored under this key of the basicAuthSecret while it is being rotated in,
+	// so that the password that Solr uses can always be recovered, even if the Solr Operator is interrupted.
+	PendingPasswordKey = "pending-password"
+
+	// How long to wait for a new password to be picked up by all Solr Nodes, before it is verified
+	credentialRotationVerifyDelay = time.Second * 5
+)
+
+// ReconcileCredentialRotation rotates the password of the user that the Solr Operator makes requests with, following the credentialRotation schedule.
+//
+// A rotation happens over two reconciles. First the new password is stored in the basicAuthSecret as a pending password, and set through the Security API.
+// Then the live credentials are checked: if Solr uses the pending password, and accepts it, then it replaces the password in the basicAuthSecret.
+// Otherwise, the previous password is set again through the Security API, and the pending password is discarded.
+func ReconcileCredentialRotation(ctx context.Context, client *client.Client, instance *solr.SolrCloud, security *SecurityConfig, logger logr.Logger) (rotated bool, reconcileWaitDuration *time.Duration, err error) {
+	reader := *client
+	credentialsSecret := security.CredentialsSecret
+	currentTime := time.Now()
+
+	nextRotation, isDue, err := scheduleNextCredentialRotation(instance.Spec.SolrSecurity.CredentialRotation.Schedule, credentialsSecret.Annotations, currentTime)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if _, hasPendingPassword := credentialsSecret.Data[PendingPasswordKey]; hasPendingPassword {
+		if rotated, err = resolvePendingPassword(ctx, client, instance, security, nextRotation, logger); err != nil {
+			return false, nil, err
+		}
+		if rotated {
+			updateBootstrapSecurityJsonCredential(ctx, client, instance, credentialsSecret, logger)
+		}
+	} else if isDue {
+		adminSecret, err := securityAdminSecret(ctx, client, instance, security)
+		if err != nil {
+			return false, nil, err
+		}
+
+		// Store the new password before it is sent to Solr, so that it cannot be lost
+		username := string(credentialsSecret.Data[corev1.BasicAuthUsernameKey])
+		credentialsSecret.Data[PendingPasswordKey] = randomPassword()
+		if err = reader.Update(ctx, credentialsSecret); err != nil {
+			return false, nil, err
+		}
+		logger.Info("Setting a new password for the Solr Operator's user", "user", username)
+		if err = setSolrUserPassword(contextWithBasicAuthHeader(ctx, adminSecret), instance, username, credentialsSecret.Data[PendingPasswordKey]); err != nil {
+			// The password may still have been set, so the pending password is resolved either way
+			logger.Error(err, "Error setting the new password for the Solr Operator's user", "user", username)
+		}
+		waitDuration := credentialRotationVerifyDelay
+		return false, &waitDuration, nil
+	} else if credentialsSecret.Annotations[SolrNextCredentialRotationAnnotation] != nextRotation {
+		if credentialsSecret.Annotations == nil {
+			credentialsSecret.Annotations = make(map[string]string, 1)
+		}
+		credentialsSecret.Annotations[SolrNextCredentialRotationAnnotation] = nextRotation
+		if err = reader.Update(ctx, credentialsSecret); err != nil {
+			return false, nil, err
+		}
+	}
+
+	if nextRotationTime, parseErr := time.Parse(time.RFC3339, nextRotation); parseErr == nil {
+		waitDuration := nextRotationTime.Sub(currentTime)
+		reconcileWaitDuration = &waitDuration
+	}
+	return rotated, reconcileWaitDuration, nil
+}
+
+// scheduleNextCredentialRotation returns the time of the next rotation, and whether the previously scheduled rotation is due.
+// If no rotation has been scheduled yet, the first one is scheduled for the next time in the schedule.
+func scheduleNextCredentialRotation(schedule string, secretAnnotations map[string]string, currentTime time.Time) (nextRotation string, isDue bool, err error) {
+	parsedSchedule, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return "", false, err
+	}
+	currentTime = currentTime.UTC()
+	if scheduledTime, hasScheduled := secretAnnotations[SolrNextCredentialRotationAnnotation]; hasScheduled {
+		if parsedScheduledTime, parseErr := time.Parse(time.RFC3339, scheduledTime); parseErr == nil {
+			if parsedScheduledTime.After(currentTime) {
+				return scheduledTime, false, nil
+			}
+			isDue = true
+		}
+	}
+	return parsedSchedule.Next(currentTime).Format(time.RFC3339), isDue, nil
+}
+
+// resolvePendingPassword finishes or rolls back a rotation, based on the live credentials of the Solr Operator's user.
+// The pending password is only kept if the previous password could not be restored, so that the next reconcile can try again.
+func resolvePendingPassword(ctx context.Context, client *client.Client, instance *solr.SolrCloud, security *SecurityConfig, nextRotation string, logger logr.Logger) (rotated bool, err error) {
+	reader := *client
+	credentialsSecret := security.CredentialsSecret
+	username := string(credentialsSecret.Data[corev1.BasicAuthUsernameKey])
+	currentPassword := credentialsSecret.Data[corev1.BasicAuthPasswordKey]
+	pendingPassword := credentialsSecret.Data[PendingPasswordKey]
+
+	adminSecret, err := securityAdminSecret(ctx, client, instance, security)
+	if err != nil {
+		return false, err
+	}
+	adminCtx := contextWithBasicAuthHeader(ctx, adminSecret)
+	authentication, err := solr_api.GetSecurityConfig(adminCtx, instance, solr_api.SecurityAuthenticationPath)
+	if err != nil {
+		return false, err
+	}
+	if authentication.Authentication == nil {
+		return false, fmt.Errorf("credential rotation requires Solr to use the BasicAuthPlugin for authentication")
+	}
+
+	var rotationErr error
+	if solrPasswordMatchesHash(pendingPassword, authentication.Authentication.Credentials[username]) {
+		pendingSecret := credentialsSecret.DeepCopy()
+		pendingSecret.Data[corev1.BasicAuthPasswordKey] = pendingPassword
+		if rotationErr = verifySolrCredentials(contextWithBasicAuthHeader(ctx, pendingSecret), instance); rotationErr == nil {
+			logger.Info("Verified the new password for the Solr Operator's user", "user", username)
+			credentialsSecret.Data[corev1.BasicAuthPasswordKey] = pendingPassword
+			if credentialsSecret.Annotations == nil {
+				credentialsSecret.Annotations = make(map[string]string, 1)
+			}
+			credentialsSecret.Annotations[SolrNextCredentialRotationAnnotation] = nextRotation
+			rotated = true
+		} else {
+			rotationErr = fmt.Errorf("Solr did not accept the new password for user %s, so it has been rolled back: %w", username, rotationErr)
+		}
+	} else {
+		rotationErr = fmt.Errorf("the new password for user %s was not set in Solr, so it has been rolled back", username)
+	}
+
+	if !rotated {
+		// Setting the previous password is idempotent, and makes sure that the pending password is not picked up later
+		logger.Info("Rolling back to the previous password for the Solr Operator's user", "user", username)
+		if err = setSolrUserPassword(adminCtx, instance, username, currentPassword); err != nil {
+			return false, fmt.Errorf("could not roll back to the previous password for user %s: %w", username, err)
+		}
+	}
+	delete(credentialsSecret.Data, PendingPasswordKey)
+	if err = reader.Update(ctx, credentialsSecret); err != nil {
+		return false, err
+	}
+	return rotated, rotationErr
+}
+
+// updateBootstrapSecurityJsonCredential sets the new password hash in the bootstrapped security.json, so that it does not lock out the Solr Operator
+// if it is ever used to bootstrap security again.
+func updateBootstrapSecurityJsonCredential(ctx context.Context, client *client.Client, instance *solr.SolrCloud, credentialsSecret *corev1.Secret, logger logr.Logger) {
+	reader := *client
+	bootstrapSecret := &corev1.Secret{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: instance.SecurityBootstrapSecretName(), Namespace: instance.Namespace}, bootstrapSecret); err != nil {
+		// The bootstrap secret may have been deleted after security was bootstrapped
+		return
+	}
+	securityJson := make(map[string]interface{})
+	if err := json.Unmarshal(bootstrapSecret.Data[SecurityJsonFile], &securityJson); err != nil {
+		logger.Error(err, "Could not parse the bootstrapped security.json to update the Solr Operator's credentials")
+		return
+	}
+	authentication, _ := securityJson["authentication"].(map[string]interface{})
+	credentials, hasCredentials := authentication["credentials"].(map[string]interface{})
+	if !hasCredentials {
+		return
+	}
+	credentials[string(credentialsSecret.Data[corev1.BasicAuthUsernameKey])] = solrPasswordHash(credentialsSecret.Data[corev1.BasicAuthPasswordKey])
+	updatedSecurityJson, err := json.Marshal(securityJson)
+	if err == nil {
+		bootstrapSecret.Data[SecurityJsonFile] = updatedSecurityJson
+		err = reader.Update(ctx, bootstrapSecret)
+	}
+	if err != nil {
+		logger.Error(err, "Could not update the Solr Operator's credentials in the bootstrapped security.json")
+	}
+}
+
+func setSolrUserPassword(adminCtx context.Context, instance *solr.SolrCloud, username string, password []byte) error {
+	return solr_api.EditSecurityConfig(adminCtx, instance, solr_api.SecurityAuthenticationPath, map[string]interface{}{
+		"set-user": map[string]string{username: string(password)},
+	})
+}
+
+// verifySolrCredentials makes a request that the Solr Operator's user is always allowed to make, to check that Solr accepts the credentials in the context
+func verifySolrCredentials(ctx context.Context, instance *solr.SolrCloud) error {
+	queryParams := url.Values{}
+	queryParams.Set("action", "LIST")
+	response := &solr_api.SolrCollectionsListing{}
+	err := solr_api.CallCollectionsApi(ctx, instance, queryParams, response)
+	if _, apiErr := solr_api.CheckForCollectionsApiError("LIST", response.ResponseHeader, nil); apiErr != nil {
+		err = apiErr
+	}
+	return err
+}
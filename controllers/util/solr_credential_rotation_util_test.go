@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestScheduleNextCredentialRotation(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	_, _, err := scheduleNextCredentialRotation("not a cron", map[string]string{}, now)
+	assert.Error(t, err, "There should be a parsing error for a bad schedule")
+
+	nextRotation, isDue, err := scheduleNextCredentialRotation("@every 2160h", nil, now)
+	assert.NoError(t, err, "There should be no error for a valid schedule")
+	assert.False(t, isDue, "The first rotation should not be due immediately")
+	assert.Equal(t, now.Add(time.Hour*2160).Format(time.RFC3339), nextRotation, "The first rotation should be scheduled for the next time in the schedule")
+
+	scheduled := now.Add(time.Hour).Format(time.RFC3339)
+	nextRotation, isDue, err = scheduleNextCredentialRotation("@every 2160h", map[string]string{SolrNextCredentialRotationAnnotation: scheduled}, now)
+	assert.NoError(t, err, "There should be no error for a valid schedule")
+	assert.False(t, isDue, "A rotation scheduled in the future should not be due")
+	assert.Equal(t, scheduled, nextRotation, "A rotation scheduled in the future should not be re-scheduled")
+
+	scheduled = now.Add(-time.Hour).Format(time.RFC3339)
+	nextRotation, isDue, err = scheduleNextCredentialRotation("0 6 1 */3 *", map[string]string{SolrNextCredentialRotationAnnotation: scheduled}, now)
+	assert.NoError(t, err, "There should be no error for a valid schedule")
+	assert.True(t, isDue, "A rotation scheduled in the past should be due")
+	assert.Equal(t, "2024-04-01T06:00:00Z", nextRotation, "The rotation after a due rotation should be scheduled from the current time")
+
+	nextRotation, isDue, err = scheduleNextCredentialRotation("@every 1h", map[string]string{SolrNextCredentialRotationAnnotation: "invalid"}, now)
+	assert.NoError(t, err, "There should be no error for a valid schedule")
+	assert.False(t, isDue, "A rotation should not be due when the scheduled time cannot be parsed")
+	assert.Equal(t, now.Add(time.Hour).Format(time.RFC3339), nextRotation, "A new rotation should be scheduled when the scheduled time cannot be parsed")
+}
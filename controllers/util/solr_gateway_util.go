@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// GenerateHTTPRoutes returns the Gateway API HTTPRoutes for the common endpoint and each Solr Node of the SolrCloud.
+// Each route has the same hostnames that an Ingress would use, for the domainName and every additionalDomainName.
+// solrCloud: SolrCloud instance
+// nodeNames: []string the names of the Solr Nodes to create routes for
+func GenerateHTTPRoutes(solrCloud *solr.SolrCloud, nodeNames []string) (routes []*gatewayv1.HTTPRoute) {
+	for _, backend := range gatewayRouteBackends(solrCloud, nodeNames) {
+		routes = append(routes, &gatewayv1.HTTPRoute{
+			ObjectMeta: backend.objectMeta,
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: backend.commonRouteSpec,
+				Hostnames:       backend.hostnames,
+				Rules: []gatewayv1.HTTPRouteRule{
+					{
+						// The Gateway API defaults the match, so it is provided to avoid constant updates
+						Matches: []gatewayv1.HTTPRouteMatch{
+							{
+								Path: &gatewayv1.HTTPPathMatch{
+									Type:  (*gatewayv1.PathMatchType)(pointer.String(string(gatewayv1.PathMatchPathPrefix))),
+									Value: pointer.String("/"),
+								},
+							},
+						},
+						BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: backend.backendRef}},
+					},
+				},
+			},
+		})
+	}
+	return routes
+}
+
+// GenerateTLSRoutes returns the Gateway API TLSRoutes for the common endpoint and each Solr Node of the SolrCloud.
+// These are used instead of HTTPRoutes when the SolrCloud has TLS enabled, so that the Gateway passes TLS connections through to Solr, using SNI to pick the backend.
+// solrCloud: SolrCloud instance
+// nodeNames: []string the names of the Solr Nodes to create routes for
+func GenerateTLSRoutes(solrCloud *solr.SolrCloud, nodeNames []string) (routes []*gatewayv1alpha2.TLSRoute) {
+	for _, backend := range gatewayRouteBackends(solrCloud, nodeNames) {
+		routes = append(routes, &gatewayv1alpha2.TLSRoute{
+			ObjectMeta: backend.objectMeta,
+			Spec: gatewayv1alpha2.TLSRouteSpec{
+				CommonRouteSpec: backend.commonRouteSpec,
+				Hostnames:       backend.hostnames,
+				Rules: []gatewayv1alpha2.TLSRouteRule{
+					{
+						BackendRefs: []gatewayv1alpha2.BackendRef{backend.backendRef},
+					},
+				},
+			},
+		})
+	}
+	return routes
+}
+
+// gatewayRouteBackend holds the information shared by the HTTPRoute and TLSRoute for a single Solr Service
+type gatewayRouteBackend struct {
+	objectMeta      metav1.ObjectMeta
+	commonRouteSpec gatewayv1.CommonRouteSpec
+	hostnames       []gatewayv1.Hostname
+	backendRef      gatewayv1.BackendRef
+}
+
+// gatewayRouteBackends returns the route information for the common Service and each Solr Node Service that should be exposed through the Gateway
+func gatewayRouteBackends(solrCloud *solr.SolrCloud, nodeNames []string) (backends []gatewayRouteBackend) {
+	extOpts := solrCloud.Spec.SolrAddressability.External
+	if extOpts == nil || extOpts.Gateway == nil {
+		return nil
+	}
+	labels := solrCloud.SharedLabelsWith(solrCloud.GetLabels())
+	allDomains := append([]string{extOpts.DomainName}, extOpts.AdditionalDomainNames...)
+
+	parentRef := gatewayv1.ParentReference{
+		// The Gateway API defaults the group and kind, so they are provided to avoid constant updates
+		Group: (*gatewayv1.Group)(pointer.String(gatewayv1.GroupName)),
+		Kind:  (*gatewayv1.Kind)(pointer.String("Gateway")),
+		Name:  gatewayv1.ObjectName(extOpts.Gateway.ParentRef.Name),
+	}
+	if extOpts.Gateway.ParentRef.Namespace != "" {
+		parentRef.Namespace = (*gatewayv1.Namespace)(pointer.String(extOpts.Gateway.ParentRef.Namespace))
+	}
+	if extOpts.Gateway.ParentRef.SectionName != "" {
+		parentRef.SectionName = (*gatewayv1.SectionName)(pointer.String(extOpts.Gateway.ParentRef.SectionName))
+	}
+
+	newBackend := func(name string, serviceName string, port int, hostnames []gatewayv1.Hostname) gatewayRouteBackend {
+		return gatewayRouteBackend{
+			objectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: solrCloud.GetNamespace(),
+				Labels:    DuplicateLabelsOrAnnotations(labels),
+			},
+			commonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{parentRef},
+			},
+			hostnames: hostnames,
+			backendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Group: (*gatewayv1.Group)(pointer.String("")),
+					Kind:  (*gatewayv1.Kind)(pointer.String("Service")),
+					Name:  gatewayv1.ObjectName(serviceName),
+					Port:  (*gatewayv1.PortNumber)(pointer.Int32(int32(port))),
+				},
+				Weight: pointer.Int32(1),
+			},
+		}
+	}
+
+	if !extOpts.HideCommon {
+		hostnames := make([]gatewayv1.Hostname, len(allDomains))
+		for i, domainName := range allDomains {
+			hostnames[i] = gatewayv1.Hostname(solrCloud.ExternalCommonUrl(domainName, false))
+		}
+		backends = append(backends, newBackend(solrCloud.CommonRouteName(), solrCloud.CommonServiceName(), solrCloud.Spec.SolrAddressability.CommonServicePort, hostnames))
+	}
+	if !extOpts.HideNodes {
+		for _, nodeName := range nodeNames {
+			hostnames := make([]gatewayv1.Hostname, len(allDomains))
+			for i, domainName := range allDomains {
+				hostnames[i] = gatewayv1.Hostname(solrCloud.ExternalNodeUrl(nodeName, domainName, false))
+			}
+			backends = append(backends, newBackend(nodeName, nodeName, solrCloud.NodePort(), hostnames))
+		}
+	}
+	return backends
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"testing"
+)
+
+func TestGenerateGatewayRoutes(t *testing.T) {
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solr.SolrCloudSpec{
+			SolrAddressability: solr.SolrAddressabilityOptions{
+				External: &solr.ExternalAddressability{
+					Method:                solr.GatewayAPI,
+					DomainName:            "example.com",
+					AdditionalDomainNames: []string{"other.com"},
+					UseExternalAddress:    true,
+					Gateway: &solr.SolrGatewayOptions{
+						ParentRef: solr.SolrGatewayParentReference{
+							Name:        "gateway",
+							Namespace:   "gateway-system",
+							SectionName: "solr",
+						},
+					},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	nodeNames := []string{"foo-solrcloud-0", "foo-solrcloud-1"}
+
+	httpRoutes := GenerateHTTPRoutes(solrCloud, nodeNames)
+	if assert.Len(t, httpRoutes, 3, "There should be an HTTPRoute for the common endpoint and each node") {
+		commonRoute := httpRoutes[0]
+		assert.Equal(t, "foo-solrcloud-common", commonRoute.Name, "Wrong name for the common HTTPRoute")
+		assert.Equal(t, []gatewayv1.Hostname{"default-foo-solrcloud.example.com", "default-foo-solrcloud.other.com"}, commonRoute.Spec.Hostnames, "Wrong hostnames for the common HTTPRoute")
+		if assert.Len(t, commonRoute.Spec.ParentRefs, 1, "The common HTTPRoute should have one parentRef") {
+			assert.EqualValues(t, "gateway", commonRoute.Spec.ParentRefs[0].Name, "Wrong parentRef name")
+			assert.EqualValues(t, "gateway-system", *commonRoute.Spec.ParentRefs[0].Namespace, "Wrong parentRef namespace")
+			assert.EqualValues(t, "solr", *commonRoute.Spec.ParentRefs[0].SectionName, "Wrong parentRef sectionName")
+		}
+		if assert.Len(t, commonRoute.Spec.Rules, 1, "The common HTTPRoute should have one rule") && assert.Len(t, commonRoute.Spec.Rules[0].BackendRefs, 1, "The common HTTPRoute should have one backend") {
+			backend := commonRoute.Spec.Rules[0].BackendRefs[0]
+			assert.EqualValues(t, "foo-solrcloud-common", backend.Name, "The common HTTPRoute should send requests to the common service")
+			assert.EqualValues(t, 80, *backend.Port, "The common HTTPRoute should use the common service port")
+		}
+
+		nodeRoute := httpRoutes[1]
+		assert.Equal(t, "foo-solrcloud-0", nodeRoute.Name, "Wrong name for the node HTTPRoute")
+		assert.Equal(t, []gatewayv1.Hostname{"default-foo-solrcloud-0.example.com", "default-foo-solrcloud-0.other.com"}, nodeRoute.Spec.Hostnames, "Wrong hostnames for the node HTTPRoute")
+		if assert.Len(t, nodeRoute.Spec.Rules, 1, "The node HTTPRoute should have one rule") && assert.Len(t, nodeRoute.Spec.Rules[0].BackendRefs, 1, "The node HTTPRoute should have one backend") {
+			backend := nodeRoute.Spec.Rules[0].BackendRefs[0]
+			assert.EqualValues(t, "foo-solrcloud-0", backend.Name, "The node HTTPRoute should send requests to the node service")
+			assert.EqualValues(t, 80, *backend.Port, "The node HTTPRoute should use the node port")
+		}
+	}
+	assert.Equal(t, "default-foo-solrcloud-0.example.com", solrCloud.AdvertisedNodeHost("foo-solrcloud-0"), "Wrong advertised host for a GatewayAPI node")
+	assert.Equal(t, "default-foo-solrcloud-0.example.com", solrCloud.ExternalNodeUrl("foo-solrcloud-0", "example.com", true), "The external node url of a GatewayAPI node should not include the default port")
+
+	// The generated routes contain every field that the Gateway API defaults, so they should not cause constant updates
+	foundRoute := httpRoutes[1].DeepCopy()
+	assert.False(t, CopyHTTPRouteFields(GenerateHTTPRoutes(solrCloud, nodeNames)[1], foundRoute, logr.Discard()), "An unchanged HTTPRoute should not require an update")
+	solrCloud.Spec.SolrAddressability.External.AdditionalDomainNames = nil
+	assert.True(t, CopyHTTPRouteFields(GenerateHTTPRoutes(solrCloud, nodeNames)[1], foundRoute, logr.Discard()), "Removing a domain should require an update")
+	assert.Equal(t, []gatewayv1.Hostname{"default-foo-solrcloud-0.example.com"}, foundRoute.Spec.Hostnames, "The hostnames should be updated")
+
+	tlsRoutes := GenerateTLSRoutes(solrCloud, nodeNames)
+	if assert.Len(t, tlsRoutes, 3, "There should be a TLSRoute for the common endpoint and each node") {
+		assert.Equal(t, "foo-solrcloud-1", tlsRoutes[2].Name, "Wrong name for the node TLSRoute")
+		assert.Equal(t, []gatewayv1.Hostname{"default-foo-solrcloud-1.example.com"}, tlsRoutes[2].Spec.Hostnames, "Wrong hostnames for the node TLSRoute")
+		foundTLSRoute := tlsRoutes[2].DeepCopy()
+		assert.False(t, CopyTLSRouteFields(GenerateTLSRoutes(solrCloud, nodeNames)[2], foundTLSRoute, logr.Discard()), "An unchanged TLSRoute should not require an update")
+	}
+
+	solrCloud.Spec.SolrAddressability.External.HideNodes = true
+	httpRoutes = GenerateHTTPRoutes(solrCloud, nodeNames)
+	if assert.Len(t, httpRoutes, 1, "There should only be a common HTTPRoute when the nodes are hidden") {
+		assert.Equal(t, "foo-solrcloud-common", httpRoutes[0].Name, "Wrong name for the common HTTPRoute")
+	}
+	solrCloud.Spec.SolrAddressability.External.HideCommon = true
+	assert.Empty(t, GenerateHTTPRoutes(solrCloud, nodeNames), "There should be no HTTPRoutes when the common endpoint and nodes are hidden")
+}
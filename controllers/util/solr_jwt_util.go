@@ -0,0 +1,337 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	b64 "encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"math/big"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// The issuer of the tokens that the Solr Operator signs itself, when a signingKeySecret is provided
+	SolrOperatorJWTIssuer = "solr-operator"
+
+	JWTClientIdKey     = "client-id"
+	JWTClientSecretKey = "client-secret"
+
+	// The role that the Solr Operator needs, for the permissions of the bootstrapped security.json
+	solrOperatorRole = "k8s"
+
+	// How long the tokens that the Solr Operator signs itself are valid for
+	operatorTokenLifetime = time.Minute * 5
+)
+
+// The token sources of each SolrCloud are kept between reconciles, so that tokens are re-used until they expire.
+var jwtTokenSources sync.Map
+
+type cachedTokenSource struct {
+	fingerprint string
+	source      oauth2.TokenSource
+}
+
+// Reconcile the token source used to make calls to Solr secured with JWT auth.
+// Also, bootstraps an initial security.json config with the JWTAuthPlugin, if not supplied by the user.
+func reconcileForJWTAuth(ctx context.Context, client *client.Client, instance *solr.SolrCloud) (*SecurityConfig, error) {
+	sec := instance.Spec.SolrSecurity
+	if sec.JWT == nil {
+		return nil, fmt.Errorf("invalid JWT auth config, the 'jwt' options are required for the JWT authenticationType")
+	}
+	security := &SecurityConfig{SolrSecurity: sec}
+
+	tokenSource, err := jwtTokenSource(ctx, client, instance)
+	if err != nil {
+		return nil, err
+	}
+	security.TokenSource = tokenSource
+
+	if sec.BootstrapSecurityJson != nil {
+		securityJson, err := loadSecretKeyValue(ctx, client, sec.BootstrapSecurityJson, instance.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		security.SecurityJson = securityJson
+		security.SecurityJsonSrc = &corev1.EnvVarSource{SecretKeyRef: sec.BootstrapSecurityJson}
+		return security, nil
+	}
+
+	// The security.json is only generated once, just like for basic auth, since it is only used to bootstrap security
+	err = reconcileGeneratedSecurityJson(ctx, client, instance, security, func() ([]byte, error) {
+		return generateJWTSecurityJson(ctx, client, instance)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return security, nil
+}
+
+// jwtTokenSource returns the source of the tokens that the Solr Operator makes requests to the SolrCloud with.
+// The token source is re-used across reconciles, until its config or secrets change.
+func jwtTokenSource(ctx context.Context, client *client.Client, instance *solr.SolrCloud) (oauth2.TokenSource, error) {
+	reader := *client
+	jwtOptions := instance.Spec.SolrSecurity.JWT
+	operatorAuth := jwtOptions.OperatorAuth
+
+	var fingerprintSource []string
+	var newTokenSource func() oauth2.TokenSource
+	if operatorAuth.ClientCredentials != nil {
+		clientSecret := &corev1.Secret{}
+		if err := reader.Get(ctx, types.NamespacedName{Name: operatorAuth.ClientCredentials.ClientSecret, Namespace: instance.Namespace}, clientSecret); err != nil {
+			return nil, err
+		}
+		if err := validateCredentialsSecretData(clientSecret, solr.JWT, JWTClientIdKey, JWTClientSecretKey); err != nil {
+			return nil, err
+		}
+		config := &clientcredentials.Config{
+			ClientID:     string(clientSecret.Data[JWTClientIdKey]),
+			ClientSecret: string(clientSecret.Data[JWTClientSecretKey]),
+			TokenURL:     operatorAuth.ClientCredentials.TokenUrl,
+			Scopes:       operatorAuth.ClientCredentials.Scopes,
+		}
+		fingerprintSource = append([]string{config.TokenURL, config.ClientID, config.ClientSecret}, config.Scopes...)
+		// The token source outlives the reconcile, so it cannot use the reconcile's context
+		newTokenSource = func() oauth2.TokenSource { return config.TokenSource(context.Background()) }
+	} else if operatorAuth.SigningKeySecret != nil {
+		signingKey, err := loadSecretKeyValue(ctx, client, operatorAuth.SigningKeySecret, instance.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		privateKey, err := parseRSAPrivateKey([]byte(signingKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid signingKeySecret: %w", err)
+		}
+		fingerprintSource = []string{signingKey, jwtOptions.PrincipalClaim, jwtOptions.RolesClaim}
+		newTokenSource = func() oauth2.TokenSource {
+			return oauth2.ReuseTokenSource(nil, &signingKeyTokenSource{
+				privateKey:     privateKey,
+				principalClaim: jwtOptions.PrincipalClaim,
+				rolesClaim:     jwtOptions.RolesClaim,
+			})
+		}
+	} else {
+		return nil, fmt.Errorf("invalid JWT auth config, either 'clientCredentials' or 'signingKeySecret' must be provided in 'operatorAuth'")
+	}
+
+	fingerprint := fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(fingerprintSource, "\n"))))
+	key := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+	if cached, isCached := jwtTokenSources.Load(key); isCached && cached.(*cachedTokenSource).fingerprint == fingerprint {
+		return cached.(*cachedTokenSource).source, nil
+	}
+	source := newTokenSource()
+	jwtTokenSources.Store(key, &cachedTokenSource{fingerprint: fingerprint, source: source})
+	return source, nil
+}
+
+func contextWithBearerToken(ctx context.Context, tokenSource oauth2.TokenSource) (context.Context, error) {
+	token, err := tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("could not get a token to authenticate to Solr with: %w", err)
+	}
+	return context.WithValue(ctx, solr_api.HTTP_HEADERS_CONTEXT_KEY, map[string]string{"Authorization": "Bearer " + token.AccessToken}), nil
+}
+
+// signingKeyTokenSource signs short-lived tokens for the Solr Operator's user, that give it the role it needs in the bootstrapped security.json
+type signingKeyTokenSource struct {
+	privateKey     *rsa.PrivateKey
+	principalClaim string
+	rolesClaim     string
+}
+
+func (s *signingKeyTokenSource) Token() (*oauth2.Token, error) {
+	now := time.Now()
+	expiry := now.Add(operatorTokenLifetime)
+	claims := map[string]interface{}{
+		"iss": SolrOperatorJWTIssuer,
+		"sub": solr.DefaultBasicAuthUsername,
+		"iat": now.Unix(),
+		"exp": expiry.Unix(),
+	}
+	if s.principalClaim != "" {
+		claims[s.principalClaim] = solr.DefaultBasicAuthUsername
+	}
+	if s.rolesClaim != "" {
+		claims[s.rolesClaim] = []string{solrOperatorRole}
+	}
+	accessToken, err := signJWT(s.privateKey, claims)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{AccessToken: accessToken, TokenType: "Bearer", Expiry: expiry}, nil
+}
+
+// signJWT creates a token with the given claims, signed with RS256
+func signJWT(privateKey *rsa.PrivateKey, claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": SolrOperatorJWTIssuer})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := b64.RawURLEncoding.EncodeToString(header) + "." + b64.RawURLEncoding.EncodeToString(payload)
+	hashed := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + b64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseRSAPrivateKey parses a PEM-encoded RSA private key, in either the PKCS #1 or the PKCS #8 format
+func parseRSAPrivateKey(pemBytes []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM-encoded private key found")
+	}
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, isRSA := parsedKey.(*rsa.PrivateKey)
+	if !isRSA {
+		return nil, fmt.Errorf("only RSA private keys are supported")
+	}
+	return privateKey, nil
+}
+
+// rsaPublicJwk returns the JSON Web Key of the public key that the Solr Operator's tokens can be verified with
+func rsaPublicJwk(privateKey *rsa.PrivateKey) map[string]interface{} {
+	return map[string]interface{}{
+		"kty": "RSA",
+		"use": "sig",
+		"alg": "RS256",
+		"kid": SolrOperatorJWTIssuer,
+		"n":   b64.RawURLEncoding.EncodeToString(privateKey.PublicKey.N.Bytes()),
+		"e":   b64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.PublicKey.E)).Bytes()),
+	}
+}
+
+// generateJWTSecurityJson loads the keys referenced by the jwt options, and generates the security.json to bootstrap Solr with
+func generateJWTSecurityJson(ctx context.Context, client *client.Client, instance *solr.SolrCloud) ([]byte, error) {
+	jwtOptions := instance.Spec.SolrSecurity.JWT
+	var jwkSet map[string]interface{}
+	if jwtOptions.JwkSetSecret != nil {
+		jwkSetJson, err := loadSecretKeyValue(ctx, client, jwtOptions.JwkSetSecret, instance.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(jwkSetJson), &jwkSet); err != nil {
+			return nil, fmt.Errorf("invalid jwkSetSecret, it must contain a JSON Web Key Set: %w", err)
+		}
+	}
+	var operatorJwk map[string]interface{}
+	if jwtOptions.OperatorAuth.SigningKeySecret != nil {
+		signingKey, err := loadSecretKeyValue(ctx, client, jwtOptions.OperatorAuth.SigningKeySecret, instance.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		privateKey, err := parseRSAPrivateKey([]byte(signingKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid signingKeySecret: %w", err)
+		}
+		operatorJwk = rsaPublicJwk(privateKey)
+	}
+	return json.Marshal(GenerateJWTSecurityConfig(instance, jwkSet, operatorJwk))
+}
+
+// GenerateJWTSecurityConfig generates the security.json for the JWTAuthPlugin, with the same permissions as the security.json bootstrapped for basic auth.
+// The probe endpoints are always open, since the probes cannot get tokens.
+func GenerateJWTSecurityConfig(solrCloud *solr.SolrCloud, jwkSet map[string]interface{}, operatorJwk map[string]interface{}) map[string]interface{} {
+	jwtOptions := solrCloud.Spec.SolrSecurity.JWT
+
+	issuer := map[string]interface{}{
+		"name": "default",
+		"iss":  jwtOptions.Issuer,
+	}
+	if jwtOptions.JwksUrl != "" {
+		issuer["jwksUrl"] = jwtOptions.JwksUrl
+	} else if jwkSet != nil {
+		issuer["jwk"] = jwkSet
+	}
+	if jwtOptions.Audience != "" {
+		issuer["aud"] = jwtOptions.Audience
+	}
+	issuers := []interface{}{issuer}
+	if operatorJwk != nil {
+		issuers = append(issuers, map[string]interface{}{
+			"name": SolrOperatorJWTIssuer,
+			"iss":  SolrOperatorJWTIssuer,
+			"jwk":  operatorJwk,
+		})
+	}
+
+	authentication := map[string]interface{}{
+		"class": "solr.JWTAuthPlugin",
+		// Unauthenticated requests are left to the authorization plugin, so that the probe endpoints can be open
+		"blockUnknown": false,
+		"issuers":      issuers,
+	}
+	if jwtOptions.PrincipalClaim != "" {
+		authentication["principalClaim"] = jwtOptions.PrincipalClaim
+	}
+	if len(jwtOptions.ClaimsMatch) > 0 {
+		authentication["claimsMatch"] = jwtOptions.ClaimsMatch
+	}
+	if jwtOptions.RolesClaim != "" {
+		authentication["rolesClaim"] = jwtOptions.RolesClaim
+	}
+
+	authorization := map[string]interface{}{
+		"permissions": bootstrapPermissions(solrCloud),
+	}
+	if jwtOptions.RolesClaim != "" {
+		authorization["class"] = "solr.ExternalRoleRuleBasedAuthorizationPlugin"
+	} else {
+		userRoles := make(map[string][]string, len(jwtOptions.UserRoles)+1)
+		for user, roles := range jwtOptions.UserRoles {
+			userRoles[user] = roles
+		}
+		if operatorJwk != nil {
+			userRoles[solr.DefaultBasicAuthUsername] = []string{solrOperatorRole}
+		}
+		authorization["class"] = "solr.RuleBasedAuthorizationPlugin"
+		authorization["user-role"] = userRoles
+	}
+
+	return map[string]interface{}{
+		"authentication": authentication,
+		"authorization":  authorization,
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	b64 "encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+	"testing"
+)
+
+func TestParseRSAPrivateKey(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err, "Could not generate an RSA key") {
+		return
+	}
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	parsedKey, err := parseRSAPrivateKey(pkcs1)
+	if assert.NoError(t, err, "A PKCS #1 key should be parsed") {
+		assert.True(t, privateKey.Equal(parsedKey), "Wrong key parsed from PKCS #1")
+	}
+
+	pkcs8Bytes, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+	parsedKey, err = parseRSAPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes}))
+	if assert.NoError(t, err, "A PKCS #8 key should be parsed") {
+		assert.True(t, privateKey.Equal(parsedKey), "Wrong key parsed from PKCS #8")
+	}
+
+	_, err = parseRSAPrivateKey([]byte("not a key"))
+	assert.Error(t, err, "Non-PEM data should be rejected")
+}
+
+func TestSigningKeyTokenSource(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err, "Could not generate an RSA key") {
+		return
+	}
+	token, err := (&signingKeyTokenSource{privateKey: privateKey, principalClaim: "preferred_username", rolesClaim: "groups"}).Token()
+	if !assert.NoError(t, err, "The token should be signed") {
+		return
+	}
+	assert.Equal(t, "Bearer", token.TokenType, "Wrong token type")
+
+	parts := strings.Split(token.AccessToken, ".")
+	if !assert.Len(t, parts, 3, "The token should have a header, payload and signature") {
+		return
+	}
+	signature, _ := b64.RawURLEncoding.DecodeString(parts[2])
+	hashed := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.SHA256, hashed[:], signature), "The signature should be verified by the public key")
+
+	header := map[string]interface{}{}
+	headerJson, _ := b64.RawURLEncoding.DecodeString(parts[0])
+	assert.NoError(t, json.Unmarshal(headerJson, &header), "The header should be JSON")
+	assert.Equal(t, map[string]interface{}{"alg": "RS256", "typ": "JWT", "kid": SolrOperatorJWTIssuer}, header, "Wrong token header")
+
+	claims := map[string]interface{}{}
+	claimsJson, _ := b64.RawURLEncoding.DecodeString(parts[1])
+	assert.NoError(t, json.Unmarshal(claimsJson, &claims), "The claims should be JSON")
+	assert.Equal(t, SolrOperatorJWTIssuer, claims["iss"], "Wrong issuer claim")
+	assert.Equal(t, solr.DefaultBasicAuthUsername, claims["sub"], "Wrong subject claim")
+	assert.Equal(t, solr.DefaultBasicAuthUsername, claims["preferred_username"], "The principal claim should be set to the operator's user")
+	assert.Equal(t, []interface{}{"k8s"}, claims["groups"], "The roles claim should give the operator the k8s role")
+	assert.EqualValues(t, token.Expiry.Unix(), claims["exp"], "The expiry claim should match the token expiry")
+}
+
+func TestGenerateJWTSecurityConfig(t *testing.T) {
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solr.SolrCloudSpec{
+			SolrSecurity: &solr.SolrSecurityOptions{
+				AuthenticationType: solr.JWT,
+				JWT: &solr.SolrJWTOptions{
+					Issuer:       "https://idp.example.com",
+					JwksUrl:      "https://idp.example.com/jwks",
+					Audience:     "solr",
+					ClaimsMatch:  map[string]string{"tenant": "search"},
+					RolesClaim:   "groups",
+					OperatorAuth: solr.SolrJWTOperatorAuth{ClientCredentials: &solr.SolrJWTClientCredentials{TokenUrl: "https://idp.example.com/token", ClientSecret: "client"}},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+
+	config := GenerateJWTSecurityConfig(solrCloud, nil, nil)
+	authentication := config["authentication"].(map[string]interface{})
+	assert.Equal(t, "solr.JWTAuthPlugin", authentication["class"], "Wrong authentication plugin")
+	assert.Equal(t, false, authentication["blockUnknown"], "Unauthenticated requests must reach the authorization plugin, for the probes")
+	assert.Equal(t, "groups", authentication["rolesClaim"], "Wrong rolesClaim")
+	assert.Equal(t, map[string]string{"tenant": "search"}, authentication["claimsMatch"], "Wrong claimsMatch")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "default", "iss": "https://idp.example.com", "jwksUrl": "https://idp.example.com/jwks", "aud": "solr"}}, authentication["issuers"], "Wrong issuers")
+	authorization := config["authorization"].(map[string]interface{})
+	assert.Equal(t, "solr.ExternalRoleRuleBasedAuthorizationPlugin", authorization["class"], "The roles should be taken from the tokens when a rolesClaim is provided")
+	assert.NotContains(t, authorization, "user-role", "There should be no user roles when the roles are taken from the tokens")
+	permissions := authorization["permissions"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "k8s-probe-0", "role": nil, "collection": nil, "path": "/admin/info/system"}, permissions[0], "The probe endpoints should be open")
+
+	// Tokens signed by the Solr Operator, with roles mapped from users
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err, "Could not generate an RSA key") {
+		return
+	}
+	solrCloud.Spec.SolrSecurity.JWT.JwksUrl = ""
+	solrCloud.Spec.SolrSecurity.JWT.RolesClaim = ""
+	solrCloud.Spec.SolrSecurity.JWT.UserRoles = map[string][]string{"alice": {"admin"}}
+	solrCloud.Spec.SolrSecurity.JWT.OperatorAuth = solr.SolrJWTOperatorAuth{SigningKeySecret: &corev1.SecretKeySelector{Key: "key"}}
+	jwkSet := map[string]interface{}{"keys": []interface{}{map[string]interface{}{"kty": "RSA", "kid": "idp"}}}
+	config = GenerateJWTSecurityConfig(solrCloud, jwkSet, rsaPublicJwk(privateKey))
+	issuers := config["authentication"].(map[string]interface{})["issuers"].([]interface{})
+	if assert.Len(t, issuers, 2, "There should be an issuer for the Solr Operator's tokens") {
+		assert.Equal(t, jwkSet, issuers[0].(map[string]interface{})["jwk"], "The inline JWK set should be used for the issuer")
+		operatorIssuer := issuers[1].(map[string]interface{})
+		assert.Equal(t, SolrOperatorJWTIssuer, operatorIssuer["iss"], "Wrong issuer for the Solr Operator's tokens")
+		assert.Equal(t, b64.RawURLEncoding.EncodeToString(privateKey.PublicKey.N.Bytes()), operatorIssuer["jwk"].(map[string]interface{})["n"], "The operator's public key should be used for its issuer")
+		assert.Equal(t, "AQAB", operatorIssuer["jwk"].(map[string]interface{})["e"], "Wrong public exponent")
+	}
+	authorization = config["authorization"].(map[string]interface{})
+	assert.Equal(t, "solr.RuleBasedAuthorizationPlugin", authorization["class"], "The roles should be mapped from users when no rolesClaim is provided")
+	assert.Equal(t, map[string][]string{"alice": {"admin"}, solr.DefaultBasicAuthUsername: {"k8s"}}, authorization["user-role"], "The operator's user should be given the k8s role")
+
+	// The generated config must be valid JSON
+	_, err = json.Marshal(config)
+	assert.NoError(t, err, "The security config should be marshalled to JSON")
+}
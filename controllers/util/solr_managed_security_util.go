@@ -0,0 +1,348 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"strings"
+)
+
+const (
+	// OperatorPermissionPrefix is the prefix of the names of the permissions that the Solr Operator needs
+	OperatorPermissionPrefix = "k8s-"
+
+	// The Security API can only manage users with the BasicAuthPlugin, and roles and permissions with the RuleBasedAuthorizationPlugins
+	basicAuthPluginSuffix              = "BasicAuthPlugin"
+	ruleBasedAuthorizationPluginSuffix = "RuleBasedAuthorizationPlugin"
+)
+
+// DesiredSecurityConfig is the security config that the Solr Operator keeps in sync with the live security config of a SolrCloud
+type DesiredSecurityConfig struct {
+	// The plain text password of each user, when the users are defined in managedSecurity
+	Passwords map[string]string
+
+	// The "<hash> <salt>" credential of each user, when the users are defined in the bootstrapSecurityJson
+	PasswordHashes map[string]string
+
+	UserRoles map[string][]string
+
+	Permissions []map[string]interface{}
+}
+
+// SecurityCommands are the Security API commands that will make the live security config match the desired security config
+type SecurityCommands struct {
+	Authentication []map[string]interface{}
+
+	Authorization []map[string]interface{}
+
+	// Users of the desired config whose credentials cannot be set through the Security API, since only their password hash is known
+	UnsettableUsers []string
+}
+
+// DesiredSecurityConfigFromJson returns the users, user roles and permissions of a security.json
+func DesiredSecurityConfigFromJson(securityJson string) (*DesiredSecurityConfig, error) {
+	parsed := &struct {
+		Authentication solr_api.SolrAuthenticationConfig `json:"authentication"`
+		Authorization  solr_api.SolrAuthorizationConfig  `json:"authorization"`
+	}{}
+	if err := json.Unmarshal([]byte(securityJson), parsed); err != nil {
+		return nil, fmt.Errorf("cannot parse the bootstrapSecurityJson: %w", err)
+	}
+	desired := &DesiredSecurityConfig{
+		PasswordHashes: parsed.Authentication.Credentials,
+		UserRoles:      make(map[string][]string, len(parsed.Authorization.UserRole)),
+		Permissions:    parsed.Authorization.Permissions,
+	}
+	for user, roles := range parsed.Authorization.UserRole {
+		desired.UserRoles[user] = userRoleList(roles)
+	}
+	return desired, nil
+}
+
+// DesiredSecurityConfigFromSpec returns the users, user roles and permissions defined in managedSecurity
+// opts: the managedSecurity options of the SolrCloud
+// passwords: the password of each user, loaded from their passwordSecret
+func DesiredSecurityConfigFromSpec(opts *solr.SolrManagedSecurityOptions, passwords map[string]string) *DesiredSecurityConfig {
+	desired := &DesiredSecurityConfig{
+		Passwords: passwords,
+		UserRoles: make(map[string][]string, len(opts.Users)),
+	}
+	for _, user := range opts.Users {
+		desired.UserRoles[user.Name] = append([]string{}, user.Roles...)
+	}
+	for _, permission := range opts.Permissions {
+		permissionMap := map[string]interface{}{"name": permission.Name}
+		if permission.Collection != nil {
+			if *permission.Collection == "" {
+				// A null collection is how Solr refers to requests that are not for a collection
+				permissionMap["collection"] = nil
+			} else {
+				permissionMap["collection"] = *permission.Collection
+			}
+		}
+		if len(permission.Path) > 0 {
+			permissionMap["path"] = permission.Path
+		}
+		if len(permission.Method) > 0 {
+			permissionMap["method"] = permission.Method
+		}
+		if len(permission.Params) > 0 {
+			permissionMap["params"] = permission.Params
+		}
+		if len(permission.Role) > 0 {
+			permissionMap["role"] = permission.Role
+		} else {
+			permissionMap["role"] = nil
+		}
+		desired.Permissions = append(desired.Permissions, permissionMap)
+	}
+	return desired
+}
+
+// GenerateSecurityCommands compares the desired security config with the live security config, and returns the commands that need to be sent to the Security API.
+// The credentials and roles of the protectedUsers are never changed, so that the Solr Operator does not lock itself out of Solr.
+func GenerateSecurityCommands(desired *DesiredSecurityConfig, liveAuthentication *solr_api.SolrAuthenticationConfig, liveAuthorization *solr_api.SolrAuthorizationConfig, protectedUsers []string) (commands SecurityCommands) {
+	protected := make(map[string]bool, len(protectedUsers))
+	for _, user := range protectedUsers {
+		protected[user] = true
+	}
+
+	// Credentials
+	setUsers := make(map[string]string)
+	var deleteUsers []string
+	for user, password := range desired.Passwords {
+		liveCredential, exists := liveAuthentication.Credentials[user]
+		if !protected[user] && (!exists || !solrPasswordMatchesHash([]byte(password), liveCredential)) {
+			setUsers[user] = password
+		}
+	}
+	for user, credential := range desired.PasswordHashes {
+		if !protected[user] && liveAuthentication.Credentials[user] != credential {
+			commands.UnsettableUsers = append(commands.UnsettableUsers, user)
+		}
+	}
+	sort.Strings(commands.UnsettableUsers)
+	for user := range liveAuthentication.Credentials {
+		_, hasPassword := desired.Passwords[user]
+		_, hasPasswordHash := desired.PasswordHashes[user]
+		if !protected[user] && !hasPassword && !hasPasswordHash {
+			deleteUsers = append(deleteUsers, user)
+		}
+	}
+	sort.Strings(deleteUsers)
+	if len(setUsers) > 0 {
+		commands.Authentication = append(commands.Authentication, map[string]interface{}{"set-user": setUsers})
+	}
+	if len(deleteUsers) > 0 {
+		commands.Authentication = append(commands.Authentication, map[string]interface{}{"delete-user": deleteUsers})
+	}
+
+	// User roles
+	setUserRoles := make(map[string]interface{})
+	for user, roles := range desired.UserRoles {
+		if protected[user] {
+			continue
+		}
+		liveRoles, exists := liveAuthorization.UserRole[user]
+		if !exists || !sameRoles(roles, userRoleList(liveRoles)) {
+			setUserRoles[user] = roles
+		}
+	}
+	for user := range liveAuthorization.UserRole {
+		if _, desiredUser := desired.UserRoles[user]; !desiredUser && !protected[user] {
+			// A null value removes the roles of the user
+			setUserRoles[user] = nil
+		}
+	}
+	if len(setUserRoles) > 0 {
+		commands.Authorization = append(commands.Authorization, map[string]interface{}{"set-user-role": setUserRoles})
+	}
+
+	// Permissions are matched by their position, since the first matching permission is used by Solr
+	livePermissions := make([]map[string]interface{}, len(liveAuthorization.Permissions))
+	for i, permission := range liveAuthorization.Permissions {
+		livePermissions[i] = normalizePermission(permission)
+	}
+	desiredPermissions := make([]map[string]interface{}, 0, len(desired.Permissions))
+	hasOperatorPermissions := false
+	for _, permission := range desired.Permissions {
+		normalized := normalizePermission(permission)
+		if name, isString := normalized["name"].(string); isString && strings.HasPrefix(name, OperatorPermissionPrefix) {
+			hasOperatorPermissions = true
+		}
+		desiredPermissions = append(desiredPermissions, normalized)
+	}
+	if !hasOperatorPermissions {
+		// Keep the permissions that the Solr Operator needs, if the desired config does not manage them
+		var operatorPermissions []map[string]interface{}
+		for _, permission := range livePermissions {
+			if name, isString := permission["name"].(string); isString && strings.HasPrefix(name, OperatorPermissionPrefix) {
+				operatorPermissions = append(operatorPermissions, permission)
+			}
+		}
+		desiredPermissions = append(operatorPermissions, desiredPermissions...)
+	}
+	for i, permission := range desiredPermissions {
+		if i < len(livePermissions) {
+			if !reflect.DeepEqual(permission, livePermissions[i]) {
+				// Providing the index replaces the permission at that index
+				replacement := copyPermission(permission)
+				replacement["index"] = i + 1
+				commands.Authorization = append(commands.Authorization, map[string]interface{}{"set-permission": replacement})
+			}
+		} else {
+			commands.Authorization = append(commands.Authorization, map[string]interface{}{"set-permission": permission})
+		}
+	}
+	// Permissions are deleted from the end of the list, since Solr re-indexes the permissions after each deletion
+	for i := len(livePermissions); i > len(desiredPermissions); i-- {
+		commands.Authorization = append(commands.Authorization, map[string]interface{}{"delete-permission": i})
+	}
+
+	return commands
+}
+
+// ReconcileManagedSecurity makes the live security config of the SolrCloud match its managedSecurity config, using the Security API.
+// The number of commands sent to Solr are returned, along with the users whose credentials could not be set.
+func ReconcileManagedSecurity(ctx context.Context, client *client.Client, instance *solr.SolrCloud, security *SecurityConfig, logger logr.Logger) (appliedCommands int, unsettableUsers []string, err error) {
+	opts := instance.Spec.SolrSecurity.ManagedSecurity
+
+	var desired *DesiredSecurityConfig
+	if opts.FromBootstrapSecurityJson {
+		if desired, err = DesiredSecurityConfigFromJson(security.SecurityJson); err != nil {
+			return 0, nil, err
+		}
+	} else {
+		passwords := make(map[string]string, len(opts.Users))
+		for _, user := range opts.Users {
+			if passwords[user.Name], err = loadSecretKeyValue(ctx, client, &user.PasswordSecret, instance.Namespace); err != nil {
+				return 0, nil, fmt.Errorf("cannot load the password of user %s: %w", user.Name, err)
+			}
+		}
+		desired = DesiredSecurityConfigFromSpec(opts, passwords)
+	}
+
+	adminSecret, err := securityAdminSecret(ctx, client, instance, security)
+	if err != nil {
+		return 0, nil, err
+	}
+	protectedUsers := []string{string(security.CredentialsSecret.Data[corev1.BasicAuthUsernameKey]), string(adminSecret.Data[corev1.BasicAuthUsernameKey])}
+	adminCtx := contextWithBasicAuthHeader(ctx, adminSecret)
+
+	authentication, err := solr_api.GetSecurityConfig(adminCtx, instance, solr_api.SecurityAuthenticationPath)
+	if err != nil {
+		return 0, nil, err
+	}
+	if authentication.Authentication == nil || !strings.HasSuffix(authentication.Authentication.Class, basicAuthPluginSuffix) {
+		return 0, nil, fmt.Errorf("managed security requires Solr to use the BasicAuthPlugin for authentication")
+	}
+	authorization, err := solr_api.GetSecurityConfig(adminCtx, instance, solr_api.SecurityAuthorizationPath)
+	if err != nil {
+		return 0, nil, err
+	}
+	if authorization.Authorization == nil || !strings.HasSuffix(authorization.Authorization.Class, ruleBasedAuthorizationPluginSuffix) {
+		return 0, nil, fmt.Errorf("managed security requires Solr to use the RuleBasedAuthorizationPlugin for authorization")
+	}
+
+	commands := GenerateSecurityCommands(desired, authentication.Authentication, authorization.Authorization, protectedUsers)
+	for _, command := range commands.Authentication {
+		logger.Info("Updating the Solr authentication config", "command", commandNames(command))
+		if err = solr_api.EditSecurityConfig(adminCtx, instance, solr_api.SecurityAuthenticationPath, command); err != nil {
+			return appliedCommands, commands.UnsettableUsers, err
+		}
+		appliedCommands++
+	}
+	for _, command := range commands.Authorization {
+		logger.Info("Updating the Solr authorization config", "command", commandNames(command))
+		if err = solr_api.EditSecurityConfig(adminCtx, instance, solr_api.SecurityAuthorizationPath, command); err != nil {
+			return appliedCommands, commands.UnsettableUsers, err
+		}
+		appliedCommands++
+	}
+	return appliedCommands, commands.UnsettableUsers, nil
+}
+
+// normalizePermission removes the index of a permission, and unwraps lists with a single value,
+// since Solr accepts both a single value and a list for most fields of a permission.
+func normalizePermission(permission map[string]interface{}) map[string]interface{} {
+	normalized := make(map[string]interface{}, len(permission))
+	// Round-trip through JSON, so that desired and live permissions use the same types
+	if permissionJson, err := json.Marshal(permission); err == nil {
+		_ = json.Unmarshal(permissionJson, &normalized)
+	}
+	delete(normalized, "index")
+	for key, value := range normalized {
+		if list, isList := value.([]interface{}); isList && len(list) == 1 {
+			normalized[key] = list[0]
+		}
+	}
+	return normalized
+}
+
+func copyPermission(permission map[string]interface{}) map[string]interface{} {
+	permissionCopy := make(map[string]interface{}, len(permission)+1)
+	for key, value := range permission {
+		permissionCopy[key] = value
+	}
+	return permissionCopy
+}
+
+// userRoleList returns the roles of a user in the "user-role" config, which can either be a single role or a list of roles
+func userRoleList(roles interface{}) (roleList []string) {
+	switch typedRoles := roles.(type) {
+	case string:
+		roleList = []string{typedRoles}
+	case []string:
+		roleList = typedRoles
+	case []interface{}:
+		for _, role := range typedRoles {
+			if roleString, isString := role.(string); isString {
+				roleList = append(roleList, roleString)
+			}
+		}
+	}
+	return roleList
+}
+
+func sameRoles(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	return reflect.DeepEqual(sortedA, sortedB)
+}
+
+// commandNames returns the names of the commands, without the data of the commands which can include passwords
+func commandNames(command map[string]interface{}) (names []string) {
+	for name := range command {
+		names = append(names, name)
+	}
+	return names
+}
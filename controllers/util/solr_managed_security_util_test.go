@@ -0,0 +1,164 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestSolrPasswordMatchesHash(t *testing.T) {
+	credential := solrPasswordHash([]byte("secret"))
+	assert.True(t, solrPasswordMatchesHash([]byte("secret"), credential), "The password should match its own hash")
+	assert.False(t, solrPasswordMatchesHash([]byte("other"), credential), "A different password should not match the hash")
+	assert.False(t, solrPasswordMatchesHash([]byte("secret"), "invalid"), "An invalid credential should not match")
+
+	// The hash of "SolrRocks" from the Solr Reference Guide
+	assert.True(t, solrPasswordMatchesHash([]byte("SolrRocks"), "IV0EHq1OnNrj6gvRCwvFwTrZ1+z1oBbnQdiVC3otuq0= Ndd7LKvVBAaZIF0QAVi1ekCfAJXr1GGfLtRUXhgrF8c="), "The password should match the hash generated by Solr")
+}
+
+func TestGenerateSecurityCommandsFromSpec(t *testing.T) {
+	liveAuthentication := &solr_api.SolrAuthenticationConfig{
+		Class: "solr.BasicAuthPlugin",
+		Credentials: map[string]string{
+			"k8s-oper": solrPasswordHash([]byte("operator")),
+			"admin":    solrPasswordHash([]byte("admin")),
+			"alice":    solrPasswordHash([]byte("alice")),
+			"bob":      solrPasswordHash([]byte("bob")),
+			"old":      solrPasswordHash([]byte("old")),
+		},
+	}
+	liveAuthorization := &solr_api.SolrAuthorizationConfig{
+		Class: "solr.RuleBasedAuthorizationPlugin",
+		UserRole: map[string]interface{}{
+			"k8s-oper": "k8s",
+			"admin":    []interface{}{"admin", "k8s"},
+			"alice":    []interface{}{"users"},
+			"old":      []interface{}{"users"},
+		},
+		Permissions: []map[string]interface{}{
+			{"name": "k8s-status", "role": "k8s", "collection": nil, "path": "/admin/collections", "index": 1},
+			{"name": "read", "role": []interface{}{"admin", "users"}, "index": 2},
+			{"name": "update", "role": []interface{}{"admin"}, "index": 3},
+			{"name": "all", "role": []interface{}{"admin"}, "index": 4},
+		},
+	}
+	opts := &solr.SolrManagedSecurityOptions{
+		Users: []solr.SolrSecurityUser{
+			{Name: "alice", PasswordSecret: corev1.SecretKeySelector{Key: "alice"}, Roles: []string{"users"}},
+			{Name: "bob", PasswordSecret: corev1.SecretKeySelector{Key: "bob"}, Roles: []string{"users", "writers"}},
+			{Name: "carol", PasswordSecret: corev1.SecretKeySelector{Key: "carol"}, Roles: []string{"admin"}},
+			{Name: "k8s-oper", PasswordSecret: corev1.SecretKeySelector{Key: "k8s-oper"}, Roles: []string{"admin"}},
+		},
+		Permissions: []solr.SolrSecurityPermission{
+			{Name: "read", Role: []string{"admin", "users"}},
+			{Name: "update", Role: []string{"admin", "writers"}},
+		},
+	}
+	desired := DesiredSecurityConfigFromSpec(opts, map[string]string{"alice": "alice", "bob": "new-password", "carol": "carol", "k8s-oper": "changed"})
+
+	commands := GenerateSecurityCommands(desired, liveAuthentication, liveAuthorization, []string{"k8s-oper", "admin"})
+	assert.Empty(t, commands.UnsettableUsers, "All users have plain text passwords")
+	assert.Equal(t, []map[string]interface{}{
+		{"set-user": map[string]string{"bob": "new-password", "carol": "carol"}},
+		{"delete-user": []string{"old"}},
+	}, commands.Authentication, "Only new users and changed passwords should be set, and the operator's user should not be changed")
+
+	if assert.Len(t, commands.Authorization, 3, "Wrong number of authorization commands") {
+		assert.Equal(t, map[string]interface{}{"set-user-role": map[string]interface{}{
+			"bob":   []string{"users", "writers"},
+			"carol": []string{"admin"},
+			"old":   nil,
+		}}, commands.Authorization[0], "Only changed user roles should be set, and removed users should have their roles removed")
+		assert.Equal(t, map[string]interface{}{"set-permission": map[string]interface{}{"name": "update", "role": []interface{}{"admin", "writers"}, "index": 3}}, commands.Authorization[1], "A changed permission should be replaced at its index")
+		assert.Equal(t, map[string]interface{}{"delete-permission": 4}, commands.Authorization[2], "The permissions that are no longer desired should be deleted")
+	}
+
+	// Once the commands are applied, no more commands should be needed
+	liveAuthentication.Credentials["bob"] = solrPasswordHash([]byte("new-password"))
+	liveAuthentication.Credentials["carol"] = solrPasswordHash([]byte("carol"))
+	delete(liveAuthentication.Credentials, "old")
+	liveAuthorization.UserRole["bob"] = []interface{}{"writers", "users"}
+	liveAuthorization.UserRole["carol"] = "admin"
+	delete(liveAuthorization.UserRole, "old")
+	liveAuthorization.Permissions = []map[string]interface{}{
+		{"name": "k8s-status", "role": "k8s", "collection": nil, "path": "/admin/collections", "index": 1},
+		{"name": "read", "role": []interface{}{"admin", "users"}, "index": 2},
+		{"name": "update", "role": []interface{}{"admin", "writers"}, "index": 3},
+	}
+	commands = GenerateSecurityCommands(desired, liveAuthentication, liveAuthorization, []string{"k8s-oper", "admin"})
+	assert.Empty(t, commands.Authentication, "No authentication commands should be needed once the config matches")
+	assert.Empty(t, commands.Authorization, "No authorization commands should be needed once the config matches")
+
+	// New permissions are added to the end of the list
+	opts.Permissions = append(opts.Permissions, solr.SolrSecurityPermission{Name: "custom", Collection: new(string), Path: []string{"/admin/cores"}})
+	desired = DesiredSecurityConfigFromSpec(opts, map[string]string{"alice": "alice", "bob": "new-password", "carol": "carol"})
+	commands = GenerateSecurityCommands(desired, liveAuthentication, liveAuthorization, []string{"k8s-oper", "admin"})
+	assert.Equal(t, []map[string]interface{}{
+		{"set-permission": map[string]interface{}{"name": "custom", "collection": nil, "path": "/admin/cores", "role": nil}},
+	}, commands.Authorization, "A new permission should be added")
+}
+
+func TestGenerateSecurityCommandsFromJson(t *testing.T) {
+	aliceCredential := solrPasswordHash([]byte("alice"))
+	desired, err := DesiredSecurityConfigFromJson(`{
+      "authentication": {
+        "class": "solr.BasicAuthPlugin",
+        "credentials": {"k8s-oper": "unknown", "alice": "` + aliceCredential + `", "bob": "unknown"}
+      },
+      "authorization": {
+        "class": "solr.RuleBasedAuthorizationPlugin",
+        "user-role": {"k8s-oper": ["k8s"], "alice": "admin", "bob": ["users"]},
+        "permissions": [
+          {"name": "k8s-status", "role": "k8s", "collection": null, "path": "/admin/collections"},
+          {"name": "all", "role": ["admin"]}
+        ]
+      }
+    }`)
+	if !assert.NoError(t, err, "The security.json should be parsed") {
+		return
+	}
+	liveAuthentication := &solr_api.SolrAuthenticationConfig{
+		Class: "solr.BasicAuthPlugin",
+		Credentials: map[string]string{
+			"k8s-oper": solrPasswordHash([]byte("operator")),
+			"alice":    aliceCredential,
+		},
+	}
+	liveAuthorization := &solr_api.SolrAuthorizationConfig{
+		Class: "solr.RuleBasedAuthorizationPlugin",
+		UserRole: map[string]interface{}{
+			"k8s-oper": []interface{}{"k8s"},
+			"alice":    []interface{}{"admin"},
+		},
+		Permissions: []map[string]interface{}{
+			{"name": "k8s-status", "role": []interface{}{"k8s"}, "collection": nil, "path": []interface{}{"/admin/collections"}, "index": 1},
+			{"name": "all", "role": "admin", "index": 2},
+		},
+	}
+
+	commands := GenerateSecurityCommands(desired, liveAuthentication, liveAuthorization, []string{"k8s-oper"})
+	assert.Equal(t, []string{"bob"}, commands.UnsettableUsers, "A user with only a password hash cannot be created")
+	assert.Empty(t, commands.Authentication, "No authentication commands can be sent for password hashes")
+	assert.Equal(t, []map[string]interface{}{
+		{"set-user-role": map[string]interface{}{"bob": []string{"users"}}},
+	}, commands.Authorization, "Only the roles of the new user should be set, the permissions are equivalent")
+}
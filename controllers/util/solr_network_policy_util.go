@@ -0,0 +1,192 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sort"
+)
+
+const (
+	// SolrOperatorPodLabel is the label, with the value "solr-operator", that the Solr Operator Helm chart adds to the Solr Operator pods
+	SolrOperatorPodLabel = "control-plane"
+
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+)
+
+// GenerateSolrNetworkPolicy returns a new NetworkPolicy that only allows the expected traffic to the Solr pods of the SolrCloud.
+// The Solr port is allowed from the other Solr pods, the Solr Operator, the given SolrPrometheusExporters and the allowedClients.
+// The port is the podPort, since NetworkPolicies apply to the port that the pod listens on, not the port of the Service.
+// solrCloud: SolrCloud instance
+// exporters: []SolrPrometheusExporter the SolrPrometheusExporters that reference the SolrCloud
+// operatorNamespace: string the namespace that the Solr Operator is running in, if known
+func GenerateSolrNetworkPolicy(solrCloud *solr.SolrCloud, exporters []solr.SolrPrometheusExporter, operatorNamespace string) *netv1.NetworkPolicy {
+	solrPodLabels := solrCloud.SharedLabels()
+	solrPodLabels["technology"] = solr.SolrTechnologyLabel
+
+	peers := []netv1.NetworkPolicyPeer{
+		{PodSelector: &metav1.LabelSelector{MatchLabels: solrPodLabels}},
+		solrOperatorPeer(solrCloud, operatorNamespace),
+	}
+	peers = append(peers, exporterPeers(solrCloud, exporters)...)
+	if solrCloud.Spec.NetworkPolicy != nil {
+		peers = append(peers, solrCloud.Spec.NetworkPolicy.AllowedClients...)
+	}
+
+	return &netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      solrCloud.NetworkPolicyName(),
+			Namespace: solrCloud.GetNamespace(),
+			Labels:    solrCloud.SharedLabelsWith(solrCloud.GetLabels()),
+		},
+		Spec: netv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: solrPodLabels},
+			Ingress: []netv1.NetworkPolicyIngressRule{
+				{
+					From:  peers,
+					Ports: []netv1.NetworkPolicyPort{tcpNetworkPolicyPort(solrCloud.Spec.SolrAddressability.PodPort)},
+				},
+			},
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+		},
+	}
+}
+
+// GenerateZookeeperNetworkPolicy returns a new NetworkPolicy that only allows the expected traffic to the pods of the provided Zookeeper ensemble.
+// The Zookeeper pods can reach each other on any port, the Solr pods and the given SolrPrometheusExporters can reach the client port,
+// and the allowedZookeeperClients can reach any port.
+// solrCloud: SolrCloud instance
+// exporters: []SolrPrometheusExporter the SolrPrometheusExporters that reference the SolrCloud
+func GenerateZookeeperNetworkPolicy(solrCloud *solr.SolrCloud, exporters []solr.SolrPrometheusExporter) *netv1.NetworkPolicy {
+	zkPodLabels := solrCloud.SharedLabels()
+	zkPodLabels["technology"] = solr.ZookeeperTechnologyLabel
+	solrPodLabels := solrCloud.SharedLabels()
+	solrPodLabels["technology"] = solr.SolrTechnologyLabel
+
+	ingressRules := []netv1.NetworkPolicyIngressRule{
+		{
+			From: []netv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: zkPodLabels}}},
+		},
+		{
+			From:  append([]netv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: solrPodLabels}}}, exporterPeers(solrCloud, exporters)...),
+			Ports: []netv1.NetworkPolicyPort{tcpNetworkPolicyPort(ZookeeperClientPort)},
+		},
+	}
+	if solrCloud.Spec.NetworkPolicy != nil && len(solrCloud.Spec.NetworkPolicy.AllowedZookeeperClients) > 0 {
+		ingressRules = append(ingressRules, netv1.NetworkPolicyIngressRule{
+			From: solrCloud.Spec.NetworkPolicy.AllowedZookeeperClients,
+		})
+	}
+
+	return &netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      solrCloud.ZookeeperNetworkPolicyName(),
+			Namespace: solrCloud.GetNamespace(),
+			Labels:    solrCloud.SharedLabelsWith(solrCloud.GetLabels()),
+		},
+		Spec: netv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: zkPodLabels},
+			Ingress:     ingressRules,
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+		},
+	}
+}
+
+// SolrPrometheusExportersForCloud returns the SolrPrometheusExporters that reference the given SolrCloud by name
+func SolrPrometheusExportersForCloud(solrCloud *solr.SolrCloud, exporters []solr.SolrPrometheusExporter) (cloudExporters []solr.SolrPrometheusExporter) {
+	for _, exporter := range exporters {
+		cloudRef := exporter.Spec.SolrReference.Cloud
+		if cloudRef == nil || cloudRef.Name != solrCloud.Name {
+			continue
+		}
+		namespace := cloudRef.Namespace
+		if namespace == "" {
+			namespace = exporter.Namespace
+		}
+		if namespace == solrCloud.Namespace {
+			cloudExporters = append(cloudExporters, exporter)
+		}
+	}
+	return cloudExporters
+}
+
+// solrOperatorPeer returns the peer that selects the Solr Operator pods, either provided by the user or defaulted to the pods of the Helm chart
+func solrOperatorPeer(solrCloud *solr.SolrCloud, operatorNamespace string) netv1.NetworkPolicyPeer {
+	if solrCloud.Spec.NetworkPolicy != nil && solrCloud.Spec.NetworkPolicy.SolrOperator != nil {
+		return *solrCloud.Spec.NetworkPolicy.SolrOperator
+	}
+	peer := netv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{SolrOperatorPodLabel: "solr-operator"}},
+		// If the namespace of the Solr Operator is unknown, it must be looked for in all namespaces
+		NamespaceSelector: &metav1.LabelSelector{},
+	}
+	if operatorNamespace != "" {
+		peer.NamespaceSelector.MatchLabels = map[string]string{namespaceNameLabel: operatorNamespace}
+	}
+	return peer
+}
+
+// exporterPeers returns a peer for the pods of the given SolrPrometheusExporters in each namespace.
+// The peers are sorted, so that the generated NetworkPolicy does not change when the order of the exporters does.
+func exporterPeers(solrCloud *solr.SolrCloud, exporters []solr.SolrPrometheusExporter) (peers []netv1.NetworkPolicyPeer) {
+	exportersByNamespace := make(map[string][]string)
+	for _, exporter := range exporters {
+		exportersByNamespace[exporter.Namespace] = append(exportersByNamespace[exporter.Namespace], exporter.Name)
+	}
+	namespaces := make([]string, 0, len(exportersByNamespace))
+	for namespace := range exportersByNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		exporterNames := exportersByNamespace[namespace]
+		sort.Strings(exporterNames)
+		peer := netv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"technology": solr.SolrPrometheusExporterTechnologyLabel},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      solr.SolrPrometheusExporterTechnologyLabel,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   exporterNames,
+					},
+				},
+			},
+		}
+		if namespace != solrCloud.Namespace {
+			peer.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{namespaceNameLabel: namespace}}
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+// tcpNetworkPolicyPort returns a NetworkPolicyPort with the protocol set explicitly, since Kubernetes defaults it
+func tcpNetworkPolicyPort(port int) netv1.NetworkPolicyPort {
+	protocol := corev1.ProtocolTCP
+	portValue := intstr.FromInt(port)
+	return netv1.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &portValue,
+	}
+}
@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestGenerateSolrNetworkPolicy(t *testing.T) {
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solr.SolrCloudSpec{
+			SolrAddressability: solr.SolrAddressabilityOptions{
+				PodPort: 8000,
+			},
+			NetworkPolicy: &solr.SolrNetworkPolicyOptions{
+				AllowedClients: []netv1.NetworkPolicyPeer{
+					{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"}}},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	exporters := SolrPrometheusExportersForCloud(solrCloud, []solr.SolrPrometheusExporter{
+		{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default"}, Spec: solr.SolrPrometheusExporterSpec{SolrReference: solr.SolrReference{Cloud: &solr.SolrCloudReference{Name: "foo"}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"}, Spec: solr.SolrPrometheusExporterSpec{SolrReference: solr.SolrReference{Cloud: &solr.SolrCloudReference{Name: "foo", Namespace: "default"}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "monitoring"}, Spec: solr.SolrPrometheusExporterSpec{SolrReference: solr.SolrReference{Cloud: &solr.SolrCloudReference{Name: "foo", Namespace: "default"}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "d", Namespace: "monitoring"}, Spec: solr.SolrPrometheusExporterSpec{SolrReference: solr.SolrReference{Cloud: &solr.SolrCloudReference{Name: "foo"}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "e", Namespace: "default"}, Spec: solr.SolrPrometheusExporterSpec{SolrReference: solr.SolrReference{Cloud: &solr.SolrCloudReference{Name: "bar"}}}},
+	})
+	assert.Len(t, exporters, 3, "Only the exporters that reference the SolrCloud should be found")
+
+	networkPolicy := GenerateSolrNetworkPolicy(solrCloud, exporters, "solr-operator")
+	assert.Equal(t, "foo-solrcloud", networkPolicy.Name, "Wrong name for the Solr NetworkPolicy")
+	assert.Equal(t, map[string]string{"solr-cloud": "foo", "technology": solr.SolrTechnologyLabel}, networkPolicy.Spec.PodSelector.MatchLabels, "The NetworkPolicy should select the Solr pods")
+	assert.Equal(t, []netv1.PolicyType{netv1.PolicyTypeIngress}, networkPolicy.Spec.PolicyTypes, "Only ingress traffic should be restricted")
+	if assert.Len(t, networkPolicy.Spec.Ingress, 1, "There should be one ingress rule for the Solr port") {
+		rule := networkPolicy.Spec.Ingress[0]
+		if assert.Len(t, rule.Ports, 1, "Only the Solr port should be allowed") {
+			assert.Equal(t, 8000, rule.Ports[0].Port.IntValue(), "The podPort should be allowed")
+		}
+		if assert.Len(t, rule.From, 5, "Wrong number of peers for the Solr port") {
+			assert.Equal(t, map[string]string{"solr-cloud": "foo", "technology": solr.SolrTechnologyLabel}, rule.From[0].PodSelector.MatchLabels, "The first peer should be the Solr pods")
+			assert.Nil(t, rule.From[0].NamespaceSelector, "The Solr pods are in the same namespace")
+
+			assert.Equal(t, map[string]string{"control-plane": "solr-operator"}, rule.From[1].PodSelector.MatchLabels, "The second peer should be the Solr Operator")
+			assert.Equal(t, map[string]string{"kubernetes.io/metadata.name": "solr-operator"}, rule.From[1].NamespaceSelector.MatchLabels, "The Solr Operator peer should select the namespace of the Solr Operator")
+
+			assert.Nil(t, rule.From[2].NamespaceSelector, "The exporters in the namespace of the SolrCloud should not need a namespaceSelector")
+			assert.Equal(t, []string{"a", "b"}, rule.From[2].PodSelector.MatchExpressions[0].Values, "The exporters in the namespace of the SolrCloud should be sorted")
+			assert.Equal(t, map[string]string{"kubernetes.io/metadata.name": "monitoring"}, rule.From[3].NamespaceSelector.MatchLabels, "The exporters in other namespaces should have a namespaceSelector")
+			assert.Equal(t, []string{"c"}, rule.From[3].PodSelector.MatchExpressions[0].Values, "Only the exporters that reference the namespace of the SolrCloud should be allowed")
+
+			assert.Equal(t, solrCloud.Spec.NetworkPolicy.AllowedClients[0], rule.From[4], "The allowedClients should be the last peers")
+		}
+	}
+
+	// The namespace of the Solr Operator is not always known
+	networkPolicy = GenerateSolrNetworkPolicy(solrCloud, nil, "")
+	assert.Len(t, networkPolicy.Spec.Ingress[0].From, 3, "There should be no exporter peers")
+	assert.Equal(t, &metav1.LabelSelector{}, networkPolicy.Spec.Ingress[0].From[1].NamespaceSelector, "The Solr Operator should be looked for in all namespaces if its namespace is unknown")
+
+	solrOperatorPeer := netv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-operator"}}}
+	solrCloud.Spec.NetworkPolicy.SolrOperator = &solrOperatorPeer
+	networkPolicy = GenerateSolrNetworkPolicy(solrCloud, nil, "solr-operator")
+	assert.Equal(t, solrOperatorPeer, networkPolicy.Spec.Ingress[0].From[1], "The provided Solr Operator peer should be used")
+
+	// The generated NetworkPolicy contains every field that Kubernetes defaults, so it should not cause constant updates
+	foundPolicy := networkPolicy.DeepCopy()
+	assert.False(t, CopyNetworkPolicyFields(GenerateSolrNetworkPolicy(solrCloud, nil, "solr-operator"), foundPolicy, logr.Discard()), "An unchanged NetworkPolicy should not require an update")
+	solrCloud.Spec.SolrAddressability.PodPort = 8983
+	assert.True(t, CopyNetworkPolicyFields(GenerateSolrNetworkPolicy(solrCloud, nil, "solr-operator"), foundPolicy, logr.Discard()), "Changing the podPort should require an update")
+	assert.Equal(t, 8983, foundPolicy.Spec.Ingress[0].Ports[0].Port.IntValue(), "The new podPort should be allowed")
+}
+
+func TestGenerateZookeeperNetworkPolicy(t *testing.T) {
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solr.SolrCloudSpec{
+			NetworkPolicy: &solr.SolrNetworkPolicyOptions{},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+
+	networkPolicy := GenerateZookeeperNetworkPolicy(solrCloud, nil)
+	assert.Equal(t, "foo-solrcloud-zookeeper", networkPolicy.Name, "Wrong name for the Zookeeper NetworkPolicy")
+	assert.Equal(t, map[string]string{"solr-cloud": "foo", "technology": solr.ZookeeperTechnologyLabel}, networkPolicy.Spec.PodSelector.MatchLabels, "The NetworkPolicy should select the Zookeeper pods")
+	if assert.Len(t, networkPolicy.Spec.Ingress, 2, "There should be ingress rules for the Zookeeper pods and the Solr pods") {
+		assert.Empty(t, networkPolicy.Spec.Ingress[0].Ports, "The Zookeeper pods should be able to reach each other on any port")
+		assert.Equal(t, networkPolicy.Spec.PodSelector.MatchLabels, networkPolicy.Spec.Ingress[0].From[0].PodSelector.MatchLabels, "The first rule should allow the Zookeeper pods")
+		if assert.Len(t, networkPolicy.Spec.Ingress[1].Ports, 1, "Only the client port should be allowed for Solr") {
+			assert.Equal(t, ZookeeperClientPort, networkPolicy.Spec.Ingress[1].Ports[0].Port.IntValue(), "The Zookeeper client port should be allowed for Solr")
+		}
+		assert.Equal(t, map[string]string{"solr-cloud": "foo", "technology": solr.SolrTechnologyLabel}, networkPolicy.Spec.Ingress[1].From[0].PodSelector.MatchLabels, "The second rule should allow the Solr pods")
+	}
+
+	solrCloud.Spec.NetworkPolicy.AllowedZookeeperClients = []netv1.NetworkPolicyPeer{
+		{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "zookeeper-operator"}}},
+	}
+	networkPolicy = GenerateZookeeperNetworkPolicy(solrCloud, nil)
+	if assert.Len(t, networkPolicy.Spec.Ingress, 3, "There should be an ingress rule for the allowedZookeeperClients") {
+		assert.Equal(t, solrCloud.Spec.NetworkPolicy.AllowedZookeeperClients, networkPolicy.Spec.Ingress[2].From, "Wrong peers for the allowedZookeeperClients")
+		assert.Empty(t, networkPolicy.Spec.Ingress[2].Ports, "The allowedZookeeperClients should be able to reach any port")
+	}
+}
@@ -18,6 +18,7 @@
 package util
 
 import (
+	"fmt"
 	solr "github.com/apache/solr-operator/api/v1beta1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,44 +49,55 @@ func GeneratePodDisruptionBudget(cloud *solr.SolrCloud, selector map[string]stri
 }
 
 /*
-*
 We cannot actually use the shard topology for PDBs, because Kubernetes does not currently support a pod
 mapping to multiple PDBs. Since a Solr pod is sure to host replicas of multiple shards, then we would
-have to create multiple PDBs that cover a single pod. Therefore we can only use the Cloud PDB defined in the method
-above.
+have to create multiple PDBs that cover a single pod.
 
-Whenever we can use this, when generating PDBs, we need to label them so that a list of all PDBs for a cloud can be found easily.
-That way, when we have the list of PDBs to create/update, we will aslo know the list of PDBs that need to be deleted.
+Instead, the Shard PodDisruptionBudget method uses the cluster-wide PDB above, along with a validating webhook for pod evictions,
+which uses the function below to refuse evictions that would take too many replicas of a shard offline.
 
 Kubernetes Documentation: https://kubernetes.io/docs/tasks/run-application/configure-pdb/#arbitrary-controllers-and-selectors
 */
-func createPodDisruptionBudgetForShard(cloud *solr.SolrCloud, collection string, shard string, nodes []string) policyv1.PodDisruptionBudget {
-	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(
-		intstr.ValueOrDefault(cloud.Spec.UpdateStrategy.ManagedUpdateOptions.MaxShardReplicasUnavailable, intstr.FromInt(DefaultMaxShardReplicasUnavailable)),
-		len(nodes),
-		false)
-	if err != nil {
-		maxUnavailable = 1
+
+// IsPodSafeToEvict determines whether the given pod can be evicted, without taking more than MaxShardReplicasUnavailable replicas of any shard offline.
+// The replicas of the terminatingPods are considered not active, since they will soon be taken offline even if the cluster state does not yet reflect it.
+// This uses the same rules as the Managed Update strategy, when choosing which pods are safe to restart.
+func IsPodSafeToEvict(cloud *solr.SolrCloud, podName string, terminatingPods []string, state NodeReplicaState) (isSafe bool, reason string) {
+	nodeContent, isInClusterState := state.PodContents(cloud, podName)
+	if !isInClusterState || !nodeContent.InClusterState() {
+		return true, "Pod is not represented in the cluster state"
 	}
-	// From the documentation above, Kubernetes will only accept an int minAvailable for PDBs that use custom pod selectors.
-	// Therefore, we cannot use the maxUnavailable straight from what the user provides.
-	minAvailable := intstr.FromInt(len(nodes) - maxUnavailable)
-	return policyv1.PodDisruptionBudget{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      cloud.Name + "-" + collection + "-" + shard,
-			Namespace: cloud.Namespace,
-		},
-		Spec: policyv1.PodDisruptionBudgetSpec{
-			Selector: &metav1.LabelSelector{
-				MatchExpressions: []metav1.LabelSelectorRequirement{
-					{
-						Key:      "statefulset.kubernetes.io/pod-name",
-						Operator: "In",
-						Values:   nodes,
-					},
-				},
-			},
-			MinAvailable: &minAvailable,
-		},
+	if !nodeContent.live {
+		return true, "Pod's Solr Node is not live, therefore it is safe to take down"
+	}
+
+	shardReplicasNotActive := make(map[string]int, len(state.ShardReplicasNotActive))
+	for shard, notActiveReplicaCount := range state.ShardReplicasNotActive {
+		shardReplicasNotActive[shard] = notActiveReplicaCount
+	}
+	for _, terminatingPod := range terminatingPods {
+		if terminatingContent, isTerminatingInClusterState := state.PodContents(cloud, terminatingPod); isTerminatingInClusterState && terminatingContent.live {
+			for shard, activeReplicaCount := range terminatingContent.activeReplicasPerShard {
+				shardReplicasNotActive[shard] += activeReplicaCount
+			}
+		}
+	}
+
+	maxShardReplicasUnavailable := cloud.Spec.UpdateStrategy.ManagedUpdateOptions.MaxShardReplicasUnavailable
+	maxShardReplicasUnavailableCache := make(map[string]int, len(state.TotalShardReplicas))
+	for shard, replicaCount := range nodeContent.totalReplicasPerShard {
+		// If all of the replicas for a shard on the node are down, then evicting the pod does not affect the shard.
+		if replicaCount == nodeContent.downReplicasPerShard[shard] {
+			continue
+		}
+		notActiveReplicaCount := shardReplicasNotActive[shard]
+		maxShardReplicasDown, _ := ResolveMaxShardReplicasUnavailable(maxShardReplicasUnavailable, shard, state.TotalShardReplicas, maxShardReplicasUnavailableCache)
+
+		// Like with updates, pods that have multiple replicas of a shard must be allowed to be evicted when the rest of the shard is active.
+		// Otherwise, the pod could never be evicted.
+		if notActiveReplicaCount > 0 && notActiveReplicaCount+nodeContent.activeReplicasPerShard[shard] > maxShardReplicasDown {
+			return false, fmt.Sprintf("Shard %s already has %d replicas not active, taking down %d more would put it over the maximum allowed down: %d", shard, notActiveReplicaCount, nodeContent.activeReplicasPerShard[shard], maxShardReplicasDown)
+		}
 	}
+	return true, "Pod's replicas are safe to take down, adhering to the minimum active replicas per shard"
 }
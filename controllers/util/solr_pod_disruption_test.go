@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestIsPodSafeToEvict(t *testing.T) {
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solr.SolrCloudSpec{
+			Replicas: Replicas(5),
+			SolrAddressability: solr.SolrAddressabilityOptions{
+				PodPort: 2000,
+			},
+		},
+	}
+	nodeName := func(pod int) string {
+		return SolrNodeName(solrCloud, solrCloud.GetSolrPodName(pod))
+	}
+	replica := func(pod int, state solr_api.SolrReplicaState) solr_api.SolrReplicaStatus {
+		return solr_api.SolrReplicaStatus{State: state, Core: "core", NodeName: nodeName(pod), Type: solr_api.NRT}
+	}
+	clusterStatus := solr_api.SolrClusterStatus{
+		LiveNodes: []string{nodeName(0), nodeName(1), nodeName(3), nodeName(4)},
+		Collections: map[string]solr_api.SolrCollectionStatus{
+			"col1": {
+				Shards: map[string]solr_api.SolrShardStatus{
+					"shard1": {
+						Replicas: map[string]solr_api.SolrReplicaStatus{
+							"rep-1-1-1": replica(0, solr_api.ReplicaActive),
+							"rep-1-1-2": replica(1, solr_api.ReplicaActive),
+							"rep-1-1-3": replica(2, solr_api.ReplicaDown),
+						},
+						State: solr_api.ShardActive,
+					},
+					"shard2": {
+						Replicas: map[string]solr_api.SolrReplicaStatus{
+							"rep-1-2-1": replica(3, solr_api.ReplicaActive),
+							"rep-1-2-2": replica(4, solr_api.ReplicaActive),
+						},
+						State: solr_api.ShardActive,
+					},
+				},
+			},
+		},
+	}
+	state := findSolrNodeContents(clusterStatus, "", GetAllManagedSolrNodeNames(solrCloud))
+
+	isSafe, _ := IsPodSafeToEvict(solrCloud, solrCloud.GetSolrPodName(0), nil, state)
+	assert.False(t, isSafe, "Pod should not be evictable, since its shard already has a replica that is not active")
+
+	isSafe, _ = IsPodSafeToEvict(solrCloud, solrCloud.GetSolrPodName(2), nil, state)
+	assert.True(t, isSafe, "Pod should be evictable, since its Solr Node is not live")
+
+	isSafe, _ = IsPodSafeToEvict(solrCloud, solrCloud.GetSolrPodName(3), nil, state)
+	assert.True(t, isSafe, "Pod should be evictable, since all other replicas of its shard are active")
+
+	isSafe, _ = IsPodSafeToEvict(solrCloud, solrCloud.GetSolrPodName(3), []string{solrCloud.GetSolrPodName(4)}, state)
+	assert.False(t, isSafe, "Pod should not be evictable, since the other replica of its shard is on a terminating pod")
+
+	isSafe, _ = IsPodSafeToEvict(solrCloud, solrCloud.GetSolrPodName(5), nil, state)
+	assert.True(t, isSafe, "Pod should be evictable, since it is not in the cluster state")
+}
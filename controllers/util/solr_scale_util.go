@@ -23,19 +23,22 @@ import (
 	"github.com/apache/solr-operator/controllers/util/solr_api"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
 	"time"
 )
 
-// BalanceReplicasForCluster takes a SolrCloud and balances all replicas across the Pods that are currently alive.
+// BalanceReplicasForCluster takes a SolrCloud and balances all replicas across the Pods that are currently alive, in all of the given StatefulSets.
 //
 // Note: unlike EvictReplicasForPodIfNecessary, the only way we know that we are done balancingReplicas is by seeing
 // a successful status returned from the command. So if we delete the asyncStatus, and then something happens in the operator,
 // and we lose our state, then we will need to retry the balanceReplicas command. This should be ok since calling
 // balanceReplicas multiple times should not be bad when the replicas for the cluster are already balanced.
-func BalanceReplicasForCluster(ctx context.Context, solrCloud *solr.SolrCloud, statefulSet *appsv1.StatefulSet, balanceReason string, balanceCmdUniqueId string, logger logr.Logger) (balanceComplete bool, requestInProgress bool, retryLaterDuration time.Duration, err error) {
+func BalanceReplicasForCluster(ctx context.Context, solrCloud *solr.SolrCloud, statefulSets []*appsv1.StatefulSet, balanceReason string, balanceCmdUniqueId string, recorder record.EventRecorder, logger logr.Logger) (balanceComplete bool, requestInProgress bool, retryLaterDuration time.Duration, err error) {
 	logger = logger.WithValues("balanceReason", balanceReason)
+	configuredPods, readyPods := StatefulSetReplicaCounts(statefulSets)
 	// If the Cloud has 1 or zero pods, there is no reason to balance replicas.
-	if statefulSet.Spec.Replicas == nil || *statefulSet.Spec.Replicas < 1 {
+	if configuredPods < 1 {
 		balanceComplete = true
 	} else {
 		requestId := "balance-replicas-" + balanceCmdUniqueId
@@ -46,8 +49,8 @@ func BalanceReplicasForCluster(ctx context.Context, solrCloud *solr.SolrCloud, s
 			logger.Error(err, "Error occurred while checking the status of the balance replicas task. Will try again.", "requestId", requestId)
 		} else if asyncState == "notfound" {
 			// Only start the balance command if all pods are ready
-			if *statefulSet.Spec.Replicas != statefulSet.Status.ReadyReplicas {
-				logger.Info("Cannot start balancing replicas until all pods are ready.", "pods", *statefulSet.Spec.Replicas, "readyPods", statefulSet.Status.ReadyReplicas)
+			if configuredPods != readyPods {
+				logger.Info("Cannot start balancing replicas until all pods are ready.", "pods", configuredPods, "readyPods", readyPods)
 			} else {
 				// Submit new BalanceReplicas request
 				rebalanceRequest := &solr_api.SolrRebalanceRequest{
@@ -59,6 +62,7 @@ func BalanceReplicasForCluster(ctx context.Context, solrCloud *solr.SolrCloud, s
 				if isUnsupportedApi, apiError := solr_api.CheckForCollectionsApiError("BALANCE_REPLICAS", rebalanceResponse.ResponseHeader, rebalanceResponse.Error); isUnsupportedApi {
 					// TODO: Remove this if-statement when Solr 9.3 is the lowest supported version
 					logger.Error(err, "Could not balance replicas across the cluster, because the SolrCloud's version does not support this feature.")
+					recorder.Event(solrCloud, corev1.EventTypeWarning, EventReasonBalanceReplicasFailed, "Could not balance replicas across the SolrCloud, because its Solr version does not support this feature")
 					// Swallow the error after logging it, because it's not a real error.
 					// Balancing is not supported, so we just need to finish the clusterOp.
 					err = nil
@@ -69,9 +73,11 @@ func BalanceReplicasForCluster(ctx context.Context, solrCloud *solr.SolrCloud, s
 
 				if !balanceComplete && err == nil {
 					logger.Info("Started balancing replicas across cluster.", "requestId", requestId)
+					recorder.Eventf(solrCloud, corev1.EventTypeNormal, EventReasonBalancingReplicas, "Started balancing replicas across the SolrCloud, reason: %s", balanceReason)
 					requestInProgress = true
 				} else if err != nil {
 					logger.Error(err, "Could not balance replicas across the cluster. Will try again.")
+					recorder.Eventf(solrCloud, corev1.EventTypeWarning, EventReasonBalanceReplicasFailed, "Could not start balancing replicas across the SolrCloud, will try again: %s", err.Error())
 				}
 			}
 		} else {
@@ -80,8 +86,10 @@ func BalanceReplicasForCluster(ctx context.Context, solrCloud *solr.SolrCloud, s
 			if asyncState == "completed" {
 				balanceComplete = true
 				logger.Info("Replica Balancing command completed successfully")
+				recorder.Event(solrCloud, corev1.EventTypeNormal, EventReasonReplicasBalanced, "Finished balancing replicas across the SolrCloud")
 			} else if asyncState == "failed" {
 				logger.Info("Replica Balancing command failed. Will try again", "message", message)
+				recorder.Eventf(solrCloud, corev1.EventTypeWarning, EventReasonBalanceReplicasFailed, "Balancing replicas across the SolrCloud failed, will try again: %s", message)
 			} else {
 				requestInProgress = true
 			}
@@ -102,3 +110,14 @@ func BalanceReplicasForCluster(ctx context.Context, solrCloud *solr.SolrCloud, s
 	}
 	return
 }
+
+// StatefulSetReplicaCounts returns the number of pods configured, and the number of pods that are ready, across the given StatefulSets.
+func StatefulSetReplicaCounts(statefulSets []*appsv1.StatefulSet) (configuredPods int32, readyPods int32) {
+	for _, statefulSet := range statefulSets {
+		if statefulSet.Spec.Replicas != nil {
+			configuredPods += *statefulSet.Spec.Replicas
+		}
+		readyPods += statefulSet.Status.ReadyReplicas
+	}
+	return
+}
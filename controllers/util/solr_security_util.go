@@ -25,12 +25,14 @@ import (
 	"fmt"
 	solr "github.com/apache/solr-operator/api/v1beta1"
 	"github.com/apache/solr-operator/controllers/util/solr_api"
+	"golang.org/x/oauth2"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"math/rand"
+	"net/http"
 	"regexp"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -53,17 +55,39 @@ type SecurityConfig struct {
 	CredentialsSecret *corev1.Secret
 	SecurityJson      string
 	SecurityJsonSrc   *corev1.EnvVarSource
+
+	// The source of the tokens the operator uses for JWT auth
+	TokenSource oauth2.TokenSource
+
+	// The client presenting the operatorClientCert, if one is configured
+	HttpClient *http.Client
 }
 
 // Given a SolrCloud instance and an API service client, produce a SecurityConfig needed to enable Solr security
 func ReconcileSecurityConfig(ctx context.Context, client *client.Client, instance *solr.SolrCloud) (*SecurityConfig, error) {
 	sec := instance.Spec.SolrSecurity
-	if sec.AuthenticationType == solr.Basic {
-		return reconcileForBasicAuth(ctx, client, instance)
+	var security *SecurityConfig
+	var err error
+	switch sec.AuthenticationType {
+	case solr.Basic:
+		security, err = reconcileForBasicAuth(ctx, client, instance)
+	case solr.JWT:
+		security, err = reconcileForJWTAuth(ctx, client, instance)
+	case solr.Cert:
+		security, err = reconcileForCertAuth(ctx, client, instance)
+	default:
+		// shouldn't ever get here since the YAML would be validated against the enum before this, but keeping it here for human readers to grok the overall flow
+		return nil, fmt.Errorf("%s not supported! Only 'Basic', 'JWT' and 'Cert' authentication are supported by the Solr operator", sec.AuthenticationType)
+	}
+
+	// The operatorClientCert can be used along with any authentication type, since Solr may require client certificates regardless
+	if err == nil && sec.OperatorClientCert != "" {
+		security.HttpClient, err = operatorHttpClient(ctx, client, instance)
 	}
-
-	// shouldn't ever get here since the YAML would be validated against the enum before this, but keeping it here for human readers to grok the overall flow
-	return nil, fmt.Errorf("%s not supported! Only 'Basic' authentication is supported by the Solr operator", sec.AuthenticationType)
+	if err != nil {
+		return nil, err
+	}
+	return security, nil
 }
 
 // Reconcile the credentials and supporting config needed to make calls to Solr secured with basic auth
@@ -167,7 +191,7 @@ func reconcileForBasicAuthWithUserProvidedSecret(ctx context.Context, client *cl
 	// is there a user-provided security.json in a secret?
 	// in this config, we don't need to enforce the user providing a security.json as they can bootstrap the security.json however they want
 	if sec.BootstrapSecurityJson != nil {
-		securityJson, err := loadSecurityJsonFromSecret(ctx, client, sec.BootstrapSecurityJson, instance.Namespace)
+		securityJson, err := loadSecretKeyValue(ctx, client, sec.BootstrapSecurityJson, instance.Namespace)
 		if err != nil {
 			return nil, err
 		}
@@ -255,14 +279,27 @@ func cmdToPutSecurityJsonInZk() string {
 
 // Add auth data to the supplied Context using secrets already resolved (stored in the SecurityConfig)
 func (security *SecurityConfig) AddAuthToContext(ctx context.Context) (context.Context, error) {
-	if security.SolrSecurity.AuthenticationType == solr.Basic {
+	if security.HttpClient != nil {
+		ctx = contextWithHttpClient(ctx, security.HttpClient)
+	}
+	switch security.SolrSecurity.AuthenticationType {
+	case solr.Basic:
 		return contextWithBasicAuthHeader(ctx, security.CredentialsSecret), nil
+	case solr.JWT:
+		return contextWithBearerToken(ctx, security.TokenSource)
 	}
 	return ctx, nil
 }
 
 // Similar to security.AddAuthToContext but we need to lookup the secret containing the authn credentials first
 func AddAuthToContext(ctx context.Context, client *client.Client, solrCloud *solr.SolrCloud) (context.Context, error) {
+	if solrCloud.Spec.SolrSecurity != nil && solrCloud.Spec.SolrSecurity.OperatorClientCert != "" {
+		httpClient, err := operatorHttpClient(ctx, client, solrCloud)
+		if err != nil {
+			return nil, err
+		}
+		ctx = contextWithHttpClient(ctx, httpClient)
+	}
 	if solrCloud.Spec.SolrSecurity != nil && solrCloud.Spec.SolrSecurity.AuthenticationType == solr.Basic {
 		reader := *client
 		basicAuthSecret := &corev1.Secret{}
@@ -270,10 +307,49 @@ func AddAuthToContext(ctx context.Context, client *client.Client, solrCloud *sol
 			return nil, err
 		}
 		return contextWithBasicAuthHeader(ctx, basicAuthSecret), nil
+	} else if solrCloud.Spec.SolrSecurity != nil && solrCloud.Spec.SolrSecurity.AuthenticationType == solr.JWT {
+		tokenSource, err := jwtTokenSource(ctx, client, solrCloud)
+		if err != nil {
+			return nil, err
+		}
+		return contextWithBearerToken(ctx, tokenSource)
 	}
 	return ctx, nil
 }
 
+// securityAdminSecret returns the credentials used to call the Security API, which the user of the Solr Operator does not have access to by default
+func securityAdminSecret(ctx context.Context, client *client.Client, instance *solr.SolrCloud, security *SecurityConfig) (*corev1.Secret, error) {
+	reader := *client
+	sec := instance.Spec.SolrSecurity
+	if sec.SecurityAdminSecret != "" {
+		adminSecret := &corev1.Secret{}
+		if err := reader.Get(ctx, types.NamespacedName{Name: sec.SecurityAdminSecret, Namespace: instance.Namespace}, adminSecret); err != nil {
+			return nil, err
+		}
+		return adminSecret, ValidateBasicAuthSecret(adminSecret)
+	}
+	if sec.BasicAuthSecret != "" {
+		return security.CredentialsSecret, nil
+	}
+
+	// The Solr Operator bootstrapped security, so the admin user it created can be used
+	bootstrapSecret := &corev1.Secret{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: instance.SecurityBootstrapSecretName(), Namespace: instance.Namespace}, bootstrapSecret); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("the %s secret containing the admin credentials no longer exists, a securityAdminSecret must be provided", instance.SecurityBootstrapSecretName())
+		}
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: bootstrapSecret.ObjectMeta,
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("admin"),
+			corev1.BasicAuthPasswordKey: bootstrapSecret.Data["admin"],
+		},
+		Type: corev1.SecretTypeBasicAuth,
+	}, nil
+}
+
 func contextWithBasicAuthHeader(ctx context.Context, basicAuthSecret *corev1.Secret) context.Context {
 	creds := fmt.Sprintf("%s:%s", basicAuthSecret.Data[corev1.BasicAuthUsernameKey], basicAuthSecret.Data[corev1.BasicAuthPasswordKey])
 	headerValue := "Basic " + b64.StdEncoding.EncodeToString([]byte(creds))
@@ -409,6 +485,64 @@ func generateSecurityJson(solrCloud *solr.SolrCloud) map[string][]byte {
 	return secretData
 }
 
+// reconcileGeneratedSecurityJson creates the bootstrap secret with the generated security.json, if it does not exist yet.
+// The security.json is only generated once, since it is only used to bootstrap security.
+func reconcileGeneratedSecurityJson(ctx context.Context, client *client.Client, instance *solr.SolrCloud, security *SecurityConfig, generateSecurityJson func() ([]byte, error)) error {
+	reader := *client
+	bootstrapSecret := &corev1.Secret{}
+	err := reader.Get(ctx, types.NamespacedName{Name: instance.SecurityBootstrapSecretName(), Namespace: instance.Namespace}, bootstrapSecret)
+	if err != nil && errors.IsNotFound(err) {
+		securityJson, err := generateSecurityJson()
+		if err != nil {
+			return err
+		}
+		bootstrapSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      instance.SecurityBootstrapSecretName(),
+				Namespace: instance.GetNamespace(),
+				Labels:    instance.SharedLabelsWith(instance.GetLabels()),
+			},
+			Data: map[string][]byte{
+				SecurityJsonFile: securityJson,
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+		if err = controllerutil.SetControllerReference(instance, bootstrapSecret, reader.Scheme()); err != nil {
+			return err
+		}
+		if err = reader.Create(ctx, bootstrapSecret); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	security.SecurityJson = string(bootstrapSecret.Data[SecurityJsonFile])
+	security.SecurityJsonSrc = &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: bootstrapSecret.Name}, Key: SecurityJsonFile}}
+	return nil
+}
+
+// bootstrapPermissions returns the permissions of the security.json bootstrapped for authentication types other than basic auth.
+// They are the same as the permissions bootstrapped for basic auth, except that the probe endpoints are always open.
+func bootstrapPermissions(solrCloud *solr.SolrCloud) []interface{} {
+	var permissions []interface{}
+	for i, p := range getProbePaths(solrCloud) {
+		permissions = append(permissions, map[string]interface{}{"name": fmt.Sprintf("k8s-probe-%d", i), "role": nil, "collection": nil, "path": strings.TrimPrefix(p, "/solr")})
+	}
+	return append(permissions,
+		map[string]interface{}{"name": "k8s-status", "role": solrOperatorRole, "collection": nil, "path": "/admin/collections"},
+		map[string]interface{}{"name": "k8s-metrics", "role": solrOperatorRole, "collection": nil, "path": "/admin/metrics"},
+		map[string]interface{}{"name": "k8s-zk", "role": solrOperatorRole, "collection": nil, "path": "/admin/zookeeper/status"},
+		map[string]interface{}{"name": "k8s-ping", "role": solrOperatorRole, "collection": "*", "path": "/admin/ping"},
+		map[string]interface{}{"name": "read", "role": []string{"admin", "users"}},
+		map[string]interface{}{"name": "update", "role": []string{"admin"}},
+		map[string]interface{}{"name": "security-read", "role": []string{"admin"}},
+		map[string]interface{}{"name": "security-edit", "role": []string{"admin"}},
+		map[string]interface{}{"name": "all", "role": []string{"admin"}},
+	)
+}
+
 func randomPassword() []byte {
 	rand.Seed(time.Now().UnixNano())
 	lower := "abcdefghijklmnpqrstuvwxyz" // no 'o'
@@ -435,14 +569,30 @@ func randomSaltHash() []byte {
 
 // this mimics the password hash generation approach used by Solr
 func solrPasswordHash(passBytes []byte) string {
+	return solrPasswordHashWithSalt(passBytes, randomSaltHash())
+}
+
+func solrPasswordHashWithSalt(passBytes []byte, salt []byte) string {
 	// combine password with salt to create the hash
-	salt := randomSaltHash()
-	passHashBytes := sha256.Sum256(append(salt[:], passBytes...))
+	passHashBytes := sha256.Sum256(append(salt[:len(salt):len(salt)], passBytes...))
 	passHashBytes = sha256.Sum256(passHashBytes[:])
 	passHash := b64.StdEncoding.EncodeToString(passHashBytes[:])
 	return fmt.Sprintf("%s %s", passHash, b64.StdEncoding.EncodeToString(salt))
 }
 
+// solrPasswordMatchesHash checks whether the password matches the "<hash> <salt>" credential stored by the Solr BasicAuthPlugin
+func solrPasswordMatchesHash(passBytes []byte, credential string) bool {
+	parts := strings.Split(credential, " ")
+	if len(parts) != 2 {
+		return false
+	}
+	salt, err := b64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	return solrPasswordHashWithSalt(passBytes, salt) == credential
+}
+
 // Gets a list of probe paths we need to setup authz for
 func getProbePaths(solrCloud *solr.SolrCloud) []string {
 	// Current startup and liveness probes use the same API, so liveness needn't be explicitly specified here
@@ -529,20 +679,20 @@ func useSecureProbe(solrCloud *solr.SolrCloud, probe *corev1.Probe, mountPath st
 }
 
 // Called during reconcile to load the security.json from a user-supplied secret
-func loadSecurityJsonFromSecret(ctx context.Context, client *client.Client, securityJsonSecret *corev1.SecretKeySelector, ns string) (string, error) {
+func loadSecretKeyValue(ctx context.Context, client *client.Client, secretKey *corev1.SecretKeySelector, ns string) (string, error) {
 	sec := &corev1.Secret{}
-	nn := types.NamespacedName{Name: securityJsonSecret.Name, Namespace: ns}
+	nn := types.NamespacedName{Name: secretKey.Name, Namespace: ns}
 	reader := *client
 	err := reader.Get(ctx, nn, sec)
 	if err != nil {
 		return "", err
 	}
 
-	securityJson, hasSecurityJson := sec.Data[securityJsonSecret.Key]
-	if !hasSecurityJson {
+	value, hasKey := sec.Data[secretKey.Key]
+	if !hasKey {
 		return "", fmt.Errorf("required key '%s' not found in the user-supplied secret %s",
-			securityJsonSecret.Key, sec.Name)
+			secretKey.Key, sec.Name)
 	}
 
-	return string(securityJson), nil
+	return string(value), nil
 }
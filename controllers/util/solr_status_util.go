@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"strings"
+)
+
+// SetSolrCloudCondition sets a condition in the SolrCloud status.
+// The lastTransitionTime of the condition is only changed if the status of the condition changes.
+func SetSolrCloudCondition(status *solr.SolrCloudStatus, generation int64, conditionType solr.SolrCloudConditionType, conditionStatus metav1.ConditionStatus, reason string, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               string(conditionType),
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// RemoveSolrCloudCondition removes a condition from the SolrCloud status, if it exists.
+func RemoveSolrCloudCondition(status *solr.SolrCloudStatus, conditionType solr.SolrCloudConditionType) {
+	meta.RemoveStatusCondition(&status.Conditions, string(conditionType))
+}
+
+// UpdateSolrNodeConditions sets the Available, Progressing and BackupReposAvailable conditions of the SolrCloud status,
+// using the Solr Node information that has already been populated in the status.
+// The ClusterOperationInProgress condition must be set before calling this, as it is used to determine whether the SolrCloud is progressing.
+func UpdateSolrNodeConditions(solrCloud *solr.SolrCloud, status *solr.SolrCloudStatus) {
+	generation := solrCloud.Generation
+
+	if status.ReadyReplicas > 0 {
+		SetSolrCloudCondition(status, generation, solr.SolrCloudAvailable, metav1.ConditionTrue, solr.SolrCloudReasonSolrNodesReady, fmt.Sprintf("%d/%d Solr Nodes are ready", status.ReadyReplicas, status.Replicas))
+	} else {
+		SetSolrCloudCondition(status, generation, solr.SolrCloudAvailable, metav1.ConditionFalse, solr.SolrCloudReasonNoSolrNodesReady, fmt.Sprintf("0/%d Solr Nodes are ready", status.Replicas))
+	}
+
+	desiredReplicas := status.Replicas
+	if solrCloud.Spec.Replicas != nil {
+		desiredReplicas = solrCloud.TotalReplicas()
+	}
+	if clusterOpCondition := meta.FindStatusCondition(status.Conditions, string(solr.SolrCloudClusterOperationInProgress)); clusterOpCondition != nil && clusterOpCondition.Status == metav1.ConditionTrue {
+		SetSolrCloudCondition(status, generation, solr.SolrCloudProgressing, metav1.ConditionTrue, solr.SolrCloudReasonClusterOperation, fmt.Sprintf("Running the %s cluster operation", clusterOpCondition.Reason))
+	} else if status.Replicas != desiredReplicas {
+		SetSolrCloudCondition(status, generation, solr.SolrCloudProgressing, metav1.ConditionTrue, solr.SolrCloudReasonScaling, fmt.Sprintf("Scaling from %d to %d Solr Nodes", status.Replicas, desiredReplicas))
+	} else if status.UpToDateNodes < status.Replicas {
+		SetSolrCloudCondition(status, generation, solr.SolrCloudProgressing, metav1.ConditionTrue, solr.SolrCloudReasonUpdatingSolrNodes, fmt.Sprintf("%d/%d Solr Nodes are up-to-date", status.UpToDateNodes, status.Replicas))
+	} else if status.ReadyReplicas < status.Replicas {
+		SetSolrCloudCondition(status, generation, solr.SolrCloudProgressing, metav1.ConditionTrue, solr.SolrCloudReasonWaitingForSolrNodes, fmt.Sprintf("%d/%d Solr Nodes are ready", status.ReadyReplicas, status.Replicas))
+	} else {
+		SetSolrCloudCondition(status, generation, solr.SolrCloudProgressing, metav1.ConditionFalse, solr.SolrCloudReasonReconciled, fmt.Sprintf("All %d Solr Nodes are ready and up-to-date", status.Replicas))
+	}
+
+	if len(solrCloud.Spec.BackupRepositories) == 0 {
+		RemoveSolrCloudCondition(status, solr.SolrCloudBackupReposAvailable)
+	} else if status.BackupRestoreReady {
+		SetSolrCloudCondition(status, generation, solr.SolrCloudBackupReposAvailable, metav1.ConditionTrue, solr.SolrCloudReasonBackupReposAvailable, "All backupRepositories are available on all Solr Nodes")
+	} else {
+		var unavailableRepos []string
+		for _, repo := range solrCloud.Spec.BackupRepositories {
+			if !status.BackupRepositoriesAvailable[repo.Name] {
+				unavailableRepos = append(unavailableRepos, repo.Name)
+			}
+		}
+		sort.Strings(unavailableRepos)
+		SetSolrCloudCondition(status, generation, solr.SolrCloudBackupReposAvailable, metav1.ConditionFalse, solr.SolrCloudReasonBackupReposUnavailable, fmt.Sprintf("backupRepositories not yet available on all Solr Nodes: %s", strings.Join(unavailableRepos, ", ")))
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"testing"
+)
+
+func TestUpdateSolrNodeConditions(t *testing.T) {
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Generation: 2},
+		Spec: solr.SolrCloudSpec{
+			Replicas: pointer.Int32(3),
+			BackupRepositories: []solr.SolrBackupRepository{
+				{Name: "repo1"},
+				{Name: "repo2"},
+			},
+		},
+	}
+	status := &solr.SolrCloudStatus{
+		Replicas:                    3,
+		ReadyReplicas:               2,
+		UpToDateNodes:               1,
+		BackupRestoreReady:          false,
+		BackupRepositoriesAvailable: map[string]bool{"repo1": true, "repo2": false},
+	}
+
+	UpdateSolrNodeConditions(solrCloud, status)
+
+	available := meta.FindStatusCondition(status.Conditions, string(solr.SolrCloudAvailable))
+	if assert.NotNil(t, available, "Available condition not set") {
+		assert.Equal(t, metav1.ConditionTrue, available.Status, "SolrCloud with a ready node should be available")
+		assert.Equal(t, int64(2), available.ObservedGeneration, "Wrong observedGeneration for condition")
+	}
+	progressing := meta.FindStatusCondition(status.Conditions, string(solr.SolrCloudProgressing))
+	if assert.NotNil(t, progressing, "Progressing condition not set") {
+		assert.Equal(t, metav1.ConditionTrue, progressing.Status, "SolrCloud with out-of-date nodes should be progressing")
+		assert.Equal(t, solr.SolrCloudReasonUpdatingSolrNodes, progressing.Reason, "Wrong reason for the Progressing condition")
+	}
+	backupRepos := meta.FindStatusCondition(status.Conditions, string(solr.SolrCloudBackupReposAvailable))
+	if assert.NotNil(t, backupRepos, "BackupReposAvailable condition not set") {
+		assert.Equal(t, metav1.ConditionFalse, backupRepos.Status, "Backup repos should not be available")
+		assert.Contains(t, backupRepos.Message, "repo2", "Unavailable backup repo should be listed in the message")
+		assert.NotContains(t, backupRepos.Message, "repo1", "Available backup repo should not be listed in the message")
+	}
+
+	// A running cluster operation takes precedence over the other reasons for progressing
+	SetSolrCloudCondition(status, 2, solr.SolrCloudClusterOperationInProgress, metav1.ConditionTrue, "RollingUpdate", "")
+	UpdateSolrNodeConditions(solrCloud, status)
+	assert.Equal(t, solr.SolrCloudReasonClusterOperation, meta.FindStatusCondition(status.Conditions, string(solr.SolrCloudProgressing)).Reason, "Wrong reason for the Progressing condition")
+
+	// Once everything is up-to-date and ready, the SolrCloud is no longer progressing
+	lastTransitionTime := available.LastTransitionTime
+	SetSolrCloudCondition(status, 2, solr.SolrCloudClusterOperationInProgress, metav1.ConditionFalse, solr.SolrCloudReasonNoClusterOperation, "")
+	status.ReadyReplicas = 3
+	status.UpToDateNodes = 3
+	status.BackupRestoreReady = true
+	solrCloud.Spec.BackupRepositories = nil
+	UpdateSolrNodeConditions(solrCloud, status)
+
+	assert.True(t, meta.IsStatusConditionTrue(status.Conditions, string(solr.SolrCloudAvailable)), "SolrCloud should be available")
+	assert.Equal(t, lastTransitionTime, meta.FindStatusCondition(status.Conditions, string(solr.SolrCloudAvailable)).LastTransitionTime, "The lastTransitionTime should not change if the status does not change")
+	progressing = meta.FindStatusCondition(status.Conditions, string(solr.SolrCloudProgressing))
+	assert.Equal(t, metav1.ConditionFalse, progressing.Status, "SolrCloud should not be progressing")
+	assert.Equal(t, solr.SolrCloudReasonReconciled, progressing.Reason, "Wrong reason for the Progressing condition")
+	assert.Nil(t, meta.FindStatusCondition(status.Conditions, string(solr.SolrCloudBackupReposAvailable)), "BackupReposAvailable condition should be removed when there are no backupRepositories")
+
+	// Scaling
+	solrCloud.Spec.Replicas = pointer.Int32(5)
+	UpdateSolrNodeConditions(solrCloud, status)
+	assert.Equal(t, solr.SolrCloudReasonScaling, meta.FindStatusCondition(status.Conditions, string(solr.SolrCloudProgressing)).Reason, "Wrong reason for the Progressing condition")
+
+	// No ready nodes
+	status.ReadyReplicas = 0
+	UpdateSolrNodeConditions(solrCloud, status)
+	assert.True(t, meta.IsStatusConditionFalse(status.Conditions, string(solr.SolrCloudAvailable)), "SolrCloud without ready nodes should not be available")
+}
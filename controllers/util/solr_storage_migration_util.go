@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DataVolumeRequiresMigration determines whether a data volume, with the given PVC spec, cannot be used with the data volumeClaimTemplate of the StatefulSet.
+// This is the case when the template uses a different StorageClass, or requests less storage, since neither can be changed for an existing volume.
+// Volumes that request less storage than the template can be expanded instead, so they do not require a migration.
+func DataVolumeRequiresMigration(statefulSet *appsv1.StatefulSet, pvcSpec corev1.PersistentVolumeClaimSpec) bool {
+	if len(statefulSet.Spec.VolumeClaimTemplates) == 0 {
+		return false
+	}
+	templateSpec := statefulSet.Spec.VolumeClaimTemplates[0].Spec
+
+	// If the template does not specify a StorageClass, the default StorageClass is used, which cannot be compared
+	if templateSpec.StorageClassName != nil && (pvcSpec.StorageClassName == nil || *pvcSpec.StorageClassName != *templateSpec.StorageClassName) {
+		return true
+	}
+
+	templateSize, templateHasSize := templateSpec.Resources.Requests[corev1.ResourceStorage]
+	size, hasSize := pvcSpec.Resources.Requests[corev1.ResourceStorage]
+	return templateHasSize && hasSize && size.Cmp(templateSize) > 0
+}
+
+// PodsRequiringStorageMigration returns the names of the pods of the StatefulSet whose data PVC requires a migration to the StatefulSet's data volumeClaimTemplate.
+// PVCs that are already being deleted are not included, since they have already been migrated.
+func PodsRequiringStorageMigration(statefulSet *appsv1.StatefulSet, pvcs []corev1.PersistentVolumeClaim) (podNames []string) {
+	if len(statefulSet.Spec.VolumeClaimTemplates) == 0 {
+		return nil
+	}
+	pvcsByName := make(map[string]*corev1.PersistentVolumeClaim, len(pvcs))
+	for i := range pvcs {
+		pvcsByName[pvcs[i].Name] = &pvcs[i]
+	}
+	for _, podName := range GetStatefulSetPodNames([]*appsv1.StatefulSet{statefulSet}) {
+		if pvc, hasPVC := pvcsByName[DataVolumeClaimName(statefulSet, podName)]; hasPVC && pvc.DeletionTimestamp == nil && DataVolumeRequiresMigration(statefulSet, pvc.Spec) {
+			podNames = append(podNames, podName)
+		}
+	}
+	return podNames
+}
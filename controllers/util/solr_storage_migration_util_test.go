@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"testing"
+)
+
+func TestPodsRequiringStorageMigration(t *testing.T) {
+	pvcSpec := func(storageClass string, size string) corev1.PersistentVolumeClaimSpec {
+		return corev1.PersistentVolumeClaimSpec{
+			StorageClassName: pointer.String(storageClass),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			},
+		}
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-solrcloud"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32(4),
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "data"}, Spec: pvcSpec("fast", "10Gi")},
+			},
+		},
+	}
+
+	assert.False(t, DataVolumeRequiresMigration(statefulSet, pvcSpec("fast", "10Gi")), "A PVC matching the template does not require a migration")
+	assert.False(t, DataVolumeRequiresMigration(statefulSet, pvcSpec("fast", "5Gi")), "A smaller PVC can be expanded, so it does not require a migration")
+	assert.True(t, DataVolumeRequiresMigration(statefulSet, pvcSpec("fast", "20Gi")), "A larger PVC cannot be shrunk, so it requires a migration")
+	assert.True(t, DataVolumeRequiresMigration(statefulSet, pvcSpec("slow", "10Gi")), "A PVC with a different StorageClass requires a migration")
+
+	deleting := metav1.Now()
+	pvcs := []corev1.PersistentVolumeClaim{
+		{ObjectMeta: metav1.ObjectMeta{Name: "data-foo-solrcloud-0"}, Spec: pvcSpec("fast", "10Gi")},
+		{ObjectMeta: metav1.ObjectMeta{Name: "data-foo-solrcloud-1", DeletionTimestamp: &deleting}, Spec: pvcSpec("slow", "10Gi")},
+		{ObjectMeta: metav1.ObjectMeta{Name: "data-foo-solrcloud-2"}, Spec: pvcSpec("slow", "10Gi")},
+		{ObjectMeta: metav1.ObjectMeta{Name: "data-foo-solrcloud-3"}, Spec: pvcSpec("fast", "20Gi")},
+		{ObjectMeta: metav1.ObjectMeta{Name: "data-foo-solrcloud-4"}, Spec: pvcSpec("slow", "10Gi")},
+	}
+	assert.Equal(t, []string{"foo-solrcloud-2", "foo-solrcloud-3"}, PodsRequiringStorageMigration(statefulSet, pvcs), "Wrong pods require a storage migration. PVCs being deleted, or for pods that will not exist, should be skipped")
+
+	// The default StorageClass cannot be compared to the StorageClass of the PVCs
+	statefulSet.Spec.VolumeClaimTemplates[0].Spec.StorageClassName = nil
+	assert.False(t, DataVolumeRequiresMigration(statefulSet, pvcSpec("slow", "10Gi")), "A template without a StorageClass should not cause a migration")
+}
@@ -38,6 +38,7 @@ const (
 	DefaultClientKeyStorePath      = "/var/solr/client-tls"
 	DefaultWritableKeyStorePath    = "/var/solr/tls/pkcs12"
 	TLSCertKey                     = "tls.crt"
+	TLSCACertKey                   = "ca.crt"
 	DefaultTrustStorePath          = "/var/solr/tls-truststore"
 	DefaultClientTrustStorePath    = "/var/solr/client-tls-truststore"
 	InitdbInitContainer            = "generate-init-db"
@@ -27,6 +27,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"net/url"
 	"sort"
 	"strings"
@@ -120,7 +121,7 @@ func (state NodeReplicaState) PodHasReplicas(cloud *solr.SolrCloud, podName stri
 	return isInClusterState && contents.replicas > 0
 }
 
-func GetNodeReplicaState(ctx context.Context, cloud *solr.SolrCloud, statefulSet *appsv1.StatefulSet, hasReadyPod bool, logger logr.Logger) (state NodeReplicaState, retryLater bool, err error) {
+func GetNodeReplicaState(ctx context.Context, cloud *solr.SolrCloud, statefulSets []*appsv1.StatefulSet, hasReadyPod bool, logger logr.Logger) (state NodeReplicaState, retryLater bool, err error) {
 	clusterResp := &solr_api.SolrClusterStatusResponse{}
 	overseerResp := &solr_api.SolrOverseerStatusResponse{}
 
@@ -139,7 +140,7 @@ func GetNodeReplicaState(ctx context.Context, cloud *solr.SolrCloud, statefulSet
 			}
 		}
 		if err == nil {
-			state = findSolrNodeContents(clusterResp.ClusterStatus, overseerResp.Leader, GetManagedSolrNodeNames(cloud, int(*statefulSet.Spec.Replicas)))
+			state = findSolrNodeContents(clusterResp.ClusterStatus, overseerResp.Leader, GetManagedSolrNodeNames(cloud, statefulSets))
 		} else {
 			logger.Error(err, "Could not fetch cluster state information for cloud")
 		}
@@ -536,8 +537,9 @@ func GetAllManagedSolrNodeNames(solrCloud *solr.SolrCloud) map[string]bool {
 	return allNodeNames
 }
 
-func GetManagedSolrNodeNames(solrCloud *solr.SolrCloud, currentlyConfiguredPodCount int) map[string]bool {
-	podNames := solrCloud.GetSolrPodNames(currentlyConfiguredPodCount)
+// GetManagedSolrNodeNames returns the Solr nodeNames for the pods that are currently configured in the StatefulSets of the SolrCloud's node pools
+func GetManagedSolrNodeNames(solrCloud *solr.SolrCloud, statefulSets []*appsv1.StatefulSet) map[string]bool {
+	podNames := GetStatefulSetPodNames(statefulSets)
 	allNodeNames := make(map[string]bool, len(podNames))
 	for _, podName := range podNames {
 		allNodeNames[SolrNodeName(solrCloud, podName)] = true
@@ -545,14 +547,27 @@ func GetManagedSolrNodeNames(solrCloud *solr.SolrCloud, currentlyConfiguredPodCo
 	return allNodeNames
 }
 
+// GetStatefulSetPodNames returns the names of the pods that are currently configured in the given StatefulSets
+func GetStatefulSetPodNames(statefulSets []*appsv1.StatefulSet) (podNames []string) {
+	for _, statefulSet := range statefulSets {
+		if statefulSet.Spec.Replicas == nil {
+			continue
+		}
+		for i := 0; i < int(*statefulSet.Spec.Replicas); i++ {
+			podNames = append(podNames, fmt.Sprintf("%s-%d", statefulSet.Name, i))
+		}
+	}
+	return podNames
+}
+
 // EvictReplicasForPodIfNecessary takes a solr Pod and migrates all replicas off of that Pod.
 // For updates this will only be called for pods using ephemeral data.
 // For scale-down operations, this can be called for pods using ephemeral or persistent data.
-func EvictReplicasForPodIfNecessary(ctx context.Context, solrCloud *solr.SolrCloud, pod *corev1.Pod, podHasReplicas bool, evictionReason string, logger logr.Logger) (err error, canDeletePod bool, requestInProgress bool) {
+func EvictReplicasForPodIfNecessary(ctx context.Context, solrCloud *solr.SolrCloud, pod *corev1.Pod, podHasReplicas bool, evictionReason string, recorder record.EventRecorder, logger logr.Logger) (err error, canDeletePod bool, requestInProgress bool) {
 	logger = logger.WithValues("evictionReason", evictionReason)
 	// If the Cloud has 1 or zero pods, and this is the "-0" pod, then delete the data since we can't move it anywhere else
 	// Otherwise, move the replicas to other pods
-	if (solrCloud.Spec.Replicas == nil || *solrCloud.Spec.Replicas < 2) && strings.HasSuffix(pod.Name, "-0") {
+	if solrCloud.TotalReplicas() < 2 && strings.HasSuffix(pod.Name, "-0") {
 		queryParams := url.Values{}
 		queryParams.Add("action", "DELETENODE")
 		queryParams.Add("node", SolrNodeName(solrCloud, pod.Name))
@@ -581,9 +596,11 @@ func EvictReplicasForPodIfNecessary(ctx context.Context, solrCloud *solr.SolrClo
 				}
 				if err == nil {
 					logger.Info("Migrating all replicas off of pod before deletion.", "requestId", requestId, "pod", pod.Name)
+					recorder.Eventf(solrCloud, corev1.EventTypeNormal, EventReasonEvictingReplicas, "Migrating all replicas off of pod %s before deletion, reason: %s", pod.Name, evictionReason)
 					requestInProgress = true
 				} else {
 					logger.Error(err, "Could not migrate all replicas off of pod before deletion. Will try again.")
+					recorder.Eventf(solrCloud, corev1.EventTypeWarning, EventReasonEvictReplicasFailed, "Could not migrate replicas off of pod %s, will try again: %s", pod.Name, err.Error())
 				}
 			} else {
 				canDeletePod = true
@@ -595,8 +612,10 @@ func EvictReplicasForPodIfNecessary(ctx context.Context, solrCloud *solr.SolrClo
 			if asyncState == "completed" {
 				canDeletePod = true
 				logger.Info("Migration of all replicas off of pod before deletion complete. Pod can now be deleted.", "pod", pod.Name)
+				recorder.Eventf(solrCloud, corev1.EventTypeNormal, EventReasonReplicasEvicted, "Migrated all replicas off of pod %s", pod.Name)
 			} else if asyncState == "failed" {
 				logger.Info("Migration of all replicas off of pod before deletion failed. Will try again.", "pod", pod.Name, "message", message)
+				recorder.Eventf(solrCloud, corev1.EventTypeWarning, EventReasonEvictReplicasFailed, "Migration of replicas off of pod %s failed, will try again: %s", pod.Name, message)
 			} else {
 				requestInProgress = true
 			}
@@ -42,12 +42,14 @@ const (
 	DefaultSolrGroup = 8983
 
 	SolrStorageFinalizer             = "storage.finalizers.solr.apache.org"
+	SolrBackupDataFinalizer          = "backup-data.finalizers.solr.apache.org"
 	SolrZKConnectionStringAnnotation = "solr.apache.org/zkConnectionString"
 	SolrPVCTechnologyLabel           = "solr.apache.org/technology"
 	SolrCloudPVCTechnology           = "solr-cloud"
 	SolrPVCStorageLabel              = "solr.apache.org/storage"
 	SolrCloudPVCDataStorage          = "data"
 	SolrPVCInstanceLabel             = "solr.apache.org/instance"
+	SolrNodePoolLabel                = "solr.apache.org/nodePool"
 	SolrXmlMd5Annotation             = "solr.apache.org/solrXmlMd5"
 	SolrXmlFile                      = "solr.xml"
 	LogXmlMd5Annotation              = "solr.apache.org/logXmlMd5"
@@ -79,12 +81,32 @@ var (
 	DefaultSolrZKPrepInitContainerCPU        = resource.NewMilliQuantity(400, resource.DecimalExponent)
 )
 
-// GenerateStatefulSet returns a new appsv1.StatefulSet pointer generated for the SolrCloud instance
+// NodePoolSelector returns the selector for the pods, and therefore the PVCs, of a node pool of the SolrCloud.
+// Since the default node pool does not have a label, its selector excludes the pods of the other node pools instead.
+// The selector of a StatefulSet cannot be changed, so a default StatefulSet that was created without this requirement
+// is only recreated with it once the SolrCloud uses node pools.
+func NodePoolSelector(solrCloud *solr.SolrCloud, nodePoolName string) *metav1.LabelSelector {
+	selector := &metav1.LabelSelector{
+		MatchLabels: solrCloud.SharedLabels(),
+	}
+	selector.MatchLabels["technology"] = solr.SolrTechnologyLabel
+	if nodePoolName != "" {
+		selector.MatchLabels[SolrNodePoolLabel] = nodePoolName
+	} else {
+		selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+			{Key: SolrNodePoolLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+		}
+	}
+	return selector
+}
+
+// GenerateStatefulSet returns a new appsv1.StatefulSet pointer generated for a node pool of the SolrCloud instance
 // object: SolrCloud instance
+// nodePool: the node pool of the SolrCloud to generate the StatefulSet for, taken from solrCloud.AllNodePools()
 // replicas: the number of replicas for the SolrCloud instance
 // storage: the size of the storage for the SolrCloud instance (e.g. 100Gi)
 // zkConnectionString: the connectionString of the ZK instance to connect to
-func GenerateStatefulSet(solrCloud *solr.SolrCloud, solrCloudStatus *solr.SolrCloudStatus, hostNameIPs map[string]string, reconcileConfigInfo map[string]string, tls *TLSCerts, security *SecurityConfig) *appsv1.StatefulSet {
+func GenerateStatefulSet(solrCloud *solr.SolrCloud, nodePool solr.SolrNodePool, solrCloudStatus *solr.SolrCloudStatus, hostNameIPs map[string]string, reconcileConfigInfo map[string]string, tls *TLSCerts, security *SecurityConfig) *appsv1.StatefulSet {
 	terminationGracePeriod := int64(60)
 	shareProcessNamespace := false
 	solrPodPort := solrCloud.Spec.SolrAddressability.PodPort
@@ -101,10 +123,12 @@ func GenerateStatefulSet(solrCloud *solr.SolrCloud, solrCloudStatus *solr.SolrCl
 	defaultReadinessProbe := createDefaultProbeHandlerForPath(probeScheme, solrPodPort, DefaultReadinessProbePath)
 
 	labels := solrCloud.SharedLabelsWith(solrCloud.GetLabels())
-	selectorLabels := solrCloud.SharedLabels()
-
 	labels["technology"] = solr.SolrTechnologyLabel
-	selectorLabels["technology"] = solr.SolrTechnologyLabel
+
+	// The default node pool does not have a label, so that the labels of its pods do not change for existing SolrClouds
+	if nodePool.Name != "" {
+		labels[SolrNodePoolLabel] = nodePool.Name
+	}
 
 	annotations := map[string]string{
 		SolrZKConnectionStringAnnotation: solrCloudStatus.ZkConnectionString(),
@@ -118,7 +142,7 @@ func GenerateStatefulSet(solrCloud *solr.SolrCloud, solrCloudStatus *solr.SolrCl
 		annotations = MergeLabelsOrAnnotations(annotations, customSSOptions.Annotations)
 	}
 
-	customPodOptions := solrCloud.Spec.CustomSolrKubeOptions.PodOptions.DeepCopy()
+	customPodOptions := nodePool.PodOptions.DeepCopy()
 	var podAnnotations map[string]string
 	if nil != customPodOptions {
 		podLabels = MergeLabelsOrAnnotations(podLabels, customPodOptions.Labels)
@@ -178,11 +202,11 @@ func GenerateStatefulSet(solrCloud *solr.SolrCloud, solrCloudStatus *solr.SolrCl
 		},
 	}
 
-	solrDataVolumeName := solrCloud.DataVolumeName()
+	solrDataVolumeName := nodePool.DataVolumeName()
 
 	var pvcs []corev1.PersistentVolumeClaim
-	if solrCloud.UsesPersistentStorage() {
-		pvc := solrCloud.Spec.StorageOptions.PersistentStorage.PersistentVolumeClaimTemplate.DeepCopy()
+	if nodePool.UsesPersistentStorage() {
+		pvc := nodePool.StorageOptions.PersistentStorage.PersistentVolumeClaimTemplate.DeepCopy()
 
 		// Set the default name of the pvc
 		pvc.ObjectMeta.Name = solrDataVolumeName
@@ -221,11 +245,11 @@ func GenerateStatefulSet(solrCloud *solr.SolrCloud, solrCloudStatus *solr.SolrCl
 			Name:         solrDataVolumeName,
 			VolumeSource: corev1.VolumeSource{},
 		}
-		if solrCloud.Spec.StorageOptions.EphemeralStorage != nil {
-			if nil != solrCloud.Spec.StorageOptions.EphemeralStorage.HostPath {
-				ephemeralVolume.VolumeSource.HostPath = solrCloud.Spec.StorageOptions.EphemeralStorage.HostPath
-			} else if nil != solrCloud.Spec.StorageOptions.EphemeralStorage.EmptyDir {
-				ephemeralVolume.VolumeSource.EmptyDir = solrCloud.Spec.StorageOptions.EphemeralStorage.EmptyDir
+		if nodePool.StorageOptions != nil && nodePool.StorageOptions.EphemeralStorage != nil {
+			if nil != nodePool.StorageOptions.EphemeralStorage.HostPath {
+				ephemeralVolume.VolumeSource.HostPath = nodePool.StorageOptions.EphemeralStorage.HostPath
+			} else if nil != nodePool.StorageOptions.EphemeralStorage.EmptyDir {
+				ephemeralVolume.VolumeSource.EmptyDir = nodePool.StorageOptions.EphemeralStorage.EmptyDir
 			} else {
 				ephemeralVolume.VolumeSource.EmptyDir = &corev1.EmptyDirVolumeSource{}
 			}
@@ -446,6 +470,18 @@ func GenerateStatefulSet(solrCloud *solr.SolrCloud, solrCloudStatus *solr.SolrCl
 		podAnnotations[SolrXmlMd5Annotation] = reconcileConfigInfo[SolrXmlMd5Annotation]
 	}
 
+	// Pass the labels of the node pool to Solr as system properties, so that they can be used for replica placement
+	if len(nodePool.NodeLabels) > 0 {
+		nodeLabelKeys := make([]string, 0, len(nodePool.NodeLabels))
+		for key := range nodePool.NodeLabels {
+			nodeLabelKeys = append(nodeLabelKeys, key)
+		}
+		sort.Strings(nodeLabelKeys)
+		for _, key := range nodeLabelKeys {
+			allSolrOpts = append(allSolrOpts, fmt.Sprintf("-D%s=%s", key, nodePool.NodeLabels[key]))
+		}
+	}
+
 	if solrCloud.Spec.SolrOpts != "" {
 		allSolrOpts = append(allSolrOpts, solrCloud.Spec.SolrOpts)
 	}
@@ -456,7 +492,7 @@ func GenerateStatefulSet(solrCloud *solr.SolrCloud, solrCloudStatus *solr.SolrCl
 		Value: strings.Join(allSolrOpts, " "),
 	})
 
-	initContainers := generateSolrSetupInitContainers(solrCloud, solrCloudStatus, solrDataVolumeName, security)
+	initContainers := generateSolrSetupInitContainers(solrCloud, solrCloudStatus, nodePool.PodOptions, solrDataVolumeName, security)
 
 	// Add user defined additional init containers
 	if customPodOptions != nil && len(customPodOptions.InitContainers) > 0 {
@@ -536,17 +572,15 @@ func GenerateStatefulSet(solrCloud *solr.SolrCloud, solrCloudStatus *solr.SolrCl
 	// Create the Stateful Set
 	stateful := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        solrCloud.StatefulSetName(),
+			Name:        solrCloud.NodePoolStatefulSetName(nodePool.Name),
 			Namespace:   solrCloud.GetNamespace(),
 			Labels:      labels,
 			Annotations: annotations,
 		},
 		Spec: appsv1.StatefulSetSpec{
-			Selector: &metav1.LabelSelector{
-				MatchLabels: selectorLabels,
-			},
+			Selector:            NodePoolSelector(solrCloud, nodePool.Name),
 			ServiceName:         solrCloud.HeadlessServiceName(),
-			Replicas:            solrCloud.Spec.Replicas,
+			Replicas:            nodePool.Replicas,
 			PodManagementPolicy: podManagementPolicy,
 			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
 				Type: updateStrategy,
@@ -704,7 +738,7 @@ func MaintainPreservedStatefulSetFields(expected, found *appsv1.StatefulSet) {
 	expected.Spec.Replicas = found.Spec.Replicas
 }
 
-func generateSolrSetupInitContainers(solrCloud *solr.SolrCloud, solrCloudStatus *solr.SolrCloudStatus, solrDataVolumeName string, security *SecurityConfig) (containers []corev1.Container) {
+func generateSolrSetupInitContainers(solrCloud *solr.SolrCloud, solrCloudStatus *solr.SolrCloudStatus, customPodOptions *solr.PodOptions, solrDataVolumeName string, security *SecurityConfig) (containers []corev1.Container) {
 	// The setup of the solr.xml will always be necessary
 	volumeMounts := []corev1.VolumeMount{
 		{
@@ -725,8 +759,8 @@ func generateSolrSetupInitContainers(solrCloud *solr.SolrCloud, solrCloudStatus
 	// Only add a user to the initContainer if one isn't provided in the podSecurityContext
 	// This is so that we can check if the backupDir is writable given the default user (since no user is provided)
 	addUserToInitContainer := true
-	if solrCloud.Spec.CustomSolrKubeOptions.PodOptions != nil {
-		solrPodSecurityContext := solrCloud.Spec.CustomSolrKubeOptions.PodOptions.PodSecurityContext
+	if customPodOptions != nil {
+		solrPodSecurityContext := customPodOptions.PodSecurityContext
 
 		if solrPodSecurityContext != nil {
 			if solrPodSecurityContext.RunAsUser != nil {
@@ -793,7 +827,6 @@ func generateSolrSetupInitContainers(solrCloud *solr.SolrCloud, solrCloudStatus
 	}
 
 	// If the user has provided custom resources for the default init containers, use them
-	customPodOptions := solrCloud.Spec.CustomSolrKubeOptions.PodOptions
 	if nil != customPodOptions {
 		resources := customPodOptions.DefaultInitContainerResources
 		if resources.Limits != nil || resources.Requests != nil {
@@ -944,6 +977,17 @@ func GenerateCommonService(solrCloud *solr.SolrCloud) *corev1.Service {
 		annotations = MergeLabelsOrAnnotations(annotations, customOptions.Annotations)
 	}
 
+	// Expose the common service through a LoadBalancer if necessary
+	serviceType := corev1.ServiceTypeClusterIP
+	var loadBalancerSourceRanges []string
+	if extOpts != nil && extOpts.Method == solr.LoadBalancer && !extOpts.HideCommon {
+		serviceType = corev1.ServiceTypeLoadBalancer
+		if extOpts.LoadBalancer != nil {
+			loadBalancerSourceRanges = extOpts.LoadBalancer.LoadBalancerSourceRanges
+			annotations = MergeLabelsOrAnnotations(extOpts.LoadBalancer.CommonServiceAnnotations, annotations)
+		}
+	}
+
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        solrCloud.CommonServiceName(),
@@ -961,7 +1005,9 @@ func GenerateCommonService(solrCloud *solr.SolrCloud) *corev1.Service {
 					AppProtocol: getAppProtocol(solrCloud),
 				},
 			},
-			Selector: selectorLabels,
+			Selector:                 selectorLabels,
+			Type:                     serviceType,
+			LoadBalancerSourceRanges: loadBalancerSourceRanges,
 		},
 	}
 	return service
@@ -1041,6 +1087,18 @@ func GenerateNodeService(solrCloud *solr.SolrCloud, nodeName string) *corev1.Ser
 		annotations = MergeLabelsOrAnnotations(annotations, customOptions.Annotations)
 	}
 
+	// Expose the node service through a LoadBalancer if necessary
+	serviceType := corev1.ServiceTypeClusterIP
+	var loadBalancerSourceRanges []string
+	extOpts := solrCloud.Spec.SolrAddressability.External
+	if extOpts != nil && extOpts.Method == solr.LoadBalancer && !extOpts.HideNodes {
+		serviceType = corev1.ServiceTypeLoadBalancer
+		if extOpts.LoadBalancer != nil {
+			loadBalancerSourceRanges = extOpts.LoadBalancer.LoadBalancerSourceRanges
+			annotations = MergeLabelsOrAnnotations(extOpts.LoadBalancer.NodeServiceAnnotations[nodeName], annotations)
+		}
+	}
+
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        nodeName,
@@ -1060,6 +1118,8 @@ func GenerateNodeService(solrCloud *solr.SolrCloud, nodeName string) *corev1.Ser
 				},
 			},
 			PublishNotReadyAddresses: true,
+			Type:                     serviceType,
+			LoadBalancerSourceRanges: loadBalancerSourceRanges,
 		},
 	}
 	return service
@@ -1380,23 +1440,20 @@ func setupVolumeMountForUserProvidedConfigMapEntry(reconcileConfigInfo map[strin
 
 func GetCustomProbePaths(solrCloud *solr.SolrCloud) []string {
 	probePaths := []string{}
+	foundPaths := map[string]bool{}
 
-	podOptions := solrCloud.Spec.CustomSolrKubeOptions.PodOptions
-	if podOptions == nil {
-		return probePaths
-	}
-
-	// include any custom paths
-	if podOptions.ReadinessProbe != nil && podOptions.ReadinessProbe.HTTPGet != nil {
-		probePaths = append(probePaths, podOptions.ReadinessProbe.HTTPGet.Path)
-	}
-
-	if podOptions.LivenessProbe != nil && podOptions.LivenessProbe.HTTPGet != nil {
-		probePaths = append(probePaths, podOptions.LivenessProbe.HTTPGet.Path)
-	}
-
-	if podOptions.StartupProbe != nil && podOptions.StartupProbe.HTTPGet != nil {
-		probePaths = append(probePaths, podOptions.StartupProbe.HTTPGet.Path)
+	// include any custom paths, from the pod options of every node pool
+	for _, nodePool := range solrCloud.AllNodePools() {
+		podOptions := nodePool.PodOptions
+		if podOptions == nil {
+			continue
+		}
+		for _, probe := range []*corev1.Probe{podOptions.ReadinessProbe, podOptions.LivenessProbe, podOptions.StartupProbe} {
+			if probe != nil && probe.HTTPGet != nil && !foundPaths[probe.HTTPGet.Path] {
+				foundPaths[probe.HTTPGet.Path] = true
+				probePaths = append(probePaths, probe.HTTPGet.Path)
+			}
+		}
 	}
 
 	return probePaths
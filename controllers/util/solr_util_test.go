@@ -19,8 +19,11 @@ package util
 
 import (
 	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"testing"
 )
 
@@ -223,3 +226,93 @@ func TestGenerateSolrXMLStringForCloud(t *testing.T) {
 	}
 	assert.Containsf(t, GenerateSolrXMLStringForCloud(solrCloud), "<str name=\"sharedLib\">${solr.sharedLib:},/ext/lib1,/ext/lib2</str>", "Wrong sharedLib xml for a cloud with a just additionalLibs")
 }
+
+func TestGenerateStatefulSetForNodePool(t *testing.T) {
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solr.SolrCloudSpec{
+			SolrOpts: "-Dsolr.custom=true",
+			NodePools: []solr.SolrNodePool{
+				{
+					Name:       "pull",
+					Replicas:   pointer.Int32(2),
+					NodeLabels: map[string]string{"replica_type": "PULL", "node_type": "hot"},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+	solrCloud.Status.ZookeeperConnectionInfo = solr.ZookeeperConnectionInfo{InternalConnectionString: "zk:2181", ChRoot: "/"}
+	nodePools := solrCloud.AllNodePools()
+	if !assert.Len(t, nodePools, 2, "The default node pool should be returned along with the additional node pool") {
+		return
+	}
+	assert.Equal(t, int32(5), solrCloud.TotalReplicas(), "The total replicas should include all node pools")
+
+	defaultStatefulSet := GenerateStatefulSet(solrCloud, nodePools[0], &solrCloud.Status, nil, map[string]string{}, nil, nil)
+	assert.Equal(t, "foo-solrcloud", defaultStatefulSet.Name, "The default node pool should use the original StatefulSet name")
+	assert.NotContains(t, defaultStatefulSet.Spec.Selector.MatchLabels, SolrNodePoolLabel, "The default node pool should not change the labels of the original StatefulSet selector")
+	assert.Equal(t, []metav1.LabelSelectorRequirement{{Key: SolrNodePoolLabel, Operator: metav1.LabelSelectorOpDoesNotExist}}, defaultStatefulSet.Spec.Selector.MatchExpressions, "The default node pool should not select the pods of the other node pools")
+
+	poolStatefulSet := GenerateStatefulSet(solrCloud, nodePools[1], &solrCloud.Status, nil, map[string]string{}, nil, nil)
+	assert.Equal(t, "foo-solrcloud-pull", poolStatefulSet.Name, "Wrong StatefulSet name for the node pool")
+	assert.Equal(t, int32(2), *poolStatefulSet.Spec.Replicas, "Wrong number of replicas for the node pool StatefulSet")
+	assert.Equal(t, "pull", poolStatefulSet.Spec.Selector.MatchLabels[SolrNodePoolLabel], "The node pool should be part of the StatefulSet selector")
+	assert.Empty(t, poolStatefulSet.Spec.Selector.MatchExpressions, "The node pool should only select the pods of its node pool by label")
+	assert.Equal(t, "pull", poolStatefulSet.Spec.Template.Labels[SolrNodePoolLabel], "The node pool should be part of the pod labels")
+	for _, envVar := range poolStatefulSet.Spec.Template.Spec.Containers[0].Env {
+		if envVar.Name == "SOLR_OPTS" {
+			assert.Contains(t, envVar.Value, "-Dnode_type=hot -Dreplica_type=PULL -Dsolr.custom=true", "The node labels should be passed as sorted system properties, before the custom solrOpts")
+		}
+	}
+
+	assert.Equal(t, []string{"foo-solrcloud-0", "foo-solrcloud-1", "foo-solrcloud-2", "foo-solrcloud-pull-0", "foo-solrcloud-pull-1"}, solrCloud.GetAllSolrPodNames(), "All pod names should include every node pool")
+}
+
+func TestGenerateLoadBalancerServices(t *testing.T) {
+	solrCloud := &solr.SolrCloud{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: solr.SolrCloudSpec{
+			SolrAddressability: solr.SolrAddressabilityOptions{
+				External: &solr.ExternalAddressability{
+					Method:             solr.LoadBalancer,
+					DomainName:         "example.com",
+					UseExternalAddress: true,
+					LoadBalancer: &solr.SolrLoadBalancerOptions{
+						LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+						CommonServiceAnnotations: map[string]string{"metallb.universe.tf/loadBalancerIPs": "10.1.0.1"},
+						NodeServiceAnnotations: map[string]map[string]string{
+							"foo-solrcloud-0": {"metallb.universe.tf/loadBalancerIPs": "10.1.0.2"},
+						},
+					},
+				},
+			},
+		},
+	}
+	solrCloud.WithDefaults(logr.Discard())
+
+	commonService := GenerateCommonService(solrCloud)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, commonService.Spec.Type, "The common service should be a LoadBalancer")
+	assert.Equal(t, []string{"10.0.0.0/8"}, commonService.Spec.LoadBalancerSourceRanges, "Wrong loadBalancerSourceRanges for the common service")
+	assert.Equal(t, "10.1.0.1", commonService.Annotations["metallb.universe.tf/loadBalancerIPs"], "Wrong static IP annotation for the common service")
+
+	nodeService := GenerateNodeService(solrCloud, "foo-solrcloud-0")
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, nodeService.Spec.Type, "The node service should be a LoadBalancer")
+	assert.Equal(t, []string{"10.0.0.0/8"}, nodeService.Spec.LoadBalancerSourceRanges, "Wrong loadBalancerSourceRanges for the node service")
+	assert.Equal(t, "10.1.0.2", nodeService.Annotations["metallb.universe.tf/loadBalancerIPs"], "Wrong static IP annotation for the node service")
+	assert.NotContains(t, GenerateNodeService(solrCloud, "foo-solrcloud-1").Annotations, "metallb.universe.tf/loadBalancerIPs", "Node annotations should only be added to the service of that node")
+
+	assert.Equal(t, "default-foo-solrcloud-0.example.com", solrCloud.AdvertisedNodeHost("foo-solrcloud-0"), "Wrong advertised host for a LoadBalancer node")
+	assert.Equal(t, "default-foo-solrcloud-0.example.com:8983", solrCloud.ExternalNodeUrl("foo-solrcloud-0", "example.com", true), "The external node url of a LoadBalancer should include the node port")
+
+	// Kubernetes allocates nodePorts for LoadBalancer services, these should not cause constant updates
+	foundService := nodeService.DeepCopy()
+	foundService.Spec.Ports[0].NodePort = 30001
+	assert.False(t, CopyServiceFields(GenerateNodeService(solrCloud, "foo-solrcloud-0"), foundService, logr.Discard()), "Allocated nodePorts should not require an update")
+	assert.Equal(t, int32(30001), foundService.Spec.Ports[0].NodePort, "Allocated nodePorts should be kept")
+
+	solrCloud.Spec.SolrAddressability.External.HideCommon = true
+	assert.Equal(t, corev1.ServiceTypeClusterIP, GenerateCommonService(solrCloud).Spec.Type, "A hidden common service should not be a LoadBalancer")
+	assert.True(t, CopyServiceFields(GenerateCommonService(solrCloud), commonService, logr.Discard()), "Changing the service type should require an update")
+	assert.Equal(t, corev1.ServiceTypeClusterIP, commonService.Spec.Type, "The service type should be updated")
+}
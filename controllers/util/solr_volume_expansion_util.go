@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// DataVolumeClaimSize returns the storage size requested by the data volumeClaimTemplate of the StatefulSet.
+// If the StatefulSet does not use persistent data storage, then hasPersistentData will be false.
+func DataVolumeClaimSize(statefulSet *appsv1.StatefulSet) (size resource.Quantity, hasPersistentData bool) {
+	if len(statefulSet.Spec.VolumeClaimTemplates) == 0 {
+		return size, false
+	}
+	size, hasPersistentData = statefulSet.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage]
+	return size, hasPersistentData
+}
+
+// DataVolumeClaimName returns the name of the data PVC that the StatefulSet creates for the given pod.
+func DataVolumeClaimName(statefulSet *appsv1.StatefulSet, podName string) string {
+	if len(statefulSet.Spec.VolumeClaimTemplates) == 0 {
+		return ""
+	}
+	return statefulSet.Spec.VolumeClaimTemplates[0].Name + "-" + podName
+}
+
+// PVCNeedsExpansion determines whether the PVC requests less storage than the given size.
+func PVCNeedsExpansion(pvc *corev1.PersistentVolumeClaim, size resource.Quantity) bool {
+	requested, hasRequest := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	return !hasRequest || requested.Cmp(size) < 0
+}
+
+// GetVolumeResizeStatus returns the progress of the expansion of the PVC, using the conditions set by Kubernetes and the storage provider.
+// Nil is returned if the PVC is not being expanded.
+func GetVolumeResizeStatus(pvc *corev1.PersistentVolumeClaim) *solr.SolrVolumeResizeStatus {
+	requested, hasRequest := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !hasRequest {
+		return nil
+	}
+	status := &solr.SolrVolumeResizeStatus{
+		PersistentVolumeClaim: pvc.Name,
+		RequestedSize:         requested,
+	}
+	capacity, hasCapacity := pvc.Status.Capacity[corev1.ResourceStorage]
+	if hasCapacity {
+		status.Capacity = &capacity
+	}
+
+	// If multiple conditions exist, use the one that is furthest from the expansion being complete
+	statePriority := map[solr.VolumeResizeState]int{
+		"":                                 0,
+		solr.VolumeResizeFileSystemPending: 1,
+		solr.VolumeResizeInProgress:        2,
+		solr.VolumeResizeFailed:            3,
+	}
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		var state solr.VolumeResizeState
+		switch condition.Type {
+		case corev1.PersistentVolumeClaimControllerResizeError, corev1.PersistentVolumeClaimNodeResizeError:
+			state = solr.VolumeResizeFailed
+		case corev1.PersistentVolumeClaimResizing:
+			state = solr.VolumeResizeInProgress
+		case corev1.PersistentVolumeClaimFileSystemResizePending:
+			state = solr.VolumeResizeFileSystemPending
+		default:
+			continue
+		}
+		if statePriority[state] > statePriority[status.State] {
+			status.State = state
+			status.Message = condition.Message
+		}
+	}
+
+	// A PVC without a capacity has not been bound yet, so it is not being resized
+	if status.State == "" {
+		if !hasCapacity || capacity.Cmp(requested) >= 0 {
+			return nil
+		}
+		status.State = solr.VolumeResizePending
+	}
+	return status
+}
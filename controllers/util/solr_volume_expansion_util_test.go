@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestGetVolumeResizeStatus(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-foo-solrcloud-0"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+		},
+	}
+	assert.Nil(t, GetVolumeResizeStatus(pvc), "A PVC with the requested capacity is not being resized")
+	assert.False(t, PVCNeedsExpansion(pvc, resource.MustParse("20Gi")), "A PVC with the requested size does not need to be expanded")
+	assert.True(t, PVCNeedsExpansion(pvc, resource.MustParse("30Gi")), "A PVC with a smaller size needs to be expanded")
+
+	pvc.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("10Gi")
+	status := GetVolumeResizeStatus(pvc)
+	if assert.NotNil(t, status, "A PVC with less capacity than requested is being resized") {
+		assert.Equal(t, solr.VolumeResizePending, status.State, "Wrong state for a resize without conditions")
+		assert.Equal(t, "data-foo-solrcloud-0", status.PersistentVolumeClaim, "Wrong PVC name")
+		assert.Equal(t, "10Gi", status.Capacity.String(), "Wrong capacity")
+		assert.Equal(t, "20Gi", status.RequestedSize.String(), "Wrong requested size")
+	}
+
+	pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+		{Type: corev1.PersistentVolumeClaimResizing, Status: corev1.ConditionTrue, Message: "resizing"},
+	}
+	if status = GetVolumeResizeStatus(pvc); assert.NotNil(t, status, "A PVC with a Resizing condition is being resized") {
+		assert.Equal(t, solr.VolumeResizeInProgress, status.State, "Wrong state for a Resizing condition")
+		assert.Equal(t, "resizing", status.Message, "Wrong message for a Resizing condition")
+	}
+
+	pvc.Status.Conditions = append(pvc.Status.Conditions, corev1.PersistentVolumeClaimCondition{Type: corev1.PersistentVolumeClaimControllerResizeError, Status: corev1.ConditionTrue, Message: "quota exceeded"})
+	if status = GetVolumeResizeStatus(pvc); assert.NotNil(t, status, "A PVC with a resize error is being resized") {
+		assert.Equal(t, solr.VolumeResizeFailed, status.State, "Resize errors should take precedence over other conditions")
+		assert.Equal(t, "quota exceeded", status.Message, "Wrong message for a resize error")
+	}
+
+	// The file system is resized once the volume has the requested capacity
+	pvc.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("20Gi")
+	pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+		{Type: corev1.PersistentVolumeClaimFileSystemResizePending, Status: corev1.ConditionTrue},
+	}
+	if status = GetVolumeResizeStatus(pvc); assert.NotNil(t, status, "A PVC waiting for a file system resize is being resized") {
+		assert.Equal(t, solr.VolumeResizeFileSystemPending, status.State, "Wrong state for a FileSystemResizePending condition")
+	}
+}
@@ -0,0 +1,192 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"strings"
+	"time"
+)
+
+const (
+	SolrSnapshotPodLabel                = "solr.apache.org/pod"
+	SolrSnapshotOrdinalLabel            = "solr.apache.org/ordinal"
+	SolrSnapshotExpirationAnnotation    = "solr.apache.org/snapshotExpiration"
+	VolumeSnapshotAPIGroup              = "snapshot.storage.k8s.io"
+	VolumeSnapshotKind                  = "VolumeSnapshot"
+	volumeSnapshotNameUIDSuffixLength   = 8
+	volumeSnapshotPersistentVolumeClaim = "persistentVolumeClaimName"
+)
+
+// VolumeSnapshotGVK is the GroupVersionKind of CSI VolumeSnapshots.
+// The snapshot CRDs are not part of Kubernetes itself, so VolumeSnapshots are managed as unstructured objects.
+var VolumeSnapshotGVK = schema.GroupVersionKind{Group: VolumeSnapshotAPIGroup, Version: "v1", Kind: VolumeSnapshotKind}
+
+// DataVolumeSnapshotOptions returns the deletionSnapshots options for the data PVCs of the given node pool, or nil if snapshots are not enabled.
+// Node pools that have been removed from the SolrCloud use the options of the default node pool.
+func DataVolumeSnapshotOptions(solrCloud *solr.SolrCloud, nodePool string) *solr.SolrDataVolumeSnapshotOptions {
+	storageOptions := &solrCloud.Spec.StorageOptions
+	for _, pool := range solrCloud.AllNodePools() {
+		if pool.Name == nodePool {
+			storageOptions = pool.StorageOptions
+			break
+		}
+	}
+	if storageOptions == nil || storageOptions.PersistentStorage == nil {
+		return nil
+	}
+	return storageOptions.PersistentStorage.DeletionSnapshots
+}
+
+// DataVolumePodName returns the name of the Solr pod that the data PVC was created for, and the ordinal of that pod.
+func DataVolumePodName(solrCloud *solr.SolrCloud, pvc *corev1.PersistentVolumeClaim) (podName string, ordinal string) {
+	statefulSetName := solrCloud.NodePoolStatefulSetName(pvc.Labels[SolrNodePoolLabel])
+	if index := strings.Index(pvc.Name, "-"+statefulSetName+"-"); index >= 0 {
+		podName = pvc.Name[index+1:]
+	}
+	if index := strings.LastIndex(pvc.Name, "-"); index >= 0 {
+		ordinal = pvc.Name[index+1:]
+	}
+	return podName, ordinal
+}
+
+// DataVolumeSnapshotName returns the name of the VolumeSnapshot taken of the PVC before it is deleted.
+// The name includes part of the UID of the PVC, so that a PVC that is recreated with the same name gets a new snapshot.
+func DataVolumeSnapshotName(pvc *corev1.PersistentVolumeClaim) string {
+	uid := string(pvc.UID)
+	if len(uid) > volumeSnapshotNameUIDSuffixLength {
+		uid = uid[:volumeSnapshotNameUIDSuffixLength]
+	}
+	return pvc.Name + "-" + uid
+}
+
+// GenerateDataVolumeSnapshot returns a VolumeSnapshot of the data PVC, labeled with the SolrCloud, pod and ordinal that the PVC belongs to.
+// The snapshot is not owned by the SolrCloud, so that it outlives the SolrCloud if it is deleted.
+func GenerateDataVolumeSnapshot(solrCloud *solr.SolrCloud, pvc *corev1.PersistentVolumeClaim, options *solr.SolrDataVolumeSnapshotOptions, now time.Time) *unstructured.Unstructured {
+	podName, ordinal := DataVolumePodName(solrCloud, pvc)
+	labels := MergeLabelsOrAnnotations(map[string]string{
+		SolrPVCInstanceLabel:     solrCloud.Name,
+		SolrSnapshotPodLabel:     podName,
+		SolrSnapshotOrdinalLabel: ordinal,
+	}, pvc.Labels)
+
+	retentionHours := solr.DefaultDeletionSnapshotRetentionHours
+	if options.RetentionHours != nil {
+		retentionHours = *options.RetentionHours
+	}
+	expiration := now.Add(time.Duration(retentionHours) * time.Hour).UTC().Format(time.RFC3339)
+
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetName(DataVolumeSnapshotName(pvc))
+	snapshot.SetNamespace(pvc.Namespace)
+	snapshot.SetLabels(labels)
+	snapshot.SetAnnotations(map[string]string{
+		SolrSnapshotExpirationAnnotation: expiration,
+	})
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			volumeSnapshotPersistentVolumeClaim: pvc.Name,
+		},
+	}
+	if options.VolumeSnapshotClassName != "" {
+		spec["volumeSnapshotClassName"] = options.VolumeSnapshotClassName
+	}
+	snapshot.Object["spec"] = spec
+	return snapshot
+}
+
+// GetVolumeSnapshotState returns whether the VolumeSnapshot can be used to restore the data, and the error reported by the snapshot controller if taking the snapshot failed.
+func GetVolumeSnapshotState(snapshot *unstructured.Unstructured) (readyToUse bool, errorMessage string) {
+	readyToUse, _, _ = unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	if !readyToUse {
+		if _, hasError, _ := unstructured.NestedMap(snapshot.Object, "status", "error"); hasError {
+			errorMessage, _, _ = unstructured.NestedString(snapshot.Object, "status", "error", "message")
+			if errorMessage == "" {
+				errorMessage = "unknown error"
+			}
+		}
+	}
+	return readyToUse, errorMessage
+}
+
+// IsVolumeSnapshotExpired determines whether the retention period of a VolumeSnapshot, taken by the Solr Operator, has passed.
+// Snapshots without a valid expiration annotation never expire.
+func IsVolumeSnapshotExpired(snapshot *unstructured.Unstructured, now time.Time) bool {
+	expiration, hasExpiration := snapshot.GetAnnotations()[SolrSnapshotExpirationAnnotation]
+	if !hasExpiration {
+		return false
+	}
+	expirationTime, err := time.Parse(time.RFC3339, expiration)
+	return err == nil && !now.Before(expirationTime)
+}
+
+// LatestReadyVolumeSnapshot returns the most recent VolumeSnapshot taken of a data PVC of the given pod, which is ready to use and has not expired.
+// Nil is returned if no such snapshot exists.
+func LatestReadyVolumeSnapshot(snapshots []unstructured.Unstructured, podName string, now time.Time) (latest *unstructured.Unstructured) {
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+		if snapshot.GetLabels()[SolrSnapshotPodLabel] != podName || snapshot.GetDeletionTimestamp() != nil || IsVolumeSnapshotExpired(snapshot, now) {
+			continue
+		}
+		if readyToUse, _ := GetVolumeSnapshotState(snapshot); !readyToUse {
+			continue
+		}
+		if latest == nil || snapshot.GetCreationTimestamp().After(latest.GetCreationTimestamp().Time) {
+			latest = snapshot
+		}
+	}
+	return latest
+}
+
+// GenerateRestoredDataVolumeClaim returns the data PVC for the given pod of the StatefulSet, provisioned from the VolumeSnapshot.
+// The StatefulSet will use this PVC for the pod, instead of creating an empty one from its volumeClaimTemplate.
+func GenerateRestoredDataVolumeClaim(statefulSet *appsv1.StatefulSet, podName string, snapshot *unstructured.Unstructured) *corev1.PersistentVolumeClaim {
+	template := statefulSet.Spec.VolumeClaimTemplates[0]
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        DataVolumeClaimName(statefulSet, podName),
+			Namespace:   statefulSet.Namespace,
+			Labels:      MergeLabelsOrAnnotations(template.Labels, statefulSet.Spec.Selector.MatchLabels),
+			Annotations: template.Annotations,
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	apiGroup := VolumeSnapshotAPIGroup
+	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     VolumeSnapshotKind,
+		Name:     snapshot.GetName(),
+	}
+	// The restored volume cannot be smaller than the snapshot
+	if restoreSize, hasRestoreSize, _ := unstructured.NestedString(snapshot.Object, "status", "restoreSize"); hasRestoreSize {
+		if size, err := resource.ParseQuantity(restoreSize); err == nil && PVCNeedsExpansion(pvc, size) {
+			if pvc.Spec.Resources.Requests == nil {
+				pvc.Spec.Resources.Requests = corev1.ResourceList{}
+			}
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = size
+		}
+	}
+	return pvc
+}
@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	solr "github.com/apache/solr-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"testing"
+	"time"
+)
+
+func TestGenerateDataVolumeSnapshot(t *testing.T) {
+	solrCloud := &solr.SolrCloud{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "data-foo-solrcloud-search-3",
+			Namespace: "default",
+			UID:       "0123456789abcdef",
+			Labels:    map[string]string{SolrNodePoolLabel: "search", SolrPVCTechnologyLabel: SolrCloudPVCTechnology},
+		},
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	options := &solr.SolrDataVolumeSnapshotOptions{VolumeSnapshotClassName: "csi-snapclass", RetentionHours: pointer.Int32(24)}
+
+	snapshot := GenerateDataVolumeSnapshot(solrCloud, pvc, options, now)
+	assert.Equal(t, "data-foo-solrcloud-search-3-01234567", snapshot.GetName(), "Wrong snapshot name")
+	assert.Equal(t, VolumeSnapshotGVK, snapshot.GroupVersionKind(), "Wrong snapshot kind")
+	assert.Equal(t, "foo", snapshot.GetLabels()[SolrPVCInstanceLabel], "The snapshot must be labeled with the SolrCloud")
+	assert.Equal(t, "foo-solrcloud-search-3", snapshot.GetLabels()[SolrSnapshotPodLabel], "The snapshot must be labeled with the pod")
+	assert.Equal(t, "3", snapshot.GetLabels()[SolrSnapshotOrdinalLabel], "The snapshot must be labeled with the ordinal")
+	assert.Equal(t, "search", snapshot.GetLabels()[SolrNodePoolLabel], "The snapshot must keep the labels of the PVC")
+	assert.Equal(t, "2024-01-02T00:00:00Z", snapshot.GetAnnotations()[SolrSnapshotExpirationAnnotation], "Wrong snapshot expiration")
+	className, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+	assert.Equal(t, "csi-snapclass", className, "Wrong VolumeSnapshotClass")
+	source, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+	assert.Equal(t, pvc.Name, source, "The snapshot must be taken of the PVC")
+
+	assert.False(t, IsVolumeSnapshotExpired(snapshot, now.Add(23*time.Hour)), "The snapshot should not expire before the retention period")
+	assert.True(t, IsVolumeSnapshotExpired(snapshot, now.Add(24*time.Hour)), "The snapshot should expire after the retention period")
+}
+
+func TestGetVolumeSnapshotState(t *testing.T) {
+	snapshot := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	readyToUse, errorMessage := GetVolumeSnapshotState(snapshot)
+	assert.False(t, readyToUse, "A snapshot without a status is not ready")
+	assert.Empty(t, errorMessage, "A snapshot without a status has no error")
+
+	snapshot.Object["status"] = map[string]interface{}{"readyToUse": false, "error": map[string]interface{}{"message": "driver failure"}}
+	readyToUse, errorMessage = GetVolumeSnapshotState(snapshot)
+	assert.False(t, readyToUse, "A failed snapshot is not ready")
+	assert.Equal(t, "driver failure", errorMessage, "Wrong error message for a failed snapshot")
+
+	snapshot.Object["status"] = map[string]interface{}{"readyToUse": true}
+	readyToUse, errorMessage = GetVolumeSnapshotState(snapshot)
+	assert.True(t, readyToUse, "The snapshot should be ready")
+	assert.Empty(t, errorMessage, "A ready snapshot has no error")
+}
+
+func TestRestoreDataVolumeFromSnapshot(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshot := func(name string, podName string, created time.Time, ready bool, restoreSize string) unstructured.Unstructured {
+		s := unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{"readyToUse": ready, "restoreSize": restoreSize},
+		}}
+		s.SetName(name)
+		s.SetLabels(map[string]string{SolrSnapshotPodLabel: podName})
+		s.SetCreationTimestamp(metav1.Time{Time: created})
+		return s
+	}
+	snapshots := []unstructured.Unstructured{
+		snapshot("old", "foo-solrcloud-1", now.Add(-2*time.Hour), true, "10Gi"),
+		snapshot("latest", "foo-solrcloud-1", now.Add(-time.Hour), true, "20Gi"),
+		snapshot("not-ready", "foo-solrcloud-1", now, false, ""),
+		snapshot("other-pod", "foo-solrcloud-2", now, true, "10Gi"),
+	}
+
+	latest := LatestReadyVolumeSnapshot(snapshots, "foo-solrcloud-1", now)
+	if !assert.NotNil(t, latest, "A ready snapshot should be found for the pod") {
+		return
+	}
+	assert.Equal(t, "latest", latest.GetName(), "The most recent ready snapshot should be used")
+	assert.Nil(t, LatestReadyVolumeSnapshot(snapshots, "foo-solrcloud-3", now), "No snapshot should be found for a pod without snapshots")
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-solrcloud", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"solr-cloud": "foo"}},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "data", Labels: map[string]string{SolrPVCStorageLabel: SolrCloudPVCDataStorage}},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				},
+			},
+		},
+	}
+	pvc := GenerateRestoredDataVolumeClaim(statefulSet, "foo-solrcloud-1", latest)
+	assert.Equal(t, "data-foo-solrcloud-1", pvc.Name, "Wrong name for the restored PVC")
+	assert.Equal(t, "foo", pvc.Labels["solr-cloud"], "The restored PVC must have the labels of the StatefulSet selector")
+	assert.Equal(t, SolrCloudPVCDataStorage, pvc.Labels[SolrPVCStorageLabel], "The restored PVC must have the labels of the volumeClaimTemplate")
+	if assert.NotNil(t, pvc.Spec.DataSource, "The restored PVC must use the snapshot as its data source") {
+		assert.Equal(t, VolumeSnapshotKind, pvc.Spec.DataSource.Kind, "Wrong data source kind")
+		assert.Equal(t, "latest", pvc.Spec.DataSource.Name, "Wrong data source name")
+	}
+	assert.Equal(t, resource.MustParse("20Gi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage], "The restored PVC cannot be smaller than the snapshot")
+	assert.Equal(t, resource.MustParse("10Gi"), statefulSet.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage], "The StatefulSet must not be modified")
+}
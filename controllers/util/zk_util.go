@@ -27,6 +27,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// ZookeeperClientPort is the port that provided Zookeeper ensembles listen on for clients
+	ZookeeperClientPort = 2181
+)
+
 // GenerateZookeeperCluster returns a new ZookeeperCluster pointer generated for the SolrCloud instance
 // object: SolrCloud instance
 // zkSpec: the spec of the ZookeeperCluster to generate
@@ -56,7 +61,7 @@ func GenerateZookeeperCluster(solrCloud *solrv1beta1.SolrCloud, zkSpec *solrv1be
 			Ports: []corev1.ContainerPort{
 				{
 					Name:          "client",
-					ContainerPort: 2181,
+					ContainerPort: ZookeeperClientPort,
 				},
 				{
 					Name:          "quorum",
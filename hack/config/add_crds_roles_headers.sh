@@ -23,9 +23,9 @@ set -u
 
 echo "Add headers to CRDs and Role files"
 
-rm -f "${CONFIG_DIRECTORY:-config}"/crd/bases/*.tmp "${CONFIG_DIRECTORY:-config}"/rbac/role.yaml.tmp
+rm -f "${CONFIG_DIRECTORY:-config}"/crd/bases/*.tmp "${CONFIG_DIRECTORY:-config}"/rbac/role.yaml.tmp "${CONFIG_DIRECTORY:-config}"/webhook/manifests.yaml.tmp
 
-files=("${CONFIG_DIRECTORY:-config}"/crd/bases/* "${CONFIG_DIRECTORY:-config}"/rbac/role.yaml)
+files=("${CONFIG_DIRECTORY:-config}"/crd/bases/* "${CONFIG_DIRECTORY:-config}"/rbac/role.yaml "${CONFIG_DIRECTORY:-config}"/webhook/manifests.yaml)
 
 # Copy and package CRDs
 for file in "${files[@]}"; do
@@ -32,7 +32,10 @@ HELM_DIRECTORY="${HELM_DIRECTORY:-helm}"
   printf "\n"
   cat "${CONFIG_DIRECTORY}/crd/bases/solr.apache.org_solrbackups.yaml"
   cat "${CONFIG_DIRECTORY}/crd/bases/solr.apache.org_solrclouds.yaml"
+  cat "${CONFIG_DIRECTORY}/crd/bases/solr.apache.org_solrcollections.yaml"
+  cat "${CONFIG_DIRECTORY}/crd/bases/solr.apache.org_solrconfigsets.yaml"
   cat "${CONFIG_DIRECTORY}/crd/bases/solr.apache.org_solrprometheusexporters.yaml"
+  cat "${CONFIG_DIRECTORY}/crd/bases/solr.apache.org_solrrestores.yaml"
 } > "${HELM_DIRECTORY}/solr-operator/crds/crds.yaml"
 
 # Copy Kube Role for Solr Operator permissions to Helm
@@ -46,3 +49,17 @@ HELM_DIRECTORY="${HELM_DIRECTORY:-helm}"
     | sed -E 's/name: solr-operator-role$/name: {{ include "solr-operator\.fullname" \$ }}-role/'
   printf '\n{{- end }}\n{{- end }}\n'
 } > "${HELM_DIRECTORY}/solr-operator/templates/role.yaml"
+
+# Copy the admission webhook configurations to Helm
+# Template the webhook configurations as needed for Helm values
+{
+  cat hack/headers/header.yaml.txt
+  printf '\n\n{{- if .Values.webhooks.enabled }}\n'
+  cat "${CONFIG_DIRECTORY}/webhook/manifests.yaml" \
+    | awk '/^metadata:$/{print; print "  {{- if .Values.webhooks.certManager.enabled }}"; print "  annotations:"; print "    cert-manager.io/inject-ca-from: {{ .Release.Namespace }}/{{ include \"solr-operator.fullname\" . }}-webhook-cert"; print "  {{- end }}"; next}1' \
+    | awk '/^  clientConfig:$/{print; print "    {{- if not .Values.webhooks.certManager.enabled }}"; print "    caBundle: {{ required \"Must provide a webhooks.caBundle if webhooks.certManager.enabled is set to false\" .Values.webhooks.caBundle }}"; print "    {{- end }}"; next}1' \
+    | sed -E 's/name: (mutating|validating)-webhook-configuration$/name: {{ include "solr-operator\.fullname" . }}-\1-webhook-configuration/' \
+    | sed -E 's/name: webhook-service$/name: {{ include "solr-operator\.fullname" . }}-webhook/' \
+    | sed -E 's/namespace: system$/namespace: {{ .Release.Namespace }}/'
+  printf '{{- end }}\n'
+} > "${HELM_DIRECTORY}/solr-operator/templates/webhook_configurations.yaml"
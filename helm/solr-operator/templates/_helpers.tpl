@@ -107,6 +107,21 @@ mTLS vars
 rootSolrCert.pem
 {{- end -}}
 
+{{/*
+Webhook vars
+*/}}
+{{- define "solr-operator.webhooks.certDirectory" -}}
+/etc/ssl/solr-operator/webhook-cert
+{{- end -}}
+
+{{- define "solr-operator.webhooks.certSecret" -}}
+{{- if .Values.webhooks.certManager.enabled -}}
+{{ include "solr-operator.fullname" . }}-webhook-cert
+{{- else -}}
+{{ required "Must provide a webhooks.certSecret if webhooks.certManager.enabled is set to false" .Values.webhooks.certSecret }}
+{{- end -}}
+{{- end -}}
+
 {{- define "solr-operator.mTLS.volumeMounts" -}}
 {{- if .Values.mTLS.clientCertSecret -}}
 - name: tls-client-cert
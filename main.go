@@ -33,6 +33,9 @@ import (
 	"runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -63,6 +66,12 @@ var (
 
 	// External Operator dependencies
 	useZookeeperCRD bool
+	useGatewayAPI   bool
+
+	// Admission webhooks
+	enableWebhooks bool
+	webhookPort    int
+	webhookCertDir string
 
 	// mTLS information
 	clientSkipVerify  bool
@@ -86,11 +95,19 @@ func init() {
 	utilruntime.Must(solrv1beta1.AddToScheme(scheme))
 
 	utilruntime.Must(zkApi.AddToScheme(scheme))
+
+	utilruntime.Must(gatewayv1.Install(scheme))
+	utilruntime.Must(gatewayv1alpha2.Install(scheme))
 	//+kubebuilder:scaffold:scheme
 
 	flag.BoolVar(&useZookeeperCRD, "zk-operator", true, "The operator will not use the zk operator & crd when this flag is set to false.")
+	flag.BoolVar(&useGatewayAPI, "gateway-api", false, "Manage Gateway API HTTPRoutes and TLSRoutes for SolrClouds using the GatewayAPI external addressability method. Requires the Gateway API CRDs, including the experimental TLSRoute CRD, to be installed.")
 	flag.StringVar(&watchNamespaces, "watch-namespaces", "", "The comma-separated list of namespaces to watch. If an empty string (default) is provided, the operator will watch the entire Kubernetes cluster.")
 
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "Serve the defaulting and validating admission webhooks for SolrClouds, SolrBackups and SolrPrometheusExporters, and the pod eviction webhook for the Shard PodDisruptionBudget method. Requires a TLS certificate in the webhook-cert-dir.")
+	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port that the admission webhook server listens on.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "", "The directory containing the tls.crt and tls.key for the admission webhook server. Defaults to <temp-dir>/k8s-webhook-server/serving-certs")
+
 	flag.BoolVar(&clientSkipVerify, "tls-skip-verify-server", true, "Controls whether a client verifies the server's certificate chain and host name. If true (insecure), TLS accepts any certificate presented by the server and any host name in that certificate.")
 	flag.StringVar(&clientCertPath, "tls-client-cert-path", "", "Path where a TLS client cert can be found")
 	flag.StringVar(&clientCertKeyPath, "tls-client-cert-key-path", "", "Path where a TLS client cert key can be found")
@@ -145,6 +162,13 @@ func main() {
 		LeaderElectionID:        "88488bdc.solr.apache.org",
 	}
 
+	if enableWebhooks {
+		operatorOptions.WebhookServer = webhook.NewServer(webhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+		})
+	}
+
 	/*
 		When the operator is started to watch resources in a specific set of namespaces, we use DefaultNamespaces, which will build us a MultiNamespacedCache.
 		In this scenario, it is also suggested to restrict the provided authorization to this namespace by replacing the default
@@ -180,6 +204,8 @@ func main() {
 	}
 
 	controllers.UseZkCRD(useZookeeperCRD)
+	controllers.UseGatewayAPI(useGatewayAPI)
+	controllers.SetOperatorNamespace(namespace)
 
 	// watch TLS files for update
 	if clientCertPath != "" {
@@ -199,27 +225,75 @@ func main() {
 	}
 
 	if err = (&controllers.SolrCloudReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("solrcloud-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SolrCloud")
 		os.Exit(1)
 	}
 	if err = (&controllers.SolrPrometheusExporterReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("solrprometheusexporter-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SolrPrometheusExporter")
 		os.Exit(1)
 	}
 	if err = (&controllers.SolrBackupReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Config: mgr.GetConfig(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("solrbackup-controller"),
+		Config:   mgr.GetConfig(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SolrBackup")
 		os.Exit(1)
 	}
+	if err = (&controllers.SolrRestoreReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("solrrestore-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SolrRestore")
+		os.Exit(1)
+	}
+	if err = (&controllers.SolrCollectionReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("solrcollection-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SolrCollection")
+		os.Exit(1)
+	}
+	if err = (&controllers.SolrConfigSetReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("solrconfigset-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SolrConfigSet")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err = (&solrv1beta1.SolrCloud{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SolrCloud")
+			os.Exit(1)
+		}
+		if err = (&solrv1beta1.SolrPrometheusExporter{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SolrPrometheusExporter")
+			os.Exit(1)
+		}
+		if err = (&solrv1beta1.SolrBackup{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SolrBackup")
+			os.Exit(1)
+		}
+		if err = (&controllers.SolrPodEvictionWebhook{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor("solr-pod-eviction-webhook"),
+		}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "PodEviction")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {